@@ -45,10 +45,44 @@ type AvoConfig struct {
 	// Defaults to false
 	EnableVpcEndpointTemplateController *bool `json:"enableVpcEndpointTemplateController,omitempty"`
 
+	// VpcEndpointMaxConcurrentReconciles is the maximum number of VpcEndpoint CRs the VpcEndpoint controller
+	// reconciles concurrently.
+	// Defaults to 1
+	VpcEndpointMaxConcurrentReconciles *int `json:"vpcEndpointMaxConcurrentReconciles,omitempty"`
+
 	// EnablePrivateDns is a feature flag that allows VpcEndpoint CRs to use the enablePrivateDns field.
 	// When false, the enablePrivateDns field on VpcEndpoint CRs is ignored.
 	// Defaults to false
 	EnablePrivateDns *bool `json:"enablePrivateDns,omitempty"`
+
+	// EnableWebhookAWSValidation is a feature flag that allows the VpcEndpoint validating webhook to call AWS to check
+	// that the subnets and VPCs referenced by a VpcEndpoint exist and that its subnets are in distinct Availability Zones.
+	// Defaults to false
+	EnableWebhookAWSValidation *bool `json:"enableWebhookAWSValidation,omitempty"`
+
+	// DefaultTags are additional AWS tags applied to the AWS resources managed for every VpcEndpoint CR, e.g. for cost
+	// allocation. Tags with the same key in a VpcEndpoint CR's spec.tags take precedence.
+	DefaultTags map[string]string `json:"defaultTags,omitempty"`
+
+	// EnableGarbageCollector is a feature flag to determine whether AVO periodically looks for the AWS resources it
+	// manages for this cluster whose VpcEndpoint CR no longer exists, reporting them through metrics and events.
+	// Defaults to true
+	EnableGarbageCollector *bool `json:"enableGarbageCollector,omitempty"`
+
+	// GarbageCollectorDeleteOrphans allows the garbage collector to delete the orphaned AWS resources it finds.
+	// Defaults to false
+	GarbageCollectorDeleteOrphans *bool `json:"garbageCollectorDeleteOrphans,omitempty"`
+
+	// GarbageCollectorInterval is how often the garbage collector looks for orphaned AWS resources.
+	// Defaults to 1h
+	GarbageCollectorInterval *metav1.Duration `json:"garbageCollectorInterval,omitempty"`
+
+	// DryRun reconciles every VpcEndpoint CR in dry-run mode, as if it had the avo.openshift.io/dry-run annotation:
+	// the AWS changes AVO would make are recorded in the CR's status.plannedChanges instead of being applied. The
+	// garbage collector only reports orphaned AWS resources and the VpcEndpointAcceptance controller only reports the
+	// VPC Endpoint connections it would accept in dry-run mode.
+	// Defaults to false
+	DryRun *bool `json:"dryRun,omitempty"`
 }
 
 //+kubebuilder:object:root=true
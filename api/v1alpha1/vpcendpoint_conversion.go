@@ -0,0 +1,189 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+)
+
+// conversionData is the content of the v1alpha2.ConversionDataAnnotation, which preserves the v1alpha2 spec and status
+// of a VpcEndpoint while it is represented as v1alpha1
+type conversionData struct {
+	Spec   avov1alpha2.VpcEndpointSpec   `json:"spec"`
+	Status avov1alpha2.VpcEndpointStatus `json:"status"`
+}
+
+// ConvertTo converts this VpcEndpoint to the v1alpha2 hub version.
+// If the VpcEndpoint was previously converted from v1alpha2, the fields that can't be represented in v1alpha1 are
+// restored from the v1alpha2.ConversionDataAnnotation and the remaining fields are taken from this VpcEndpoint.
+func (src *VpcEndpoint) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*avov1alpha2.VpcEndpoint)
+	if !ok {
+		return fmt.Errorf("unsupported VpcEndpoint conversion hub %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	if data, ok := dst.Annotations[avov1alpha2.ConversionDataAnnotation]; ok {
+		delete(dst.Annotations, avov1alpha2.ConversionDataAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+
+		restored := new(conversionData)
+		if err := json.Unmarshal([]byte(data), restored); err != nil {
+			return fmt.Errorf("failed to parse the %s annotation: %w", avov1alpha2.ConversionDataAnnotation, err)
+		}
+		dst.Spec = restored.Spec
+		dst.Status = restored.Status
+	} else {
+		// v1alpha1 VpcEndpoints always used the cluster's subnets and, unless a separate hosted zone was requested
+		// via AddtlHostedZoneName, created a CNAME record with a 300s TTL in the cluster's Private Hosted Zone
+		dst.Spec = avov1alpha2.VpcEndpointSpec{
+			Vpc: avov1alpha2.Vpc{
+				AutoDiscoverSubnets: true,
+			},
+			CustomDns: avov1alpha2.CustomDns{
+				Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+					AutoDiscover: src.Spec.SubdomainName != "" && src.Spec.AddtlHostedZoneName == "",
+					Record: avov1alpha2.Route53HostedZoneRecord{
+						Type: avov1alpha2.Route53RecordTypeCNAME,
+						TTL:  300,
+					},
+				},
+			},
+		}
+		dst.Status = avov1alpha2.VpcEndpointStatus{}
+	}
+
+	dst.Spec.ServiceName = src.Spec.ServiceName
+	dst.Spec.SecurityGroup.IngressRules = convertSecurityGroupRulesTo(src.Spec.SecurityGroup.IngressRules, dst.Spec.SecurityGroup.IngressRules)
+	dst.Spec.SecurityGroup.EgressRules = convertSecurityGroupRulesTo(src.Spec.SecurityGroup.EgressRules, dst.Spec.SecurityGroup.EgressRules)
+	dst.Spec.CustomDns.Route53PrivateHostedZone.DomainName = src.Spec.AddtlHostedZoneName
+	dst.Spec.CustomDns.Route53PrivateHostedZone.Record.Hostname = src.Spec.SubdomainName
+	dst.Spec.CustomDns.Route53PrivateHostedZone.Record.ExternalNameService.Name = src.Spec.ExternalNameService.Name
+
+	dst.Status.Status = src.Status.Status
+	dst.Status.SecurityGroupId = src.Status.SecurityGroupId
+	dst.Status.VPCEndpointId = src.Status.VPCEndpointId
+	dst.Status.Conditions = src.Status.Conditions
+
+	return nil
+}
+
+// ConvertFrom converts from the v1alpha2 hub version to this VpcEndpoint.
+// When the v1alpha2 VpcEndpoint has fields that can't be represented in v1alpha1, its spec and status are stored in
+// the v1alpha2.ConversionDataAnnotation so that ConvertTo is lossless.
+func (dst *VpcEndpoint) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*avov1alpha2.VpcEndpoint)
+	if !ok {
+		return fmt.Errorf("unsupported VpcEndpoint conversion hub %T", srcRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	delete(dst.Annotations, avov1alpha2.ConversionDataAnnotation)
+
+	dst.Spec = VpcEndpointSpec{
+		ServiceName: src.Spec.ServiceName,
+		SecurityGroup: SecurityGroup{
+			IngressRules: convertSecurityGroupRulesFrom(src.Spec.SecurityGroup.IngressRules),
+			EgressRules:  convertSecurityGroupRulesFrom(src.Spec.SecurityGroup.EgressRules),
+		},
+		SubdomainName: src.Spec.CustomDns.Route53PrivateHostedZone.Record.Hostname,
+		ExternalNameService: ExternalNameServiceSpec{
+			Name: src.Spec.CustomDns.Route53PrivateHostedZone.Record.ExternalNameService.Name,
+		},
+		AddtlHostedZoneName: src.Spec.CustomDns.Route53PrivateHostedZone.DomainName,
+	}
+	dst.Status = VpcEndpointStatus{
+		Status:          src.Status.Status,
+		SecurityGroupId: src.Status.SecurityGroupId,
+		VPCEndpointId:   src.Status.VPCEndpointId,
+		Conditions:      src.Status.Conditions,
+	}
+
+	// Only annotate the VpcEndpoint when it would otherwise lose information, so that VpcEndpoints which only use
+	// v1alpha1 fields are unchanged by a round trip
+	roundTrip := new(avov1alpha2.VpcEndpoint)
+	if err := dst.ConvertTo(roundTrip); err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(roundTrip.Spec, src.Spec) && equality.Semantic.DeepEqual(roundTrip.Status, src.Status) {
+		return nil
+	}
+
+	data, err := json.Marshal(conversionData{Spec: src.Spec, Status: src.Status})
+	if err != nil {
+		return fmt.Errorf("failed to marshal the %s annotation: %w", avov1alpha2.ConversionDataAnnotation, err)
+	}
+	if dst.Annotations == nil {
+		dst.Annotations = map[string]string{}
+	}
+	dst.Annotations[avov1alpha2.ConversionDataAnnotation] = string(data)
+
+	return nil
+}
+
+// convertSecurityGroupRulesTo converts v1alpha1 security group rules to v1alpha2. The source of each rule, e.g. its
+// CidrIp, can't be represented in v1alpha1, so it's restored from the matching restored rule when no rules have been
+// added or removed.
+func convertSecurityGroupRulesTo(src []SecurityGroupRule, restored []avov1alpha2.SecurityGroupRule) []avov1alpha2.SecurityGroupRule {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]avov1alpha2.SecurityGroupRule, len(src))
+	for i, rule := range src {
+		dst[i] = avov1alpha2.SecurityGroupRule{
+			FromPort: rule.FromPort,
+			ToPort:   rule.ToPort,
+			Protocol: rule.Protocol,
+		}
+		if len(restored) == len(src) {
+			dst[i].CidrIp = restored[i].CidrIp
+			dst[i].CidrIpv6 = restored[i].CidrIpv6
+			dst[i].PrefixListId = restored[i].PrefixListId
+			dst[i].SourceSecurityGroupId = restored[i].SourceSecurityGroupId
+			dst[i].NetworkCidrs = restored[i].NetworkCidrs
+		}
+	}
+
+	return dst
+}
+
+// convertSecurityGroupRulesFrom converts v1alpha2 security group rules to v1alpha1, dropping their source
+func convertSecurityGroupRulesFrom(src []avov1alpha2.SecurityGroupRule) []SecurityGroupRule {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]SecurityGroupRule, len(src))
+	for i, rule := range src {
+		dst[i] = SecurityGroupRule{
+			FromPort: rule.FromPort,
+			ToPort:   rule.ToPort,
+			Protocol: rule.Protocol,
+		}
+	}
+
+	return dst
+}
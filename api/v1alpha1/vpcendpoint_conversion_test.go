@@ -0,0 +1,189 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+)
+
+func testV1alpha1VpcEndpoint() *VpcEndpoint {
+	return &VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "test",
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: VpcEndpointSpec{
+			ServiceName: "com.amazonaws.vpce.us-east-1.vpce-svc-00000000000000000",
+			SecurityGroup: SecurityGroup{
+				IngressRules: []SecurityGroupRule{
+					{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				},
+			},
+			SubdomainName: "test",
+			ExternalNameService: ExternalNameServiceSpec{
+				Name: "test",
+			},
+		},
+		Status: VpcEndpointStatus{
+			Status:          "available",
+			SecurityGroupId: "sg-12345",
+			VPCEndpointId:   "vpce-12345",
+			Conditions: []metav1.Condition{
+				{Type: AWSVpcEndpointCondition, Status: metav1.ConditionTrue, Reason: "Created"},
+			},
+		},
+	}
+}
+
+func testV1alpha2VpcEndpoint() *avov1alpha2.VpcEndpoint {
+	return &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test",
+			Namespace:   "test",
+			Annotations: map[string]string{"foo": "bar"},
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			ServiceNameRef: &avov1alpha2.ServiceName{
+				ValueFrom: &avov1alpha2.ServiceNameSource{
+					AwsEndpointServiceRef: &avov1alpha2.AwsEndpointSelector{Name: "private-router"},
+				},
+			},
+			SecurityGroup: avov1alpha2.SecurityGroup{
+				IngressRules: []avov1alpha2.SecurityGroupRule{
+					{CidrIp: "10.0.0.0/16", FromPort: 443, ToPort: 443, Protocol: "tcp"},
+					{PrefixListId: "pl-12345", FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				},
+				UseVpcCidr: true,
+			},
+			AssumeRoleArn: "arn:aws:iam::123456789012:role/test",
+			Region:        "us-east-1",
+			Vpc: avov1alpha2.Vpc{
+				SubnetIds: []string{"subnet-12345"},
+			},
+			CustomDns: avov1alpha2.CustomDns{
+				Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+					DomainName: "example.com",
+					Record: avov1alpha2.Route53HostedZoneRecord{
+						Hostname: "test",
+						ExternalNameService: avov1alpha2.ExternalNameService{
+							Name: "test",
+						},
+					},
+				},
+			},
+			RejectionPolicy: avov1alpha2.RejectionPolicy{
+				Action:                avov1alpha2.RejectionPolicyRecreate,
+				MaxAttempts:           5,
+				InitialBackoffSeconds: 60,
+			},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			Status:          "available",
+			SecurityGroupId: "sg-12345",
+			VPCId:           "vpc-12345",
+			VPCEndpointId:   "vpce-12345",
+			HostedZoneId:    "Z12345",
+			Conditions: []metav1.Condition{
+				{Type: avov1alpha2.AWSVpcEndpointCondition, Status: metav1.ConditionTrue, Reason: "Created"},
+			},
+		},
+	}
+}
+
+func TestVpcEndpoint_ConvertTo(t *testing.T) {
+	src := testV1alpha1VpcEndpoint()
+	dst := new(avov1alpha2.VpcEndpoint)
+	assert.NoError(t, src.ConvertTo(dst))
+
+	assert.Equal(t, src.ObjectMeta, dst.ObjectMeta)
+	assert.Equal(t, src.Spec.ServiceName, dst.Spec.ServiceName)
+	assert.Equal(t, []avov1alpha2.SecurityGroupRule{{FromPort: 443, ToPort: 443, Protocol: "tcp"}}, dst.Spec.SecurityGroup.IngressRules)
+	assert.True(t, dst.Spec.Vpc.AutoDiscoverSubnets)
+	assert.True(t, dst.Spec.CustomDns.Route53PrivateHostedZone.AutoDiscover)
+	assert.Equal(t, "test", dst.Spec.CustomDns.Route53PrivateHostedZone.Record.Hostname)
+	assert.Equal(t, avov1alpha2.Route53RecordTypeCNAME, dst.Spec.CustomDns.Route53PrivateHostedZone.Record.Type)
+	assert.Equal(t, "test", dst.Spec.CustomDns.Route53PrivateHostedZone.Record.ExternalNameService.Name)
+	assert.Equal(t, "vpce-12345", dst.Status.VPCEndpointId)
+	assert.Equal(t, src.Status.Conditions, dst.Status.Conditions)
+
+	src.Spec.AddtlHostedZoneName = "example.com"
+	dst = new(avov1alpha2.VpcEndpoint)
+	assert.NoError(t, src.ConvertTo(dst))
+	assert.False(t, dst.Spec.CustomDns.Route53PrivateHostedZone.AutoDiscover)
+	assert.Equal(t, "example.com", dst.Spec.CustomDns.Route53PrivateHostedZone.DomainName)
+}
+
+func TestVpcEndpoint_RoundTrip(t *testing.T) {
+	t.Run("v1alpha1 to v1alpha2 to v1alpha1", func(t *testing.T) {
+		src := testV1alpha1VpcEndpoint()
+		hub := new(avov1alpha2.VpcEndpoint)
+		assert.NoError(t, src.ConvertTo(hub))
+
+		dst := new(VpcEndpoint)
+		assert.NoError(t, dst.ConvertFrom(hub))
+		assert.Equal(t, src, dst)
+		assert.NotContains(t, dst.Annotations, avov1alpha2.ConversionDataAnnotation)
+	})
+
+	t.Run("v1alpha2 to v1alpha1 to v1alpha2", func(t *testing.T) {
+		src := testV1alpha2VpcEndpoint()
+		spoke := new(VpcEndpoint)
+		assert.NoError(t, spoke.ConvertFrom(src))
+		assert.Contains(t, spoke.Annotations, avov1alpha2.ConversionDataAnnotation)
+		assert.NotContains(t, src.Annotations, avov1alpha2.ConversionDataAnnotation)
+
+		dst := new(avov1alpha2.VpcEndpoint)
+		assert.NoError(t, spoke.ConvertTo(dst))
+		assert.Equal(t, src, dst)
+	})
+
+	t.Run("v1alpha1 changes are kept", func(t *testing.T) {
+		src := testV1alpha2VpcEndpoint()
+		spoke := new(VpcEndpoint)
+		assert.NoError(t, spoke.ConvertFrom(src))
+
+		spoke.Spec.SubdomainName = "changed"
+		spoke.Spec.SecurityGroup.IngressRules[0].FromPort = 80
+		spoke.Spec.SecurityGroup.IngressRules[1].FromPort = 80
+		spoke.Spec.SecurityGroup.EgressRules = []SecurityGroupRule{{FromPort: 53, ToPort: 53, Protocol: "udp"}}
+
+		dst := new(avov1alpha2.VpcEndpoint)
+		assert.NoError(t, spoke.ConvertTo(dst))
+		assert.Equal(t, "changed", dst.Spec.CustomDns.Route53PrivateHostedZone.Record.Hostname)
+		assert.Equal(t, []avov1alpha2.SecurityGroupRule{
+			{CidrIp: "10.0.0.0/16", FromPort: 80, ToPort: 443, Protocol: "tcp"},
+			{PrefixListId: "pl-12345", FromPort: 80, ToPort: 443, Protocol: "tcp"},
+		}, dst.Spec.SecurityGroup.IngressRules)
+		assert.Equal(t, []avov1alpha2.SecurityGroupRule{{FromPort: 53, ToPort: 53, Protocol: "udp"}}, dst.Spec.SecurityGroup.EgressRules)
+		assert.Equal(t, src.Spec.AssumeRoleArn, dst.Spec.AssumeRoleArn)
+		assert.Equal(t, src.Spec.RejectionPolicy, dst.Spec.RejectionPolicy)
+		assert.Equal(t, src.Status.HostedZoneId, dst.Status.HostedZoneId)
+	})
+}
+
+func TestVpcEndpoint_ConvertToInvalidAnnotation(t *testing.T) {
+	src := testV1alpha1VpcEndpoint()
+	src.Annotations[avov1alpha2.ConversionDataAnnotation] = "{"
+
+	assert.Error(t, src.ConvertTo(new(avov1alpha2.VpcEndpoint)))
+}
@@ -64,11 +64,48 @@ func (in *AvoConfig) DeepCopyInto(out *AvoConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.VpcEndpointMaxConcurrentReconciles != nil {
+		in, out := &in.VpcEndpointMaxConcurrentReconciles, &out.VpcEndpointMaxConcurrentReconciles
+		*out = new(int)
+		**out = **in
+	}
 	if in.EnablePrivateDns != nil {
 		in, out := &in.EnablePrivateDns, &out.EnablePrivateDns
 		*out = new(bool)
 		**out = **in
 	}
+	if in.EnableWebhookAWSValidation != nil {
+		in, out := &in.EnableWebhookAWSValidation, &out.EnableWebhookAWSValidation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultTags != nil {
+		in, out := &in.DefaultTags, &out.DefaultTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnableGarbageCollector != nil {
+		in, out := &in.EnableGarbageCollector, &out.EnableGarbageCollector
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GarbageCollectorDeleteOrphans != nil {
+		in, out := &in.GarbageCollectorDeleteOrphans, &out.GarbageCollectorDeleteOrphans
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GarbageCollectorInterval != nil {
+		in, out := &in.GarbageCollectorInterval, &out.GarbageCollectorInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvoConfig.
@@ -0,0 +1,24 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+// ConversionDataAnnotation stores the fields of a v1alpha2 VpcEndpoint which cannot be represented in an older API
+// version, so that converting back to v1alpha2 is lossless.
+const ConversionDataAnnotation = "avo.openshift.io/conversion-data"
+
+// Hub marks v1alpha2 as the version that all other VpcEndpoint versions are converted to and from.
+func (*VpcEndpoint) Hub() {}
@@ -22,13 +22,34 @@ import (
 )
 
 // SecurityGroupRule is based on required inputs for `aws authorize-security-group-ingress/egress`
+// +kubebuilder:validation:XValidation:message="only one of cidrIp, cidrIpv6, prefixListId, sourceSecurityGroupId or networkCidrs may be specified",rule="[has(self.cidrIp), has(self.cidrIpv6), has(self.prefixListId), has(self.sourceSecurityGroupId), has(self.networkCidrs)].filter(x, x).size() <= 1"
 type SecurityGroupRule struct {
 	// +kubebuilder:validation:Format=cidr
 
 	// CidrIp is the IPv4 address range, in CIDR format, to allow.
-	// If not specified, the cluster's master and worker security group are allowed instead.
+	// If no source is specified, the cluster's master and worker security group are allowed instead.
 	CidrIp string `json:"cidrIp,omitempty"`
 
+	// +kubebuilder:validation:Format=cidr
+
+	// CidrIpv6 is the IPv6 address range, in CIDR format, to allow.
+	CidrIpv6 string `json:"cidrIpv6,omitempty"`
+
+	// +kubebuilder:validation:Pattern=`^pl-[0-9a-f]+$`
+
+	// PrefixListId is the id of a managed prefix list to allow, e.g. pl-0123456789abcdef0
+	PrefixListId string `json:"prefixListId,omitempty"`
+
+	// +kubebuilder:validation:Pattern=`^([0-9]{12}/)?sg-[0-9a-f]+$`
+
+	// SourceSecurityGroupId is the id of a security group to allow. A security group in another AWS account, e.g. in
+	// a peered VPC, is referenced as <account id>/<security group id>, e.g. 123456789012/sg-0123456789abcdef0
+	SourceSecurityGroupId string `json:"sourceSecurityGroupId,omitempty"`
+
+	// NetworkCidrs allows the CIDRs of one of the cluster's networks, which are read from the cluster's network
+	// configuration or, for hosted control planes, from the HostedControlPlane.
+	NetworkCidrs NetworkCidrSource `json:"networkCidrs,omitempty"`
+
 	// FromPort and ToPort are the start and end of the port range to allow.
 	// In the case of a single port, set both to the same value.
 	FromPort int32 `json:"fromPort,omitempty"`
@@ -41,8 +62,39 @@ type SecurityGroupRule struct {
 	Protocol string `json:"protocol,omitempty"`
 }
 
+// NetworkCidrSource is one of the cluster's networks whose CIDRs are allowed by a security group rule
+// +kubebuilder:validation:Enum=ClusterNetwork;MachineNetwork
+type NetworkCidrSource string
+
+const (
+	// NetworkCidrSourceClusterNetwork is the cluster's pod network
+	NetworkCidrSourceClusterNetwork NetworkCidrSource = "ClusterNetwork"
+	// NetworkCidrSourceMachineNetwork is the network of the cluster's nodes
+	NetworkCidrSourceMachineNetwork NetworkCidrSource = "MachineNetwork"
+)
+
 // SecurityGroup represents the configuration of a security group associated with the VPC Endpoint created by this CR
+// +kubebuilder:validation:XValidation:message=ids or tags are required when the managed security group is disabled,rule=!has(self.managedSecurityGroup) || self.managedSecurityGroup != 'Disabled' || (has(self.ids) && size(self.ids) > 0) || (has(self.tags) && size(self.tags) > 0)
 type SecurityGroup struct {
+	// ManagedSecurityGroup controls whether AVO creates and manages a security group for the VPC Endpoint.
+	// When Enabled, it is attached alongside any security groups selected by Ids or Tags.
+	// When Disabled, only the selected security groups are attached, and IngressRules and EgressRules are ignored.
+	// +kubebuilder:default=Enabled
+	// +optional
+	ManagedSecurityGroup ManagedSecurityGroupPolicy `json:"managedSecurityGroup,omitempty"`
+
+	// Ids is a list of existing security group ids in the VPC Endpoint's VPC to attach to the VPC Endpoint.
+	// These security groups are owned by the user and are never modified or deleted by AVO.
+	// +kubebuilder:validation:items:Pattern=`^sg-[0-9a-f]+$`
+	// +optional
+	Ids []string `json:"ids,omitempty"`
+
+	// Tags is a list of AWS tag key-value pairs to select existing security groups in the VPC Endpoint's VPC with, which
+	// are attached to the VPC Endpoint in addition to Ids. A security group must have all the tags to be selected.
+	// These security groups are owned by the user and are never modified or deleted by AVO.
+	// +optional
+	Tags []Tag `json:"tags,omitempty"`
+
 	// IngressRules is a list of security group ingress rules.
 	// They will be allowed for the master and worker security groups.
 	// +optional
@@ -53,15 +105,34 @@ type SecurityGroup struct {
 	// +optional
 	EgressRules []SecurityGroupRule `json:"egressRules,omitempty"`
 
-	// UseVpcCidr, when true, causes rules without an explicit CidrIp to use the VPC's
+	// UseVpcCidr, when true, causes rules without an explicit source to use the VPC's
 	// CIDR block instead of the cluster's master and worker node security group IDs.
 	// This is useful in multi-cluster VPC environments where multiple clusters need
-	// to access shared VPC Endpoints.
+	// to access shared VPC Endpoints. When the VPC Endpoint's IpAddressType includes IPv6, the VPC's IPv6 CIDR
+	// blocks are used as well, or only them for ipv6.
 	// +kubebuilder:default=false
 	// +optional
 	UseVpcCidr bool `json:"useVpcCidr,omitempty"`
+
+	// StrictRuleManagement, when true, causes every rule on the managed security group that is not described by
+	// IngressRules or EgressRules to be revoked, including rules that were added outside of this operator.
+	// When false, only rules previously authorized by this operator are revoked when they are removed from the spec.
+	// +kubebuilder:default=false
+	// +optional
+	StrictRuleManagement bool `json:"strictRuleManagement,omitempty"`
 }
 
+// ManagedSecurityGroupPolicy controls whether AVO creates and manages a security group for a VPC Endpoint
+// +kubebuilder:validation:Enum=Enabled;Disabled
+type ManagedSecurityGroupPolicy string
+
+const (
+	// ManagedSecurityGroupEnabled creates and manages a security group for the VPC Endpoint
+	ManagedSecurityGroupEnabled ManagedSecurityGroupPolicy = "Enabled"
+	// ManagedSecurityGroupDisabled only attaches the user's security groups to the VPC Endpoint
+	ManagedSecurityGroupDisabled ManagedSecurityGroupPolicy = "Disabled"
+)
+
 // Tag represents a key-value pair to filter AWS resources by
 type Tag struct {
 	// Key of an AWS tag
@@ -108,6 +179,117 @@ type Vpc struct {
 	SubnetTags []Tag `json:"subnetTags,omitempty"`
 }
 
+// VpcEndpointType is the type of a VPC Endpoint
+// +kubebuilder:validation:Enum=Interface;Gateway;GatewayLoadBalancer
+type VpcEndpointType string
+
+const (
+	// VpcEndpointTypeInterface is an interface VPC Endpoint, which has a network interface in each of its subnets
+	VpcEndpointTypeInterface VpcEndpointType = "Interface"
+	// VpcEndpointTypeGateway is a gateway VPC Endpoint for Amazon S3 or DynamoDB, which is the target of a route in
+	// each of its route tables
+	VpcEndpointTypeGateway VpcEndpointType = "Gateway"
+	// VpcEndpointTypeGatewayLoadBalancer is a VPC Endpoint for a Gateway Load Balancer VPC Endpoint Service, which has a
+	// network interface in a single subnet and is the target of routes that send traffic through the Gateway Load
+	// Balancer's appliances
+	VpcEndpointTypeGatewayLoadBalancer VpcEndpointType = "GatewayLoadBalancer"
+)
+
+// RouteTables represents the configuration for the route tables associated with a Gateway VPC Endpoint
+type RouteTables struct {
+	// +kubebuilder:validation:Optional
+
+	// Ids is a list of route table ids to associate with the VPC Endpoint, which must be in its VPC.
+	// When empty, the route tables in the VPC Endpoint's VPC are auto-discovered using the tag-key:
+	// "kubernetes.io/cluster/${infraName}", unless .spec.vpc.ids or .spec.vpc.tags is specified, and .tags.
+	// +kubebuilder:validation:items:Pattern=`^rtb-[0-9a-f]+$`
+	Ids []string `json:"ids,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Tags is a list of AWS tag key-value pairs to additionally filter auto-discovered route tables with. Tags
+	// without a value filter by tag key only.
+	Tags []Tag `json:"tags,omitempty"`
+}
+
+// GatewayLoadBalancerRoute is a route in a route table that sends traffic for a destination through a
+// GatewayLoadBalancer VPC Endpoint
+// +kubebuilder:validation:XValidation:message=exactly one of destinationCidrBlock or destinationIpv6CidrBlock must be specified,rule=has(self.destinationCidrBlock) != has(self.destinationIpv6CidrBlock)
+type GatewayLoadBalancerRoute struct {
+	// RouteTableId is the id of the route table to add the route to
+	// +kubebuilder:validation:Pattern=`^rtb-[0-9a-f]+$`
+	RouteTableId string `json:"routeTableId"`
+
+	// +kubebuilder:validation:Optional
+
+	// DestinationCidrBlock is the IPv4 CIDR block of the traffic to send through the VPC Endpoint, e.g. 0.0.0.0/0
+	DestinationCidrBlock string `json:"destinationCidrBlock,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// DestinationIpv6CidrBlock is the IPv6 CIDR block of the traffic to send through the VPC Endpoint, e.g. ::/0
+	DestinationIpv6CidrBlock string `json:"destinationIpv6CidrBlock,omitempty"`
+}
+
+// ReplacedRoute is an existing route that AVO replaced to send traffic through a GatewayLoadBalancer VPC Endpoint,
+// along with its original target, which is restored when the route is removed
+type ReplacedRoute struct {
+	// Route is the replaced route
+	Route GatewayLoadBalancerRoute `json:"route"`
+
+	// TargetType is the type of the route's original target, named after the EC2 ReplaceRoute parameter, e.g.
+	// gatewayId or natGatewayId
+	TargetType string `json:"targetType"`
+
+	// TargetId is the id of the route's original target
+	TargetId string `json:"targetId"`
+}
+
+// Policy is the IAM resource policy of a VPC Endpoint, which controls the requests that can be made through it
+// +kubebuilder:validation:XValidation:message=exactly one of document or configMapRef must be specified,rule=has(self.document) != has(self.configMapRef)
+type Policy struct {
+	// +kubebuilder:validation:Optional
+
+	// Document is the JSON policy document
+	Document string `json:"document,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// ConfigMapRef selects the key of a ConfigMap in the same namespace as the VpcEndpoint that contains the JSON
+	// policy document. When the ConfigMap or key is missing and the reference is optional, the policy is not set, or
+	// the previously applied policy is kept and the AWSVpcEndpointPolicyReady condition is set to False. Changes to the
+	// ConfigMap are picked up on the next periodic reconcile.
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
+}
+
+// Adopt references existing AWS resources, e.g. created by Terraform or by hand, that AVO takes over instead of
+// creating new ones. Each resource is verified to match the spec before AVO tags it and manages it like any resource
+// it created.
+type Adopt struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^vpce-[0-9a-f]+$`
+	// +kubebuilder:validation:XValidation:message=.spec.adopt.vpcEndpointId is immutable,rule=self == oldSelf
+
+	// VpcEndpointId is the AWS ID of an existing VPC Endpoint to adopt. It must be in the VPC Endpoint's VPC, connect
+	// to the VPC Endpoint Service and be of the type in the spec, and must not be managed by another VpcEndpoint.
+	VpcEndpointId string `json:"vpcEndpointId,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^sg-[0-9a-f]+$`
+	// +kubebuilder:validation:XValidation:message=.spec.adopt.securityGroupId is immutable,rule=self == oldSelf
+
+	// SecurityGroupId is the AWS ID of an existing security group to adopt as the managed security group. It must be
+	// in the VPC Endpoint's VPC and must not be managed by another VpcEndpoint.
+	SecurityGroupId string `json:"securityGroupId,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Route53Records are the FQDNs of existing records in the Route 53 Private Hosted Zone to adopt. Each of them must
+	// be one of the records expected from .spec.customDns.route53PrivateHostedZone and is updated to point to the VPC
+	// Endpoint.
+	Route53Records []string `json:"route53Records,omitempty"`
+}
+
 // ExternalNameService is the configuration of a Kubernetes ExternalName Service pointing to a CustomDns
 // Route53PrivateHostedZone Record for the VPC Endpoint.
 type ExternalNameService struct {
@@ -116,15 +298,115 @@ type ExternalNameService struct {
 }
 
 // Route53HostedZoneRecord is the configuration of an AWS Route 53 Hosted Zone Record pointing to the created VPCE.
+// +kubebuilder:validation:XValidation:message=cannot create an ExternalName service for a wildcard record,rule=!(self.hostname.startsWith('*') && has(self.externalNameService) && self.externalNameService.name != "")
+// +kubebuilder:validation:XValidation:message=cannot create zonal records for a wildcard record,rule=!(self.hostname.startsWith('*') && has(self.zonal))
+// +kubebuilder:validation:XValidation:message=zonal ExternalName services require an ExternalName service,rule=!(has(self.zonal) && has(self.zonal.externalNameServices) && self.zonal.externalNameServices && !(has(self.externalNameService) && self.externalNameService.name != ""))
 type Route53HostedZoneRecord struct {
-	// Hostname is the hostname of the record.
+	// Hostname is the hostname of the record. It may be a wildcard, e.g. "*.apps", in which case "*" must be the
+	// leftmost label.
 	Hostname string `json:"hostname"`
 
+	// Type is the type of the record: CNAME, to the VPC Endpoint's regional DNS name; AliasA or AliasAAAA, an alias
+	// record to the VPC Endpoint's regional DNS name; A, listing the private IPs of the VPC Endpoint's network
+	// interfaces; or AAAA, listing their IPv6 addresses.
+	// For a VPC Endpoint whose DNS records include IPv6, A and AliasA records are published along with the
+	// matching AAAA and AliasAAAA records, or replaced by them if its DNS records are IPv6 only.
+	// +kubebuilder:default=CNAME
+	// +optional
+	Type Route53RecordType `json:"type,omitempty"`
+
+	// TTL is the time to live of the record in seconds. Alias records use the TTL of the VPC Endpoint's DNS name
+	// instead.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=300
+	// +optional
+	TTL int64 `json:"ttl,omitempty"`
+
 	// +kubebuilder:validation:Optional
 
 	ExternalNameService ExternalNameService `json:"externalNameService,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Zonal additionally creates a record for each of the VPC Endpoint's Availability Zones, named
+	// "<availability zone>.<hostname>", pointing to the VPC Endpoint's zonal DNS name or, for A records, the private IP
+	// of its network interface in that Availability Zone.
+	Zonal *ZonalRecords `json:"zonal,omitempty"`
+}
+
+// ZonalRecordLabel is how an Availability Zone is identified in the hostname of a zonal record
+// +kubebuilder:validation:Enum=AvailabilityZoneName;AvailabilityZoneId
+type ZonalRecordLabel string
+
+const (
+	// ZonalRecordLabelAvailabilityZoneName identifies an Availability Zone by name, e.g. "us-east-1a"
+	ZonalRecordLabelAvailabilityZoneName ZonalRecordLabel = "AvailabilityZoneName"
+	// ZonalRecordLabelAvailabilityZoneId identifies an Availability Zone by ID, e.g. "use1-az1", which is the same
+	// Availability Zone in every AWS account
+	ZonalRecordLabelAvailabilityZoneId ZonalRecordLabel = "AvailabilityZoneId"
+)
+
+// ZonalRecords is the configuration of per-Availability Zone records for a Route 53 Hosted Zone Record
+type ZonalRecords struct {
+	// Label is how each Availability Zone is identified in the hostname of its record, either AvailabilityZoneName or
+	// AvailabilityZoneId.
+	// +kubebuilder:default=AvailabilityZoneName
+	// +optional
+	Label ZonalRecordLabel `json:"label,omitempty"`
+
+	// ExternalNameServices additionally creates an ExternalName service for each zonal record, named
+	// "<externalNameService.name>-<availability zone>".
+	// +optional
+	ExternalNameServices bool `json:"externalNameServices,omitempty"`
 }
 
+// Route53RecordType is the type of Route 53 record pointing to a VPC Endpoint
+// +kubebuilder:validation:Enum=CNAME;AliasA;AliasAAAA;A;AAAA
+type Route53RecordType string
+
+const (
+	// Route53RecordTypeCNAME is a CNAME record to the VPC Endpoint's regional DNS name
+	Route53RecordTypeCNAME Route53RecordType = "CNAME"
+	// Route53RecordTypeAliasA is an alias A record to the VPC Endpoint's regional DNS name
+	Route53RecordTypeAliasA Route53RecordType = "AliasA"
+	// Route53RecordTypeAliasAAAA is an alias AAAA record to the VPC Endpoint's regional DNS name
+	Route53RecordTypeAliasAAAA Route53RecordType = "AliasAAAA"
+	// Route53RecordTypeA is an A record listing the private IPs of the VPC Endpoint's network interfaces
+	Route53RecordTypeA Route53RecordType = "A"
+	// Route53RecordTypeAAAA is an AAAA record listing the IPv6 addresses of the VPC Endpoint's network interfaces
+	Route53RecordTypeAAAA Route53RecordType = "AAAA"
+)
+
+// IpAddressType is the type of IP addresses assigned to a VPC Endpoint's network interfaces
+// +kubebuilder:validation:Enum=ipv4;dualstack;ipv6
+type IpAddressType string
+
+const (
+	// IpAddressTypeIpv4 assigns IPv4 addresses to the VPC Endpoint's network interfaces
+	IpAddressTypeIpv4 IpAddressType = "ipv4"
+	// IpAddressTypeDualstack assigns both IPv4 and IPv6 addresses to the VPC Endpoint's network interfaces, which
+	// requires subnets with both IPv4 and IPv6 CIDR blocks
+	IpAddressTypeDualstack IpAddressType = "dualstack"
+	// IpAddressTypeIpv6 assigns IPv6 addresses to the VPC Endpoint's network interfaces, which requires IPv6-only
+	// subnets
+	IpAddressTypeIpv6 IpAddressType = "ipv6"
+)
+
+// DnsRecordIpType is the type of DNS records AWS creates for a VPC Endpoint's DNS names
+// +kubebuilder:validation:Enum=ipv4;dualstack;ipv6;service-defined
+type DnsRecordIpType string
+
+const (
+	// DnsRecordIpTypeIpv4 creates A records
+	DnsRecordIpTypeIpv4 DnsRecordIpType = "ipv4"
+	// DnsRecordIpTypeDualstack creates both A and AAAA records
+	DnsRecordIpTypeDualstack DnsRecordIpType = "dualstack"
+	// DnsRecordIpTypeIpv6 creates AAAA records
+	DnsRecordIpTypeIpv6 DnsRecordIpType = "ipv6"
+	// DnsRecordIpTypeServiceDefined creates the records supported by the VPC Endpoint Service
+	DnsRecordIpTypeServiceDefined DnsRecordIpType = "service-defined"
+)
+
 // DomainName represents the base domain name of a Route 53 Private Hosted Zone
 // Similar to: https://github.com/kubernetes/api/blob/7a87286591e433a1d034a768032b5fd4abb072b3/core/v1/types.go#L2100-L2110
 type DomainName struct {
@@ -205,6 +487,14 @@ type Route53PrivateHostedZone struct {
 
 	// Record is the configuration of a record within the selected Route 53 Private Hosted Zone
 	Record Route53HostedZoneRecord `json:"record,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=hostname
+
+	// Records are the configuration of additional records within the selected Route 53 Private Hosted Zone, e.g. to
+	// point both "api" and "*.apps" at the created VPCE.
+	Records []Route53HostedZoneRecord `json:"records,omitempty"`
 }
 
 // CustomDns is the configuration of customized DNS routing external to a standalone AWS VPC Endpoint
@@ -232,6 +522,13 @@ type AwsEndpointSelector struct {
 
 // +kubebuilder:validation:XValidation:message=.spec.vpc.autoDiscoverSubnets is not supported with .spec.region,rule=!(has(self.region) && self.vpc.autoDiscoverSubnets)
 // +kubebuilder:validation:XValidation:message=.spec.customDns.route53PrivateHostedZone.autoDiscoverPrivateHostedZone is not supported with .spec.region,rule=!(has(self.region) && self.customDns.route53PrivateHostedZone.autoDiscoverPrivateHostedZone)
+// +kubebuilder:validation:XValidation:message=.spec.assumeRoleExternalId and .spec.assumeRoleSessionName require .spec.assumeRoleArn,rule=has(self.assumeRoleArn) || !(has(self.assumeRoleExternalId) || has(self.assumeRoleSessionName))
+// +kubebuilder:validation:XValidation:message=.spec.routeTables is only supported with Gateway VPC Endpoints,rule=!has(self.routeTables) || (has(self.type) && self.type == 'Gateway')
+// +kubebuilder:validation:XValidation:message=.spec.routes is only supported with GatewayLoadBalancer VPC Endpoints,rule=!has(self.routes) || (has(self.type) && self.type == 'GatewayLoadBalancer')
+// +kubebuilder:validation:XValidation:message=.spec.policy is not supported with GatewayLoadBalancer VPC Endpoints,rule=!has(self.policy) || !has(self.type) || self.type != 'GatewayLoadBalancer'
+// +kubebuilder:validation:XValidation:message=GatewayLoadBalancer VPC Endpoints require exactly one subnet in .spec.vpc.subnetIds,rule=!has(self.type) || self.type != 'GatewayLoadBalancer' || (has(self.vpc) && has(self.vpc.subnetIds) && size(self.vpc.subnetIds) == 1)
+// +kubebuilder:validation:XValidation:message=.spec.adopt.securityGroupId requires the managed security group,rule=!has(self.adopt) || !has(self.adopt.securityGroupId) || !has(self.securityGroup) || !has(self.securityGroup.managedSecurityGroup) || self.securityGroup.managedSecurityGroup != 'Disabled'
+// +kubebuilder:validation:XValidation:message=".spec.dnsRecordIpType must match .spec.ipAddressType unless .spec.ipAddressType is dualstack",rule="!has(self.dnsRecordIpType) || self.dnsRecordIpType == 'service-defined' || (has(self.ipAddressType) && self.ipAddressType == 'dualstack') || self.dnsRecordIpType == (has(self.ipAddressType) ? self.ipAddressType : 'ipv4')"
 //
 // A VpcEndpoint must reference a VPC Endpoint Service via exactly one of:
 //  1. .spec.serviceName (direct service name string)
@@ -252,17 +549,51 @@ type VpcEndpointSpec struct {
 	// ServiceNameRef refers to a group and resource that contains the name of the VPC Endpoint Service
 	ServiceNameRef *ServiceName `json:"serviceNameRef,omitempty"`
 
+	// +kubebuilder:default=Interface
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:message=.spec.type is immutable,rule=self == oldSelf
+
+	// Type is the type of the VPC Endpoint: Interface, Gateway or GatewayLoadBalancer. Gateway VPC Endpoints, e.g. for
+	// com.amazonaws.<region>.s3 or com.amazonaws.<region>.dynamodb, are associated with route tables instead of subnets
+	// and have no security group or DNS records, so .spec.securityGroup and .spec.customDns are ignored.
+	// GatewayLoadBalancer VPC Endpoints are created in the single subnet in .spec.vpc.subnetIds and likewise have no
+	// security group or DNS records.
+	// Defaults to Interface.
+	Type VpcEndpointType `json:"type,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
 	// SecurityGroup contains the configuration of the security group attached to the VPC Endpoint
 	SecurityGroup SecurityGroup `json:"securityGroup"`
 
 	// +kubebuilder:validation:Optional
 
-	// AssumeRoleArn will allow AVO to use sts:AssumeRole to create VPC Endpoints in separate AWS Accounts
-	// TODO: Implement
+	// AssumeRoleArn will allow AVO to use sts:AssumeRole to create VPC Endpoints in separate AWS Accounts.
+	// The role is assumed using the credentials from AWSCredentialOverrideRef if specified, allowing role chaining,
+	// or the operator's default credentials otherwise. The session is tagged with the namespace and name of this
+	// Custom Resource, so the role's trust policy must allow sts:TagSession.
+	// +kubebuilder:validation:Pattern=`^arn:[\w-]+:iam::\d{12}:role/.+$`
 	AssumeRoleArn string `json:"assumeRoleArn,omitempty"`
 
 	// +kubebuilder:validation:Optional
 
+	// AssumeRoleExternalId is the external ID passed to sts:AssumeRole when assuming AssumeRoleArn
+	// +kubebuilder:validation:MinLength=2
+	// +kubebuilder:validation:MaxLength=1224
+	// +kubebuilder:validation:Pattern=`^[\w+=,.@:/-]*$`
+	AssumeRoleExternalId string `json:"assumeRoleExternalId,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// AssumeRoleSessionName is the role session name used when assuming AssumeRoleArn.
+	// Defaults to a name derived from the namespace and name of this Custom Resource.
+	// +kubebuilder:validation:MinLength=2
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^[\w+=,.@-]*$`
+	AssumeRoleSessionName string `json:"assumeRoleSessionName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
 	// AWSCredentialOverride is a Kubernetes secret containing AWS credentials for the operator to use for reconciling
 	// this specific vpcendpoint Custom Resource.
 	// The secret should have data keys for either:
@@ -285,6 +616,22 @@ type VpcEndpointSpec struct {
 	// https://docs.aws.amazon.com/vpc/latest/privatelink/manage-dns-names.html (defaults to false)
 	EnablePrivateDns bool `json:"enablePrivateDns,omitempty"`
 
+	// +kubebuilder:default=ipv4
+	// +kubebuilder:validation:Optional
+
+	// IpAddressType is the type of IP addresses assigned to the VPC Endpoint's network interfaces: ipv4, dualstack or
+	// ipv6. Auto-discovered subnets are limited to the ones supporting it, i.e. subnets with an IPv6 CIDR block for
+	// dualstack and IPv6-only subnets for ipv6. It can be changed after the VPC Endpoint has been created.
+	// Defaults to ipv4.
+	IpAddressType IpAddressType `json:"ipAddressType,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// DnsRecordIpType is the type of DNS records AWS creates for the VPC Endpoint's DNS names: ipv4, dualstack, ipv6
+	// or service-defined. It also determines whether Route 53 records managed by AVO include AAAA records.
+	// Defaults to IpAddressType.
+	DnsRecordIpType DnsRecordIpType `json:"dnsRecordIpType,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:XValidation:message=.spec.vpc.autoDiscoverSubnets must be true when specifying tags to search for VPCs,rule=!(size(self.tags) > 0 && !self.autoDiscoverSubnets)
 	// +kubebuilder:validation:XValidation:message=.spec.vpc.autoDiscoverSubnets must be true when specifying VPCs to load balance,rule=!(size(self.ids) > 0 && !self.autoDiscoverSubnets)
@@ -295,19 +642,179 @@ type VpcEndpointSpec struct {
 
 	// +kubebuilder:validation:Optional
 
+	// RouteTables configures the route tables associated with a Gateway VPC Endpoint
+	RouteTables RouteTables `json:"routeTables,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Routes are routes that send traffic through a GatewayLoadBalancer VPC Endpoint, which are created once the VPC
+	// Endpoint is available and deleted along with the VpcEndpoint. An existing route for the same destination is
+	// replaced.
+	Routes []GatewayLoadBalancerRoute `json:"routes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Policy is the IAM resource policy of the VPC Endpoint, which is set when it's created and restored when it's
+	// changed outside of AVO. When removed, the VPC Endpoint's policy is reset to the default policy, which allows
+	// full access. Not supported with GatewayLoadBalancer VPC Endpoints.
+	Policy *Policy `json:"policy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
 	// CustomDns will define configurations for all other custom DNS setups, such as a separate Route 53 Private Hosted
 	// Zone or an `ExternalName` Kubernetes service.
 	CustomDns CustomDns `json:"customDns,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// RejectionPolicy configures how AVO responds when the VPC Endpoint Service owner rejects the VPC Endpoint.
+	// Rejected VPC Endpoints are always deleted.
+	// +kubebuilder:default={}
+	RejectionPolicy RejectionPolicy `json:"rejectionPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// DeletionPolicy configures what happens to each kind of AWS resource managed for the VpcEndpoint when it's
+	// deleted, e.g. to keep the VPC Endpoint and its DNS records while moving workloads between clusters.
+	// +kubebuilder:default={}
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Adopt references existing AWS resources that AVO takes over instead of creating new ones, which allows
+	// migrating VPC Endpoints managed outside of AVO without recreating them
+	Adopt *Adopt `json:"adopt,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// Tags are additional AWS tags applied to the VPC Endpoint, its security group and network interfaces, and any
+	// Route 53 Private Hosted Zone created by AVO. They take precedence over the operator's default tags.
+	// The tags AVO uses to identify the resources it manages, e.g. Name, can't be overridden.
+	// +kubebuilder:validation:MaxProperties=40
+	// +kubebuilder:validation:XValidation:rule="self.all(k, !k.startsWith('aws:'))",message="tag keys may not start with aws:"
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// RejectionPolicyAction is the action taken after a rejected VPC Endpoint has been deleted
+// +kubebuilder:validation:Enum=Recreate;StayDeleted
+type RejectionPolicyAction string
+
+const (
+	// RejectionPolicyRecreate recreates a rejected VPC Endpoint with exponential backoff
+	RejectionPolicyRecreate RejectionPolicyAction = "Recreate"
+	// RejectionPolicyStayDeleted leaves a rejected VPC Endpoint deleted
+	RejectionPolicyStayDeleted RejectionPolicyAction = "StayDeleted"
+)
+
+// RejectionPolicy configures how AVO responds when a VPC Endpoint is rejected
+type RejectionPolicy struct {
+	// Action is either Recreate, to recreate the rejected VPC Endpoint with exponential backoff, or StayDeleted, to
+	// leave it deleted and report a terminal AWSVpcEndpointReady condition.
+	// +kubebuilder:default=StayDeleted
+	// +optional
+	Action RejectionPolicyAction `json:"action,omitempty"`
+
+	// MaxAttempts is the number of times a rejected VPC Endpoint is recreated before giving up.
+	// Zero means there is no limit. Only used with the Recreate action.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=5
+	// +optional
+	MaxAttempts int32 `json:"maxAttempts,omitempty"`
+
+	// InitialBackoffSeconds is the delay before the first recreation attempt, doubling with each subsequent attempt
+	// up to one hour. Only used with the Recreate action.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=60
+	// +optional
+	InitialBackoffSeconds int32 `json:"initialBackoffSeconds,omitempty"`
 }
 
+// DeletionPolicyAction is what happens to an AWS resource when its VpcEndpoint is deleted
+// +kubebuilder:validation:Enum=Delete;Retain;Orphan
+type DeletionPolicyAction string
+
 const (
-	AWSVpcEndpointCondition      = "AWSVpcEndpointReady"
-	AWSSecurityGroupCondition    = "AWSSecurityGroupReady"
-	ExternalNameServiceCondition = "ExternalNameServiceReady"
-	AWSRoute53RecordCondition    = "AWSRoute53RecordReady"
-	AWSRoute53TagsCondition      = "AWSRoute53TagsReady"
+	// DeletionPolicyDelete deletes the AWS resource
+	DeletionPolicyDelete DeletionPolicyAction = "Delete"
+	// DeletionPolicyRetain keeps the AWS resource and releases it from AVO by rewriting the tags AVO uses to identify
+	// it, so that it's no longer considered orphaned and can be adopted by another VpcEndpoint
+	DeletionPolicyRetain DeletionPolicyAction = "Retain"
+	// DeletionPolicyOrphan keeps the AWS resource as is, so that it's found again by a VpcEndpoint with the same name
+	// in the same cluster
+	DeletionPolicyOrphan DeletionPolicyAction = "Orphan"
 )
 
+// DeletionPolicy configures what happens to each kind of AWS resource managed for a VpcEndpoint when it's deleted.
+// A resource that a kept resource depends on is kept along with it: the security group of a kept VPC Endpoint and
+// the Private Hosted Zone of kept records.
+type DeletionPolicy struct {
+	// VpcEndpoint is the action for the VPC Endpoint and, for GatewayLoadBalancer VPC Endpoints, its routes
+	// +kubebuilder:default=Delete
+	// +optional
+	VpcEndpoint DeletionPolicyAction `json:"vpcEndpoint,omitempty"`
+
+	// SecurityGroup is the action for the managed security group
+	// +kubebuilder:default=Delete
+	// +optional
+	SecurityGroup DeletionPolicyAction `json:"securityGroup,omitempty"`
+
+	// HostedZone is the action for the Route 53 Private Hosted Zone, which is only ever deleted if AVO created it
+	// +kubebuilder:default=Delete
+	// +optional
+	HostedZone DeletionPolicyAction `json:"hostedZone,omitempty"`
+
+	// Route53Records is the action for the Route 53 records in .status.resourceRecords
+	// +kubebuilder:default=Delete
+	// +optional
+	Route53Records DeletionPolicyAction `json:"route53Records,omitempty"`
+}
+
+const (
+	AWSVpcEndpointCondition       = "AWSVpcEndpointReady"
+	AWSSecurityGroupCondition     = "AWSSecurityGroupReady"
+	ExternalNameServiceCondition  = "ExternalNameServiceReady"
+	AWSRoute53RecordCondition     = "AWSRoute53RecordReady"
+	AWSRoute53TagsCondition       = "AWSRoute53TagsReady"
+	AWSAssumeRoleCondition        = "AWSAssumeRoleReady"
+	AWSVpcEndpointPolicyCondition = "AWSVpcEndpointPolicyReady"
+	PausedCondition               = "Paused"
+
+	// ReadyCondition summarizes the other conditions, it's True once the VPC Endpoint and all the AWS and K8s
+	// resources managed for it are ready
+	ReadyCondition = "Ready"
+)
+
+// Reasons of the Ready condition
+const (
+	// ReadyReasonAvailable means the VPC Endpoint and all the resources managed for it are ready
+	ReadyReasonAvailable = "Available"
+	// ReadyReasonProgressing means the resources are still being created or updated
+	ReadyReasonProgressing = "Progressing"
+	// ReadyReasonPendingAcceptance means the VPC Endpoint is waiting to be accepted by the VPC Endpoint Service
+	ReadyReasonPendingAcceptance = "PendingAcceptance"
+	// ReadyReasonRejected means the VPC Endpoint was rejected by the VPC Endpoint Service
+	ReadyReasonRejected = "Rejected"
+	// ReadyReasonSubnetDiscoveryFailed means no subnets were found to attach the VPC Endpoint to
+	ReadyReasonSubnetDiscoveryFailed = "SubnetDiscoveryFailed"
+	// ReadyReasonUnauthorized means AWS denied an operation, e.g. missing IAM permissions or a role that can't be assumed
+	ReadyReasonUnauthorized = "Unauthorized"
+	// ReadyReasonThrottled means an AWS API rate limit was hit
+	ReadyReasonThrottled = "Throttled"
+	// ReadyReasonQuotaExceeded means an AWS service quota was reached, e.g. the number of VPC Endpoints per VPC
+	ReadyReasonQuotaExceeded = "QuotaExceeded"
+	// ReadyReasonReconcileError means the reconcile failed for any other reason, see .status.lastReconcileError
+	ReadyReasonReconcileError = "ReconcileError"
+)
+
+// PausedAnnotation pauses the reconciliation of a VpcEndpoint when set to "true": AVO stops changing its AWS
+// resources and only reports their state, and a paused VpcEndpoint being deleted keeps its finalizer until it's
+// unpaused
+const PausedAnnotation = "avo.openshift.io/paused"
+
+// DryRunAnnotation reconciles a VpcEndpoint in dry-run mode when set to "true": AVO reads from AWS as usual, but
+// records the changes it would make in .status.plannedChanges instead of applying them
+const DryRunAnnotation = "avo.openshift.io/dry-run"
+
 // VpcEndpointStatus defines the observed state of VpcEndpoint
 type VpcEndpointStatus struct {
 	// Status of the VPC Endpoint
@@ -317,6 +824,29 @@ type VpcEndpointStatus struct {
 	// +kubebuilder:validation:Optional
 	SecurityGroupId string `json:"securityGroupId,omitempty"`
 
+	// The AWS IDs of the user's security groups selected by .spec.securityGroup.ids and .spec.securityGroup.tags, which
+	// are attached to the VPC Endpoint, but never modified or deleted
+	// +kubebuilder:validation:Optional
+	UserSecurityGroupIds []string `json:"userSecurityGroupIds,omitempty"`
+
+	// The AWS IDs of the route tables associated with a Gateway VPC Endpoint
+	// +kubebuilder:validation:Optional
+	RouteTableIds []string `json:"routeTableIds,omitempty"`
+
+	// The routes sending traffic through a GatewayLoadBalancer VPC Endpoint that have been created, which are deleted
+	// along with the VpcEndpoint
+	// +kubebuilder:validation:Optional
+	Routes []GatewayLoadBalancerRoute `json:"routes,omitempty"`
+
+	// The routes in .status.routes that existed with another target before AVO replaced them, whose original target is
+	// restored instead of deleting the route
+	// +kubebuilder:validation:Optional
+	ReplacedRoutes []ReplacedRoute `json:"replacedRoutes,omitempty"`
+
+	// The SHA-256 hash of the normalized policy document from .spec.policy last applied to the VPC Endpoint
+	// +kubebuilder:validation:Optional
+	PolicyHash string `json:"policyHash,omitempty"`
+
 	// The AWS ID of the VPC to create resources in
 	// +kubebuilder:validation:Optional
 	VPCId string `json:"vpcId,omitempty"`
@@ -329,26 +859,196 @@ type VpcEndpointStatus struct {
 	// +kubebuilder:validation:Optional
 	VPCEndpointServiceName string `json:"vpcEndpointServiceName,omitempty"`
 
+	// The AWS account ID that owns the VPC Endpoint
+	// +kubebuilder:validation:Optional
+	OwnerId string `json:"ownerId,omitempty"`
+
+	// Whether private DNS is enabled on the VPC Endpoint, as reported by AWS
+	// +kubebuilder:validation:Optional
+	PrivateDnsEnabled bool `json:"privateDnsEnabled,omitempty"`
+
+	// The SHA-256 hash of the normalized policy document attached to the VPC Endpoint, as reported by AWS. Unlike
+	// policyHash, it's also set for the default policy AWS attaches when .spec.policy is unset.
+	// +kubebuilder:validation:Optional
+	ObservedPolicyHash string `json:"observedPolicyHash,omitempty"`
+
+	// The subnets the VPC Endpoint is attached to, sorted by Availability Zone
+	// +kubebuilder:validation:Optional
+	Subnets []VpcEndpointSubnet `json:"subnets,omitempty"`
+
+	// The network interfaces of the VPC Endpoint in each of its subnets, sorted by Availability Zone
+	// +kubebuilder:validation:Optional
+	NetworkInterfaces []VpcEndpointNetworkInterface `json:"networkInterfaces,omitempty"`
+
+	// The DNS entries of the VPC Endpoint, the regional entry first followed by the zonal entries
+	// +kubebuilder:validation:Optional
+	DnsEntries []VpcEndpointDnsEntry `json:"dnsEntries,omitempty"`
+
 	// The AWS ID of the Route 53 Private Hosted Zone being used
 	// +kubebuilder:validation:Optional
 	HostedZoneId string `json:"hostedZoneId,omitempty"`
 
-	// The FQDN of a Route 53 Hosted Zone record that has been created
+	// The FQDN of the first Route 53 Hosted Zone record that has been created
 	// +kubebuilder:validation:Optional
 	ResourceRecordSet string `json:"resourceRecordSet,omitempty"`
 
+	// The Route 53 Hosted Zone records that have been created, which are deleted along with the VpcEndpoint
+	// +kubebuilder:validation:Optional
+	ResourceRecords []ResourceRecordStatus `json:"resourceRecords,omitempty"`
+
 	// The Infra Id of the cluster, used for naming and tagging purposes
 	// +kubebuilder:validation:Optional
 	InfraId string `json:"infraId,omitempty"`
 
+	// The number of times the VPC Endpoint has been recreated after being rejected since it was last available
+	// +kubebuilder:validation:Optional
+	RecreateAttempts int32 `json:"recreateAttempts,omitempty"`
+
+	// The last time the VPC Endpoint was rejected by the VPC Endpoint Service
+	// +kubebuilder:validation:Optional
+	LastRejectionTime *metav1.Time `json:"lastRejectionTime,omitempty"`
+
+	// The additional tags, from spec.tags and the operator's default tags, last applied to the AWS resources.
+	// Only these keys are removed from the AWS resources when they are no longer configured.
+	// +kubebuilder:validation:Optional
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// The AWS resources from .spec.adopt that have been verified and adopted
+	// +kubebuilder:validation:Optional
+	AdoptedResources []AdoptedResource `json:"adoptedResources,omitempty"`
+
+	// The AWS changes AVO would make for this VpcEndpoint, recorded in dry-run mode instead of being applied. Changes
+	// that depend on a resource that doesn't exist yet are planned once it exists, so the plan stops at the first
+	// resource that would be created or deleted.
+	// +kubebuilder:validation:Optional
+	PlannedChanges []PlannedChange `json:"plannedChanges,omitempty"`
+
+	// The .metadata.generation of the VpcEndpoint last reconciled
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The last time the VpcEndpoint was reconciled
+	// +kubebuilder:validation:Optional
+	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// The error of the last reconcile, empty if it succeeded
+	// +kubebuilder:validation:Optional
+	LastReconcileError string `json:"lastReconcileError,omitempty"`
+
 	// The status conditions of the AWS and K8s resources managed by this controller
 	// +kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions"`
 }
 
+// VpcEndpointSubnet is a subnet the VPC Endpoint is attached to
+type VpcEndpointSubnet struct {
+	// Id is the AWS ID of the subnet
+	Id string `json:"id"`
+
+	// AvailabilityZone is the name of the subnet's Availability Zone, e.g. us-east-1a
+	// +kubebuilder:validation:Optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// AvailabilityZoneId is the ID of the subnet's Availability Zone, e.g. use1-az1, which is the same across AWS
+	// accounts
+	// +kubebuilder:validation:Optional
+	AvailabilityZoneId string `json:"availabilityZoneId,omitempty"`
+}
+
+// VpcEndpointNetworkInterface is a network interface AWS created for the VPC Endpoint in one of its subnets
+type VpcEndpointNetworkInterface struct {
+	// Id is the AWS ID of the network interface
+	Id string `json:"id"`
+
+	// SubnetId is the AWS ID of the network interface's subnet
+	// +kubebuilder:validation:Optional
+	SubnetId string `json:"subnetId,omitempty"`
+
+	// AvailabilityZone is the name of the network interface's Availability Zone
+	// +kubebuilder:validation:Optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// PrivateIpAddress is the private IPv4 address of the network interface
+	// +kubebuilder:validation:Optional
+	PrivateIpAddress string `json:"privateIpAddress,omitempty"`
+
+	// Ipv6Addresses are the IPv6 addresses of the network interface
+	// +kubebuilder:validation:Optional
+	Ipv6Addresses []string `json:"ipv6Addresses,omitempty"`
+}
+
+// VpcEndpointDnsEntry is a DNS entry of the VPC Endpoint
+type VpcEndpointDnsEntry struct {
+	// DnsName is the DNS name
+	DnsName string `json:"dnsName"`
+
+	// HostedZoneId is the AWS ID of the hosted zone the DNS name is in
+	// +kubebuilder:validation:Optional
+	HostedZoneId string `json:"hostedZoneId,omitempty"`
+}
+
+// PlannedChange is an AWS change recorded in dry-run mode
+type PlannedChange struct {
+	// Operation is the AWS API operation, e.g. CreateVpcEndpoint
+	Operation string `json:"operation"`
+
+	// Resource is the id or name of the AWS resource the operation applies to, if it already exists
+	// +kubebuilder:validation:Optional
+	Resource string `json:"resource,omitempty"`
+
+	// Details summarizes the operation's parameters
+	// +kubebuilder:validation:Optional
+	Details string `json:"details,omitempty"`
+}
+
+// ResourceRecordStatus is the status of a Route 53 Hosted Zone record pointing to the created VPCE
+type ResourceRecordStatus struct {
+	// Hostname is the hostname of the record in .spec.customDns.route53PrivateHostedZone, prefixed with the
+	// Availability Zone for zonal records
+	Hostname string `json:"hostname"`
+
+	// AvailabilityZone is the Availability Zone of a zonal record
+	// +kubebuilder:validation:Optional
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// Name is the FQDN of the record
+	Name string `json:"name"`
+
+	// Type is the Route 53 type of the record, e.g. CNAME, A or AAAA
+	Type string `json:"type"`
+
+	// ExternalNameService is the name of the ExternalName service pointing to the record, if any
+	// +kubebuilder:validation:Optional
+	ExternalNameService string `json:"externalNameService,omitempty"`
+}
+
+// AdoptedResourceType is the type of an AWS resource adopted from .spec.adopt
+// +kubebuilder:validation:Enum=VpcEndpoint;SecurityGroup;Route53Record
+type AdoptedResourceType string
+
+const (
+	AdoptedResourceTypeVpcEndpoint   AdoptedResourceType = "VpcEndpoint"
+	AdoptedResourceTypeSecurityGroup AdoptedResourceType = "SecurityGroup"
+	AdoptedResourceTypeRoute53Record AdoptedResourceType = "Route53Record"
+)
+
+// AdoptedResource records the adoption of an existing AWS resource from .spec.adopt
+type AdoptedResource struct {
+	// Type is the type of the adopted resource
+	Type AdoptedResourceType `json:"type"`
+
+	// Id is the AWS ID of the adopted resource, or the FQDN of an adopted Route 53 record
+	Id string `json:"id"`
+
+	// AdoptionTime is when the resource was adopted
+	AdoptionTime metav1.Time `json:"adoptionTime"`
+}
+
 // +kubebuilder:subresource:status
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName={vpce},scope="Namespaced"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
 // +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
 // +kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.vpcEndpointId`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
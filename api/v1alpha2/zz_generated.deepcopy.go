@@ -10,6 +10,42 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Adopt) DeepCopyInto(out *Adopt) {
+	*out = *in
+	if in.Route53Records != nil {
+		in, out := &in.Route53Records, &out.Route53Records
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Adopt.
+func (in *Adopt) DeepCopy() *Adopt {
+	if in == nil {
+		return nil
+	}
+	out := new(Adopt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdoptedResource) DeepCopyInto(out *AdoptedResource) {
+	*out = *in
+	in.AdoptionTime.DeepCopyInto(&out.AdoptionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptedResource.
+func (in *AdoptedResource) DeepCopy() *AdoptedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(AdoptedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AssociatedVpc) DeepCopyInto(out *AssociatedVpc) {
 	*out = *in
@@ -61,6 +97,21 @@ func (in *CustomDns) DeepCopy() *CustomDns {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionPolicy) DeepCopyInto(out *DeletionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionPolicy.
+func (in *DeletionPolicy) DeepCopy() *DeletionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DnsSelector) DeepCopyInto(out *DnsSelector) {
 	*out = *in
@@ -136,6 +187,21 @@ func (in *ExternalNameService) DeepCopy() *ExternalNameService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayLoadBalancerRoute) DeepCopyInto(out *GatewayLoadBalancerRoute) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayLoadBalancerRoute.
+func (in *GatewayLoadBalancerRoute) DeepCopy() *GatewayLoadBalancerRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayLoadBalancerRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostedControlPlaneSelector) DeepCopyInto(out *HostedControlPlaneSelector) {
 	*out = *in
@@ -171,10 +237,96 @@ func (in *ObjectFieldSelector) DeepCopy() *ObjectFieldSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedChange) DeepCopyInto(out *PlannedChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedChange.
+func (in *PlannedChange) DeepCopy() *PlannedChange {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Policy) DeepCopyInto(out *Policy) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Policy.
+func (in *Policy) DeepCopy() *Policy {
+	if in == nil {
+		return nil
+	}
+	out := new(Policy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RejectionPolicy) DeepCopyInto(out *RejectionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RejectionPolicy.
+func (in *RejectionPolicy) DeepCopy() *RejectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RejectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplacedRoute) DeepCopyInto(out *ReplacedRoute) {
+	*out = *in
+	out.Route = in.Route
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplacedRoute.
+func (in *ReplacedRoute) DeepCopy() *ReplacedRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplacedRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecordStatus) DeepCopyInto(out *ResourceRecordStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecordStatus.
+func (in *ResourceRecordStatus) DeepCopy() *ResourceRecordStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecordStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route53HostedZoneRecord) DeepCopyInto(out *Route53HostedZoneRecord) {
 	*out = *in
 	out.ExternalNameService = in.ExternalNameService
+	if in.Zonal != nil {
+		in, out := &in.Zonal, &out.Zonal
+		*out = new(ZonalRecords)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route53HostedZoneRecord.
@@ -202,7 +354,14 @@ func (in *Route53PrivateHostedZone) DeepCopyInto(out *Route53PrivateHostedZone)
 		*out = new(DomainName)
 		(*in).DeepCopyInto(*out)
 	}
-	out.Record = in.Record
+	in.Record.DeepCopyInto(&out.Record)
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]Route53HostedZoneRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route53PrivateHostedZone.
@@ -215,9 +374,44 @@ func (in *Route53PrivateHostedZone) DeepCopy() *Route53PrivateHostedZone {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteTables) DeepCopyInto(out *RouteTables) {
+	*out = *in
+	if in.Ids != nil {
+		in, out := &in.Ids, &out.Ids
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]Tag, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteTables.
+func (in *RouteTables) DeepCopy() *RouteTables {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteTables)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
 	*out = *in
+	if in.Ids != nil {
+		in, out := &in.Ids, &out.Ids
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]Tag, len(*in))
+		copy(*out, *in)
+	}
 	if in.IngressRules != nil {
 		in, out := &in.IngressRules, &out.IngressRules
 		*out = make([]SecurityGroupRule, len(*in))
@@ -372,6 +566,21 @@ func (in *VpcEndpoint) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEndpointDnsEntry) DeepCopyInto(out *VpcEndpointDnsEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEndpointDnsEntry.
+func (in *VpcEndpointDnsEntry) DeepCopy() *VpcEndpointDnsEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEndpointDnsEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VpcEndpointList) DeepCopyInto(out *VpcEndpointList) {
 	*out = *in
@@ -404,6 +613,26 @@ func (in *VpcEndpointList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEndpointNetworkInterface) DeepCopyInto(out *VpcEndpointNetworkInterface) {
+	*out = *in
+	if in.Ipv6Addresses != nil {
+		in, out := &in.Ipv6Addresses, &out.Ipv6Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEndpointNetworkInterface.
+func (in *VpcEndpointNetworkInterface) DeepCopy() *VpcEndpointNetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEndpointNetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VpcEndpointSpec) DeepCopyInto(out *VpcEndpointSpec) {
 	*out = *in
@@ -419,7 +648,32 @@ func (in *VpcEndpointSpec) DeepCopyInto(out *VpcEndpointSpec) {
 		**out = **in
 	}
 	in.Vpc.DeepCopyInto(&out.Vpc)
+	in.RouteTables.DeepCopyInto(&out.RouteTables)
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]GatewayLoadBalancerRoute, len(*in))
+		copy(*out, *in)
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(Policy)
+		(*in).DeepCopyInto(*out)
+	}
 	in.CustomDns.DeepCopyInto(&out.CustomDns)
+	out.RejectionPolicy = in.RejectionPolicy
+	out.DeletionPolicy = in.DeletionPolicy
+	if in.Adopt != nil {
+		in, out := &in.Adopt, &out.Adopt
+		*out = new(Adopt)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEndpointSpec.
@@ -435,6 +689,75 @@ func (in *VpcEndpointSpec) DeepCopy() *VpcEndpointSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VpcEndpointStatus) DeepCopyInto(out *VpcEndpointStatus) {
 	*out = *in
+	if in.UserSecurityGroupIds != nil {
+		in, out := &in.UserSecurityGroupIds, &out.UserSecurityGroupIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RouteTableIds != nil {
+		in, out := &in.RouteTableIds, &out.RouteTableIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]GatewayLoadBalancerRoute, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReplacedRoutes != nil {
+		in, out := &in.ReplacedRoutes, &out.ReplacedRoutes
+		*out = make([]ReplacedRoute, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]VpcEndpointSubnet, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkInterfaces != nil {
+		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
+		*out = make([]VpcEndpointNetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DnsEntries != nil {
+		in, out := &in.DnsEntries, &out.DnsEntries
+		*out = make([]VpcEndpointDnsEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceRecords != nil {
+		in, out := &in.ResourceRecords, &out.ResourceRecords
+		*out = make([]ResourceRecordStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastRejectionTime != nil {
+		in, out := &in.LastRejectionTime, &out.LastRejectionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AdoptedResources != nil {
+		in, out := &in.AdoptedResources, &out.AdoptedResources
+		*out = make([]AdoptedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]PlannedChange, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -454,6 +777,21 @@ func (in *VpcEndpointStatus) DeepCopy() *VpcEndpointStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEndpointSubnet) DeepCopyInto(out *VpcEndpointSubnet) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEndpointSubnet.
+func (in *VpcEndpointSubnet) DeepCopy() *VpcEndpointSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEndpointSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VpcEndpointTemplate) DeepCopyInto(out *VpcEndpointTemplate) {
 	*out = *in
@@ -561,3 +899,18 @@ func (in *VpceTemplateSpec) DeepCopy() *VpceTemplateSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZonalRecords) DeepCopyInto(out *ZonalRecords) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZonalRecords.
+func (in *ZonalRecords) DeepCopy() *ZonalRecords {
+	if in == nil {
+		return nil
+	}
+	out := new(ZonalRecords)
+	in.DeepCopyInto(out)
+	return out
+}
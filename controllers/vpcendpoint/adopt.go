@@ -0,0 +1,269 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/openshift/aws-vpce-operator/pkg/util"
+)
+
+// isAdopted returns true if the AWS resource has already been adopted from .spec.adopt
+func isAdopted(resource *avov1alpha2.VpcEndpoint, resourceType avov1alpha2.AdoptedResourceType, id string) bool {
+	for _, adopted := range resource.Status.AdoptedResources {
+		if adopted.Type == resourceType && adopted.Id == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// markAdopted records the adoption of an AWS resource in the VpcEndpoint CR's status, without updating it
+func (r *reconcileScope) markAdopted(resource *avov1alpha2.VpcEndpoint, resourceType avov1alpha2.AdoptedResourceType, id string) {
+	if isAdopted(resource, resourceType, id) {
+		return
+	}
+
+	resource.Status.AdoptedResources = append(resource.Status.AdoptedResources, avov1alpha2.AdoptedResource{
+		Type:         resourceType,
+		Id:           id,
+		AdoptionTime: metav1.Now(),
+	})
+	r.log.V(0).Info("Adopted AWS resource", "type", resourceType, "id", id)
+	r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Adopted", "Adopted %s: %s", resourceType, id)
+}
+
+// adoptionFailed returns an error for an AWS resource in .spec.adopt that can't be adopted and emits a Warning event
+// so that the mismatch is visible without reading the operator's logs
+func (r *reconcileScope) adoptionFailed(resource *avov1alpha2.VpcEndpoint, resourceType avov1alpha2.AdoptedResourceType, id, reason string) error {
+	r.Recorder.Eventf(resource, corev1.EventTypeWarning, "AdoptionFailed", "Unable to adopt %s %s: %s", resourceType, id, reason)
+	return fmt.Errorf("unable to adopt %s %s: %s", resourceType, id, reason)
+}
+
+// verifyAdoptedEc2Tags returns an error if the tags of an EC2 resource show that it's already managed by AVO under a
+// different name, i.e. for another VpcEndpoint CR
+func verifyAdoptedEc2Tags(tags []ec2Types.Tag, name string) error {
+	var managed bool
+	var actualName string
+	for _, tag := range tags {
+		switch aws.ToString(tag.Key) {
+		case util.OperatorTagKey:
+			managed = aws.ToString(tag.Value) == util.OperatorTagValue
+		case "Name":
+			actualName = aws.ToString(tag.Value)
+		}
+	}
+
+	if managed && actualName != name {
+		return fmt.Errorf("already managed as %s", actualName)
+	}
+
+	return nil
+}
+
+// adoptVpcEndpoint verifies that the VPC Endpoint in .spec.adopt.vpcEndpointId matches the spec, applies the tags AVO
+// uses to identify it and records it in the status, so that it's managed as if AVO had created it
+func (r *reconcileScope) adoptVpcEndpoint(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+	id := resource.Spec.Adopt.VpcEndpointId
+	if resource.Status.VPCEndpointId != "" && resource.Status.VPCEndpointId != id {
+		return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeVpcEndpoint, id, fmt.Sprintf("already managing VPC endpoint %s", resource.Status.VPCEndpointId))
+	}
+
+	resp, err := r.awsClient.DescribeSingleVPCEndpointById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if resp == nil || len(resp.VpcEndpoints) == 0 {
+		return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeVpcEndpoint, id, "not found")
+	}
+	vpce := resp.VpcEndpoints[0]
+
+	switch {
+	case strings.EqualFold(string(vpce.State), string(ec2Types.StateDeleting)), strings.EqualFold(string(vpce.State), string(ec2Types.StateDeleted)):
+		return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeVpcEndpoint, id, fmt.Sprintf("is %s", vpce.State))
+	case aws.ToString(vpce.VpcId) != resource.Status.VPCId:
+		return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeVpcEndpoint, id, fmt.Sprintf("is in VPC %s instead of %s", aws.ToString(vpce.VpcId), resource.Status.VPCId))
+	case aws.ToString(vpce.ServiceName) != resource.Status.VPCEndpointServiceName:
+		return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeVpcEndpoint, id, fmt.Sprintf("connects to %s instead of %s", aws.ToString(vpce.ServiceName), resource.Status.VPCEndpointServiceName))
+	case string(vpce.VpcEndpointType) != string(vpcEndpointType(resource)):
+		return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeVpcEndpoint, id, fmt.Sprintf("is of type %s instead of %s", vpce.VpcEndpointType, vpcEndpointType(resource)))
+	}
+
+	mismatch, err := r.adoptedVpcEndpointMismatch(ctx, resource, &vpce)
+	if err != nil {
+		return err
+	}
+	if mismatch != "" {
+		return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeVpcEndpoint, id, mismatch)
+	}
+
+	vpceName, err := util.GenerateVPCEndpointName(resource.Status.InfraId, resource.Name)
+	if err != nil {
+		return err
+	}
+	if err := verifyAdoptedEc2Tags(vpce.Tags, vpceName); err != nil {
+		return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeVpcEndpoint, id, err.Error())
+	}
+
+	expectedTags, err := util.GenerateAwsTagsAsMap(vpceName, r.clusterInfo.clusterTag)
+	if err != nil {
+		return err
+	}
+	maps.Copy(expectedTags, r.userTags(resource))
+	if _, err := r.ensureEc2Tags(ctx, resource, id, vpce.Tags, expectedTags); err != nil {
+		return err
+	}
+
+	resource.Status.VPCEndpointId = id
+	r.markAdopted(resource, avov1alpha2.AdoptedResourceTypeVpcEndpoint, id)
+	if err := r.Status().Update(ctx, resource); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// adoptedVpcEndpointMismatch returns how the subnets, security groups, policy or private DNS of a VPC Endpoint in
+// .spec.adopt.vpcEndpointId differ from the spec, if they do. AVO would otherwise modify the VPC Endpoint in use to
+// match the spec once adopted, e.g. by detaching its security groups or moving its network interfaces.
+func (r *reconcileScope) adoptedVpcEndpointMismatch(ctx context.Context, resource *avov1alpha2.VpcEndpoint, vpce *ec2Types.VpcEndpoint) (string, error) {
+	if vpcEndpointType(resource) != avov1alpha2.VpcEndpointTypeGateway {
+		expectedSubnetIds, err := r.expectedVpcEndpointSubnetIds(ctx, resource)
+		if err != nil {
+			return "", err
+		}
+		if toAdd, toRemove := util.StringSliceTwoWayDiff(vpce.SubnetIds, expectedSubnetIds); len(toAdd) > 0 || len(toRemove) > 0 {
+			return fmt.Sprintf("is in subnets %v instead of %v", vpce.SubnetIds, expectedSubnetIds), nil
+		}
+	}
+
+	if vpcEndpointType(resource) == avov1alpha2.VpcEndpointTypeInterface {
+		if toAdd, toRemove := r.diffVpcEndpointSecurityGroups(vpce, resource); len(toAdd) > 0 || len(toRemove) > 0 {
+			return fmt.Sprintf("is missing security groups %v and has unexpected security groups %v", toAdd, toRemove), nil
+		}
+
+		if aws.ToBool(vpce.PrivateDnsEnabled) != resource.Spec.EnablePrivateDns {
+			return fmt.Sprintf("has private DNS enabled %t instead of %t", aws.ToBool(vpce.PrivateDnsEnabled), resource.Spec.EnablePrivateDns), nil
+		}
+	}
+
+	policyDocument, err := r.getVpcEndpointPolicyDocument(ctx, resource)
+	if err != nil && !errors.Is(err, errPolicyDocumentNotFound) {
+		return "", err
+	}
+	if policyDocument != "" && !policyDocumentMatches(vpce, policyDocument) {
+		return "has a different policy than .spec.policy", nil
+	}
+
+	return "", nil
+}
+
+// adoptSecurityGroup verifies that the security group in .spec.adopt.securityGroupId is in the VPC Endpoint's VPC,
+// applies the tags AVO uses to identify it and records it in the status as the managed security group
+func (r *reconcileScope) adoptSecurityGroup(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+	id := resource.Spec.Adopt.SecurityGroupId
+	if resource.Status.SecurityGroupId != "" && resource.Status.SecurityGroupId != id {
+		return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeSecurityGroup, id, fmt.Sprintf("already managing security group %s", resource.Status.SecurityGroupId))
+	}
+
+	resp, err := r.awsClient.FilterSecurityGroupById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if resp == nil || len(resp.SecurityGroups) == 0 {
+		return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeSecurityGroup, id, "not found")
+	}
+	sg := resp.SecurityGroups[0]
+
+	if aws.ToString(sg.VpcId) != resource.Status.VPCId {
+		return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeSecurityGroup, id, fmt.Sprintf("is in VPC %s instead of %s", aws.ToString(sg.VpcId), resource.Status.VPCId))
+	}
+
+	sgName, err := util.GenerateSecurityGroupName(resource.Status.InfraId, resource.Name)
+	if err != nil {
+		return err
+	}
+	if err := verifyAdoptedEc2Tags(sg.Tags, sgName); err != nil {
+		return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeSecurityGroup, id, err.Error())
+	}
+
+	if err := r.ensureSecurityGroupTags(ctx, &sg, resource); err != nil {
+		return err
+	}
+
+	resource.Status.SecurityGroupId = id
+	r.markAdopted(resource, avov1alpha2.AdoptedResourceTypeSecurityGroup, id)
+	if err := r.Status().Update(ctx, resource); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// adoptRoute53Records verifies that the records in .spec.adopt.route53Records that haven't been adopted yet exist in
+// the hosted zone and are expected for the VpcEndpoint CR, recording them in the status without updating it. They're
+// then upserted, or replaced if their type differs, along with the other expected records.
+func (r *reconcileScope) adoptRoute53Records(ctx context.Context, resource *avov1alpha2.VpcEndpoint, hostedZoneId string, expected []route53Record) error {
+	if resource.Spec.Adopt == nil {
+		return nil
+	}
+
+	var pending []string
+	for _, name := range resource.Spec.Adopt.Route53Records {
+		if !isAdopted(resource, avov1alpha2.AdoptedResourceTypeRoute53Record, name) {
+			pending = append(pending, name)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	for _, name := range pending {
+		isExpected := false
+		for _, record := range expected {
+			isExpected = isExpected || aws_client.Route53RecordNameEqual(name, record.status.Name)
+		}
+		if !isExpected {
+			return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeRoute53Record, name, "not one of the expected records")
+		}
+
+		// Only the records with the name are listed, which may be past the first page of a large hosted zone
+		existing, err := r.awsClient.ListResourceRecordSetsByName(ctx, hostedZoneId, name)
+		if err != nil {
+			return err
+		}
+		if len(existing) == 0 {
+			return r.adoptionFailed(resource, avov1alpha2.AdoptedResourceTypeRoute53Record, name, fmt.Sprintf("not found in hosted zone %s", hostedZoneId))
+		}
+
+		r.markAdopted(resource, avov1alpha2.AdoptedResourceTypeRoute53Record, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,391 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+)
+
+const mockAdoptedServiceName = "com.amazonaws.vpce.us-east-1.vpce-svc-12345"
+
+func mockAdoptVpcEndpoint(adopt *avov1alpha2.Adopt, status avov1alpha2.VpcEndpointStatus) *avov1alpha2.VpcEndpoint {
+	status.VPCId = aws_client.MockVpcId
+	status.VPCEndpointServiceName = mockAdoptedServiceName
+	status.InfraId = testutil.MockInfrastructureName
+
+	return &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "default"},
+		Spec:       avov1alpha2.VpcEndpointSpec{Adopt: adopt},
+		Status:     status,
+	}
+}
+
+func TestVpcEndpointReconciler_adoptVpcEndpoint(t *testing.T) {
+	legacy := ec2Types.VpcEndpoint{
+		VpcEndpointId:   aws.String("vpce-0123456789abcdef0"),
+		VpcId:           aws.String(aws_client.MockVpcId),
+		ServiceName:     aws.String(mockAdoptedServiceName),
+		VpcEndpointType: ec2Types.VpcEndpointTypeInterface,
+		State:           "available",
+		SubnetIds:       []string{"subnet-a", "subnet-b"},
+		Groups:          []ec2Types.SecurityGroupIdentifier{{GroupId: aws.String("sg-terraform")}},
+		Tags:            []ec2Types.Tag{{Key: aws.String("Name"), Value: aws.String("terraform")}},
+	}
+
+	tests := []struct {
+		name      string
+		vpce      func(ec2Types.VpcEndpoint) ec2Types.VpcEndpoint
+		spec      func(*avov1alpha2.VpcEndpointSpec)
+		status    avov1alpha2.VpcEndpointStatus
+		expectErr bool
+		// expectedErr, when set, is contained in the expected error
+		expectedErr string
+	}{
+		{
+			name: "matching",
+			vpce: func(vpce ec2Types.VpcEndpoint) ec2Types.VpcEndpoint { return vpce },
+		},
+		{
+			name: "re-adopted after status loss",
+			vpce: func(vpce ec2Types.VpcEndpoint) ec2Types.VpcEndpoint {
+				vpce.Tags = mockManagedEc2Tags("mock-12345-legacy-vpce")
+				return vpce
+			},
+		},
+		{
+			name: "different VPC",
+			vpce: func(vpce ec2Types.VpcEndpoint) ec2Types.VpcEndpoint {
+				vpce.VpcId = aws.String("vpc-other")
+				return vpce
+			},
+			expectErr: true,
+		},
+		{
+			name: "different service",
+			vpce: func(vpce ec2Types.VpcEndpoint) ec2Types.VpcEndpoint {
+				vpce.ServiceName = aws.String("com.amazonaws.us-east-1.s3")
+				return vpce
+			},
+			expectErr: true,
+		},
+		{
+			name: "different type",
+			vpce: func(vpce ec2Types.VpcEndpoint) ec2Types.VpcEndpoint {
+				vpce.VpcEndpointType = ec2Types.VpcEndpointTypeGateway
+				return vpce
+			},
+			expectErr: true,
+		},
+		{
+			name: "different subnets",
+			vpce: func(vpce ec2Types.VpcEndpoint) ec2Types.VpcEndpoint {
+				vpce.SubnetIds = []string{"subnet-a", "subnet-c"}
+				return vpce
+			},
+			expectErr:   true,
+			expectedErr: "is in subnets",
+		},
+		{
+			name: "different security groups",
+			vpce: func(vpce ec2Types.VpcEndpoint) ec2Types.VpcEndpoint {
+				vpce.Groups = []ec2Types.SecurityGroupIdentifier{{GroupId: aws.String("sg-other")}}
+				return vpce
+			},
+			expectErr:   true,
+			expectedErr: "security groups",
+		},
+		{
+			name: "different policy",
+			vpce: func(vpce ec2Types.VpcEndpoint) ec2Types.VpcEndpoint {
+				vpce.PolicyDocument = aws.String(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`)
+				return vpce
+			},
+			spec: func(spec *avov1alpha2.VpcEndpointSpec) {
+				spec.Policy = &avov1alpha2.Policy{Document: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`}
+			},
+			expectErr:   true,
+			expectedErr: "different policy",
+		},
+		{
+			name: "matching policy",
+			vpce: func(vpce ec2Types.VpcEndpoint) ec2Types.VpcEndpoint {
+				vpce.PolicyDocument = aws.String(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`)
+				return vpce
+			},
+			spec: func(spec *avov1alpha2.VpcEndpointSpec) {
+				spec.Policy = &avov1alpha2.Policy{Document: `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "*", "Resource": "*"}]}`}
+			},
+		},
+		{
+			name: "private DNS disabled",
+			vpce: func(vpce ec2Types.VpcEndpoint) ec2Types.VpcEndpoint { return vpce },
+			spec: func(spec *avov1alpha2.VpcEndpointSpec) {
+				spec.EnablePrivateDns = true
+			},
+			expectErr:   true,
+			expectedErr: "private DNS",
+		},
+		{
+			name: "deleting",
+			vpce: func(vpce ec2Types.VpcEndpoint) ec2Types.VpcEndpoint {
+				vpce.State = "deleting"
+				return vpce
+			},
+			expectErr: true,
+		},
+		{
+			name: "managed by another VpcEndpoint",
+			vpce: func(vpce ec2Types.VpcEndpoint) ec2Types.VpcEndpoint {
+				vpce.Tags = mockManagedEc2Tags("mock-12345-other-vpce")
+				return vpce
+			},
+			expectErr: true,
+		},
+		{
+			name:      "already managing another VPC Endpoint",
+			vpce:      func(vpce ec2Types.VpcEndpoint) ec2Types.VpcEndpoint { return vpce },
+			status:    avov1alpha2.VpcEndpointStatus{VPCEndpointId: "vpce-created"},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vpce := test.vpce(legacy)
+			test.status.UserSecurityGroupIds = []string{"sg-terraform"}
+			resource := mockAdoptVpcEndpoint(&avov1alpha2.Adopt{VpcEndpointId: *legacy.VpcEndpointId}, test.status)
+			resource.Spec.Vpc.SubnetIds = []string{"subnet-b", "subnet-a"}
+			if test.spec != nil {
+				test.spec(&resource.Spec)
+			}
+			ec2Client := &aws_client.MockedEC2{VpcEndpoints: []ec2Types.VpcEndpoint{vpce}}
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:   testutil.NewTestMock(t, resource).Client,
+					Recorder: record.NewFakeRecorder(1),
+				},
+				log:         testr.New(t),
+				awsClient:   aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
+				clusterInfo: &clusterInfo{clusterTag: aws_client.MockLegacyClusterTag},
+			}
+
+			err := r.adoptVpcEndpoint(context.TODO(), resource)
+			if test.expectErr {
+				assert.Error(t, err)
+				if test.expectedErr != "" {
+					assert.ErrorContains(t, err, test.expectedErr)
+				}
+				assert.Empty(t, resource.Status.AdoptedResources)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, *legacy.VpcEndpointId, resource.Status.VPCEndpointId)
+			assert.True(t, isAdopted(resource, avov1alpha2.AdoptedResourceTypeVpcEndpoint, *legacy.VpcEndpointId))
+			if len(vpce.Tags) == 1 {
+				// The default tags are applied to find the VPC Endpoint if the status is lost
+				assert.Len(t, ec2Client.CreateTagsInputs, 1)
+				assert.Equal(t, []string{*legacy.VpcEndpointId}, ec2Client.CreateTagsInputs[0].Resources)
+			}
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_findOrCreateVpcEndpoint_adopt(t *testing.T) {
+	resource := mockAdoptVpcEndpoint(&avov1alpha2.Adopt{VpcEndpointId: "vpce-0123456789abcdef0"}, avov1alpha2.VpcEndpointStatus{})
+	ec2Client := &aws_client.MockedEC2{
+		VpcEndpoints: []ec2Types.VpcEndpoint{
+			{
+				VpcEndpointId:   aws.String("vpce-0123456789abcdef0"),
+				VpcId:           aws.String(aws_client.MockVpcId),
+				ServiceName:     aws.String(mockAdoptedServiceName),
+				VpcEndpointType: ec2Types.VpcEndpointTypeInterface,
+				State:           "available",
+			},
+		},
+	}
+	recorder := record.NewFakeRecorder(1)
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:   testutil.NewTestMock(t, resource).Client,
+			Recorder: recorder,
+		},
+		log:         testr.New(t),
+		awsClient:   aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
+		clusterInfo: &clusterInfo{clusterTag: aws_client.MockLegacyClusterTag},
+	}
+
+	vpce, err := r.findOrCreateVpcEndpoint(context.TODO(), resource)
+	assert.NoError(t, err)
+	assert.Equal(t, "vpce-0123456789abcdef0", *vpce.VpcEndpointId)
+	assert.Nil(t, ec2Client.LastCreateVpcEndpointInput)
+	assert.Len(t, recorder.Events, 1)
+
+	// Once adopted, the VPC Endpoint is found by id like any other
+	_, err = r.findOrCreateVpcEndpoint(context.TODO(), resource)
+	assert.NoError(t, err)
+	assert.Len(t, resource.Status.AdoptedResources, 1)
+	assert.Len(t, recorder.Events, 1)
+}
+
+func TestVpcEndpointReconciler_adoptSecurityGroup(t *testing.T) {
+	tests := []struct {
+		name      string
+		sg        ec2Types.SecurityGroup
+		expectErr bool
+	}{
+		{
+			name: "matching",
+			sg:   ec2Types.SecurityGroup{GroupId: aws.String("sg-0123456789abcdef0"), VpcId: aws.String(aws_client.MockVpcId)},
+		},
+		{
+			name:      "different VPC",
+			sg:        ec2Types.SecurityGroup{GroupId: aws.String("sg-0123456789abcdef0"), VpcId: aws.String("vpc-other")},
+			expectErr: true,
+		},
+		{
+			name: "managed by another VpcEndpoint",
+			sg: ec2Types.SecurityGroup{
+				GroupId: aws.String("sg-0123456789abcdef0"),
+				VpcId:   aws.String(aws_client.MockVpcId),
+				Tags:    mockManagedEc2Tags("mock-12345-other-sg"),
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := mockAdoptVpcEndpoint(&avov1alpha2.Adopt{SecurityGroupId: "sg-0123456789abcdef0"}, avov1alpha2.VpcEndpointStatus{})
+			ec2Client := &aws_client.MockedEC2{SecurityGroups: []ec2Types.SecurityGroup{test.sg}}
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:   testutil.NewTestMock(t, resource).Client,
+					Recorder: record.NewFakeRecorder(2),
+				},
+				log:         testr.New(t),
+				awsClient:   aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
+				clusterInfo: &clusterInfo{clusterTag: aws_client.MockLegacyClusterTag},
+			}
+
+			err := r.adoptSecurityGroup(context.TODO(), resource)
+			if test.expectErr {
+				assert.Error(t, err)
+				assert.Empty(t, resource.Status.SecurityGroupId)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "sg-0123456789abcdef0", resource.Status.SecurityGroupId)
+			assert.True(t, isAdopted(resource, avov1alpha2.AdoptedResourceTypeSecurityGroup, "sg-0123456789abcdef0"))
+			assert.Len(t, ec2Client.CreateTagsInputs, 1)
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_adoptRoute53Records(t *testing.T) {
+	expected := []route53Record{
+		{status: avov1alpha2.ResourceRecordStatus{Name: "api.example.com", Type: "CNAME"}},
+	}
+
+	tests := []struct {
+		name      string
+		records   []string
+		adopted   []avov1alpha2.AdoptedResource
+		missing   bool
+		paged     bool
+		expectErr bool
+	}{
+		{
+			name:    "existing expected record",
+			records: []string{"api.example.com."},
+		},
+		{
+			name:    "already adopted",
+			records: []string{"api.example.com."},
+			adopted: []avov1alpha2.AdoptedResource{{Type: avov1alpha2.AdoptedResourceTypeRoute53Record, Id: "api.example.com."}},
+		},
+		{
+			name:    "existing expected record past the first page",
+			records: []string{"api.example.com."},
+			paged:   true,
+		},
+		{
+			name:      "unexpected record",
+			records:   []string{"other.example.com"},
+			expectErr: true,
+		},
+		{
+			name:      "missing record",
+			records:   []string{"api.example.com"},
+			missing:   true,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := mockAdoptVpcEndpoint(&avov1alpha2.Adopt{Route53Records: test.records}, avov1alpha2.VpcEndpointStatus{AdoptedResources: test.adopted})
+			route53Client := &aws_client.MockedRoute53{
+				ResourceRecordSets: map[string][]route53Types.ResourceRecordSet{
+					"ZEXAMPLE": {{Name: aws.String("api.example.com."), Type: route53Types.RRTypeA}},
+				},
+			}
+			if test.missing {
+				route53Client.ResourceRecordSets["ZEXAMPLE"] = nil
+			}
+			if test.paged {
+				route53Client.ResourceRecordSetsPageSize = 1
+				route53Client.ResourceRecordSets["ZEXAMPLE"] = []route53Types.ResourceRecordSet{
+					{Name: aws.String("example.com."), Type: route53Types.RRTypeNs},
+					{Name: aws.String("example.com."), Type: route53Types.RRTypeSoa},
+					{Name: aws.String("api.example.com."), Type: route53Types.RRTypeA},
+					{Name: aws.String("apps.example.com."), Type: route53Types.RRTypeA},
+				}
+			}
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Recorder: record.NewFakeRecorder(1),
+				},
+				log:       testr.New(t),
+				awsClient: aws_client.NewAwsClientWithServiceClients(&aws_client.MockedEC2{}, route53Client),
+			}
+
+			err := r.adoptRoute53Records(context.TODO(), resource, "ZEXAMPLE", expected)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, resource.Status.AdoptedResources, 1)
+		})
+	}
+}
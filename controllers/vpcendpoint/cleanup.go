@@ -22,6 +22,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 	"github.com/aws/smithy-go"
 	configv1 "github.com/openshift/api/config/v1"
@@ -32,10 +35,11 @@ import (
 
 	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
 	"github.com/openshift/aws-vpce-operator/pkg/dnses"
+	"github.com/openshift/aws-vpce-operator/pkg/util"
 )
 
 // cleanupAwsResources cleans up AWS resources associated with a VPC Endpoint.
-func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+func (r *reconcileScope) cleanupAwsResources(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
 	r.log.V(0).Info("Starting AWS resource cleanup",
 		"vpcEndpoint", resource.Name,
 		"namespace", resource.Namespace,
@@ -43,11 +47,14 @@ func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resourc
 		"securityGroupId", resource.Status.SecurityGroupId,
 		"hostedZoneId", resource.Status.HostedZoneId,
 		"resourceRecordSet", resource.Status.ResourceRecordSet,
+		"resourceRecords", len(resource.Status.ResourceRecords),
 	)
 	r.Recorder.Eventf(resource, corev1.EventTypeNormal, "CleanupStarted",
 		"Starting cleanup of AWS resources (vpceId=%s, sgId=%s, hzId=%s)",
 		resource.Status.VPCEndpointId, resource.Status.SecurityGroupId, resource.Status.HostedZoneId)
 
+	policy := deletionPolicy(resource)
+
 	if meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSRoute53RecordCondition) {
 		// Ensure .status.hostedZoneId is populated. During deletion, skip the live
 		// validation if the hosted zone ID is already cached in status, since the
@@ -59,8 +66,19 @@ func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resourc
 			}
 		}
 
-		// HostedZoneId and resourceRecord are required if we want to clean up a ResourceRecordSet
-		if resource.Status.HostedZoneId != "" && resource.Status.ResourceRecordSet != "" {
+		// HostedZoneId and owned records are required if we want to clean up a ResourceRecordSet
+		owned := ownedRoute53Records(resource)
+		if resource.Status.HostedZoneId != "" && len(owned) > 0 && policy.Route53Records != avov1alpha2.DeletionPolicyDelete {
+			// Route53 records have no tags, so they're kept as is with both the Retain and Orphan actions
+			r.keptResource(resource, policy.Route53Records, "Route53 record(s) in hosted zone", resource.Status.HostedZoneId)
+			resource.Status.ResourceRecordSet = ""
+			resource.Status.ResourceRecords = nil
+			meta.RemoveStatusCondition(&resource.Status.Conditions, avov1alpha2.AWSRoute53RecordCondition)
+			if err := r.Status().Update(ctx, resource); err != nil {
+				r.log.V(0).Error(err, "failed to update status")
+				return err
+			}
+		} else if resource.Status.HostedZoneId != "" && len(owned) > 0 {
 			resp, err := r.awsClient.GetHostedZone(ctx, resource.Status.HostedZoneId)
 			if err != nil {
 				return err
@@ -72,27 +90,28 @@ func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resourc
 					return err
 				}
 
-				// Delete all records in the hosted zone except the default SOA and NS records
+				// Only delete the records that AVO created
 				for _, resourceRecord := range listRRSResp.ResourceRecordSets {
 					rr := resourceRecord
-					switch *rr.Name {
-					case fmt.Sprintf("%s.", resource.Status.ResourceRecordSet):
-						r.log.V(0).Info("Deleting Route53 Hosted Zone Record", "name", *resourceRecord.Name, "type", resourceRecord.Type)
-						if _, err := r.awsClient.DeleteResourceRecordSet(ctx, &rr, *resp.HostedZone.Id); err != nil {
-							return err
-						}
-					default:
+					if !isOwnedRoute53Record(rr, owned) {
 						continue
 					}
+
+					r.log.V(0).Info("Deleting Route53 Hosted Zone Record", "name", *resourceRecord.Name, "type", resourceRecord.Type)
+					if _, err := r.awsClient.DeleteResourceRecordSet(ctx, &rr, *resp.HostedZone.Id); err != nil {
+						return err
+					}
 				}
 			}
 
 			resource.Status.ResourceRecordSet = ""
+			resource.Status.ResourceRecords = nil
 			meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-				Type:    avov1alpha2.AWSRoute53RecordCondition,
-				Status:  metav1.ConditionFalse,
-				Reason:  "Deleted",
-				Message: "Deleted Route53 Hosted Zone Record",
+				Type:               avov1alpha2.AWSRoute53RecordCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "Deleted",
+				Message:            "Deleted Route53 Hosted Zone Record",
+				ObservedGeneration: resource.Generation,
 			})
 
 			if err := r.Status().Update(ctx, resource); err != nil {
@@ -104,7 +123,11 @@ func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resourc
 		}
 	}
 
-	if resource.Status.HostedZoneId != "" {
+	if resource.Status.HostedZoneId != "" && policy.HostedZone != avov1alpha2.DeletionPolicyDelete {
+		if err := r.keepHostedZone(ctx, resource, policy.HostedZone); err != nil {
+			return err
+		}
+	} else if resource.Status.HostedZoneId != "" {
 		// Only delete a Route53 Private Hosted Zone if AVO created it
 		if resource.Spec.CustomDns.Route53PrivateHostedZone.DomainName != "" || resource.Spec.CustomDns.Route53PrivateHostedZone.DomainNameRef != nil {
 			// don't delete the zone if it's the cluster's private zone
@@ -194,7 +217,32 @@ func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resourc
 		}
 	}
 
-	if resource.Status.VPCEndpointId != "" {
+	// Routes through a GatewayLoadBalancer VPC Endpoint are deleted, or restored to their original target, first, so
+	// that they aren't left behind as blackhole routes once the VPC Endpoint is deleted
+	if len(resource.Status.Routes) > 0 && policy.VpcEndpoint == avov1alpha2.DeletionPolicyDelete {
+		for _, route := range resource.Status.Routes {
+			if err := r.deleteVpcEndpointRoute(ctx, resource, route, resource.Status.VPCEndpointId); err != nil {
+				return err
+			}
+		}
+
+		resource.Status.Routes = nil
+		resource.Status.ReplacedRoutes = nil
+		if err := r.Status().Update(ctx, resource); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+			return err
+		}
+	}
+
+	if resource.Status.VPCEndpointId != "" && policy.VpcEndpoint != avov1alpha2.DeletionPolicyDelete {
+		if err := r.cleanupMetrics(ctx, resource); err != nil {
+			return err
+		}
+
+		if err := r.keepEc2Resource(ctx, resource, policy.VpcEndpoint, "VPC endpoint", resource.Status.VPCEndpointId); err != nil {
+			return err
+		}
+	} else if resource.Status.VPCEndpointId != "" {
 		if err := r.cleanupMetrics(ctx, resource); err != nil {
 			return err
 		}
@@ -208,6 +256,7 @@ func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resourc
 					r.log.V(0).Info("VPC endpoint already deleted", "vpceId", vpceId)
 					r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Deleted", "VPC endpoint already deleted: %s", vpceId)
 					resource.Status.VPCEndpointId = ""
+					clearVpcEndpointObservedState(resource)
 				} else {
 					return err
 				}
@@ -235,6 +284,7 @@ func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resourc
 				if resp == nil || len(resp.VpcEndpoints) == 0 {
 					r.log.V(0).Info("VPC endpoint deletion confirmed", "vpceId", vpceId)
 					resource.Status.VPCEndpointId = ""
+					clearVpcEndpointObservedState(resource)
 					break
 				}
 
@@ -242,6 +292,7 @@ func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resourc
 				if state == "deleted" {
 					r.log.V(0).Info("VPC endpoint deletion confirmed", "vpceId", vpceId)
 					resource.Status.VPCEndpointId = ""
+					clearVpcEndpointObservedState(resource)
 					break
 				}
 
@@ -269,7 +320,13 @@ func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resourc
 		}
 	}
 
-	if resource.Status.SecurityGroupId != "" {
+	// Only the managed security group is deleted, the user's security groups in .status.userSecurityGroupIds are
+	// owned by the user
+	if resource.Status.SecurityGroupId != "" && policy.SecurityGroup != avov1alpha2.DeletionPolicyDelete {
+		if err := r.keepEc2Resource(ctx, resource, policy.SecurityGroup, "security group", resource.Status.SecurityGroupId); err != nil {
+			return err
+		}
+	} else if resource.Status.SecurityGroupId != "" {
 		sgId := resource.Status.SecurityGroupId
 		r.log.V(0).Info("Deleting security group", "securityGroupId", sgId)
 		if _, err := r.awsClient.DeleteSecurityGroup(ctx, sgId); err != nil {
@@ -300,8 +357,129 @@ func (r *VpcEndpointReconciler) cleanupAwsResources(ctx context.Context, resourc
 	return nil
 }
 
+// deletionPolicy returns the VpcEndpoint CR's deletion policy with the defaults applied. A resource that a kept
+// resource depends on is kept with the same action: the managed security group can't be deleted while it's attached
+// to a kept VPC Endpoint, and deleting a hosted zone would delete the kept records in it.
+func deletionPolicy(resource *avov1alpha2.VpcEndpoint) avov1alpha2.DeletionPolicy {
+	policy := resource.Spec.DeletionPolicy
+	for _, action := range []*avov1alpha2.DeletionPolicyAction{&policy.VpcEndpoint, &policy.SecurityGroup, &policy.HostedZone, &policy.Route53Records} {
+		if *action == "" {
+			*action = avov1alpha2.DeletionPolicyDelete
+		}
+	}
+
+	if policy.SecurityGroup == avov1alpha2.DeletionPolicyDelete {
+		policy.SecurityGroup = policy.VpcEndpoint
+	}
+	if policy.HostedZone == avov1alpha2.DeletionPolicyDelete {
+		policy.HostedZone = policy.Route53Records
+	}
+
+	return policy
+}
+
+// keptResource logs and records an event for an AWS resource that is kept according to the deletion policy
+func (r *reconcileScope) keptResource(resource *avov1alpha2.VpcEndpoint, action avov1alpha2.DeletionPolicyAction, kind, id string) {
+	reason := "Orphaned"
+	if action == avov1alpha2.DeletionPolicyRetain {
+		reason = "Retained"
+	}
+
+	r.log.V(0).Info("Keeping AWS resource according to the deletion policy", "kind", kind, "id", id, "action", action)
+	r.Recorder.Eventf(resource, corev1.EventTypeNormal, reason, "%s %s: %s", reason, kind, id)
+}
+
+// keepEc2Resource keeps an EC2 resource managed for a VpcEndpoint CR being deleted. With the Retain action, the
+// resource is released from AVO by marking it as retained instead of managed and removing the cluster tag, so that
+// the garbage collector ignores it and it can be adopted with .spec.adopt, possibly from another cluster. With the
+// Orphan action, the resource keeps its tags and is only marked as orphaned by the VpcEndpoint CR, so that the
+// garbage collector ignores it until a VpcEndpoint CR with the same name picks it up again.
+func (r *reconcileScope) keepEc2Resource(ctx context.Context, resource *avov1alpha2.VpcEndpoint, action avov1alpha2.DeletionPolicyAction, kind, id string) error {
+	switch action {
+	case avov1alpha2.DeletionPolicyRetain:
+		clusterTag, err := util.GetClusterLegacyTagKey(resource.Status.InfraId)
+		if err != nil {
+			return err
+		}
+
+		if _, err := r.awsClient.CreateTags(ctx, &ec2.CreateTagsInput{
+			Resources: []string{id},
+			Tags:      []ec2Types.Tag{{Key: aws.String(util.OperatorTagKey), Value: aws.String(util.OperatorTagRetainedValue)}},
+		}); err != nil {
+			return fmt.Errorf("failed to retain %s %s: %w", kind, id, err)
+		}
+		if _, err := r.awsClient.DeleteTags(ctx, &ec2.DeleteTagsInput{
+			Resources: []string{id},
+			Tags:      []ec2Types.Tag{{Key: aws.String(clusterTag)}},
+		}); err != nil {
+			return fmt.Errorf("failed to retain %s %s: %w", kind, id, err)
+		}
+	case avov1alpha2.DeletionPolicyOrphan:
+		if _, err := r.awsClient.CreateTags(ctx, &ec2.CreateTagsInput{
+			Resources: []string{id},
+			Tags:      []ec2Types.Tag{{Key: aws.String(util.OrphanedTagKey), Value: aws.String(orphanedTagValue(resource))}},
+		}); err != nil {
+			return fmt.Errorf("failed to orphan %s %s: %w", kind, id, err)
+		}
+	}
+
+	r.keptResource(resource, action, kind, id)
+	return nil
+}
+
+// keepHostedZone keeps the Route53 Private Hosted Zone of a VpcEndpoint CR being deleted. A hosted zone created by
+// AVO is retained or orphaned in the same way as EC2 resources, see keepEc2Resource. Hosted zones AVO didn't create
+// are never deleted and their tags are left as is, in particular the cluster's own hosted zone.
+func (r *reconcileScope) keepHostedZone(ctx context.Context, resource *avov1alpha2.VpcEndpoint, action avov1alpha2.DeletionPolicyAction) error {
+	zone := resource.Spec.CustomDns.Route53PrivateHostedZone
+	if zone.DomainName == "" && zone.DomainNameRef == nil {
+		return nil
+	}
+
+	if zone.DomainName != "" {
+		dnsConfig := &configv1.DNS{}
+		if err := r.Get(ctx, client.ObjectKey{Name: dnses.DefaultDnsesName}, dnsConfig); err != nil {
+			return err
+		}
+		if zone.DomainName == dnsConfig.Spec.BaseDomain {
+			return nil
+		}
+	}
+
+	switch action {
+	case avov1alpha2.DeletionPolicyRetain:
+		clusterTag, err := util.GetClusterLegacyTagKey(resource.Status.InfraId)
+		if err != nil {
+			return err
+		}
+
+		if err := r.awsClient.ChangeHostedZoneTags(ctx, resource.Status.HostedZoneId,
+			[]route53Types.Tag{{Key: aws.String(util.OperatorTagKey), Value: aws.String(util.OperatorTagRetainedValue)}},
+			[]string{clusterTag},
+		); err != nil {
+			return fmt.Errorf("failed to retain Route53 hosted zone %s: %w", resource.Status.HostedZoneId, err)
+		}
+	case avov1alpha2.DeletionPolicyOrphan:
+		if err := r.awsClient.ChangeHostedZoneTags(ctx, resource.Status.HostedZoneId,
+			[]route53Types.Tag{{Key: aws.String(util.OrphanedTagKey), Value: aws.String(orphanedTagValue(resource))}},
+			nil,
+		); err != nil {
+			return fmt.Errorf("failed to orphan Route53 hosted zone %s: %w", resource.Status.HostedZoneId, err)
+		}
+	}
+
+	r.keptResource(resource, action, "Route53 hosted zone", resource.Status.HostedZoneId)
+	return nil
+}
+
+// orphanedTagValue returns the value of the tag marking the AWS resources orphaned by a VpcEndpoint CR, which
+// identifies the VpcEndpoint CR that picks them up again
+func orphanedTagValue(resource *avov1alpha2.VpcEndpoint) string {
+	return resource.Namespace + "/" + resource.Name
+}
+
 // cleanupMetrics deletes metrics associated with a specific VPCEndpoint custom resource in a best-effort manner
-func (r *VpcEndpointReconciler) cleanupMetrics(_ context.Context, resource *avov1alpha2.VpcEndpoint) error {
+func (r *reconcileScope) cleanupMetrics(_ context.Context, resource *avov1alpha2.VpcEndpoint) error {
 	if resource.Status.VPCEndpointId != "" {
 		// DeleteLabelValues returns true if the metric is deleted, false otherwise, currently we don't really care
 		// either way, so just always return nil
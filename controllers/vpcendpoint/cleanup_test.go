@@ -20,10 +20,15 @@ import (
 	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 	"github.com/go-logr/logr/testr"
+	configv1 "github.com/openshift/api/config/v1"
 	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
 	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
 	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+	"github.com/openshift/aws-vpce-operator/pkg/util"
 	"github.com/stretchr/testify/assert"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -55,6 +60,30 @@ func TestVpcEndpointReconciler_cleanupAwsResources(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "owned Route53 records",
+			resource: &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mock-records",
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCEndpointId:     testutil.MockVpcEndpointId,
+					HostedZoneId:      aws_client.MockHostedZoneId,
+					ResourceRecordSet: "mock",
+					ResourceRecords: []avov1alpha2.ResourceRecordStatus{
+						{Hostname: "mock", Name: "mock", Type: "CNAME"},
+						{Hostname: "*.apps", Name: "*.apps.example.com", Type: "A"},
+					},
+					Conditions: []metav1.Condition{
+						{
+							Type:   avov1alpha2.AWSRoute53RecordCondition,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
 		{
 			name: "private DNS enabled has no Route53 condition so cleanup skips Route53 naturally",
 			resource: &avov1alpha2.VpcEndpoint{
@@ -81,10 +110,12 @@ func TestVpcEndpointReconciler_cleanupAwsResources(t *testing.T) {
 			if test.resource != nil {
 				client = testutil.NewTestMock(t, test.resource).Client
 			}
-			r := &VpcEndpointReconciler{
-				Client:      client,
-				Scheme:      client.Scheme(),
-				Recorder:    record.NewFakeRecorder(10),
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:   client,
+					Scheme:   client.Scheme(),
+					Recorder: record.NewFakeRecorder(10),
+				},
 				awsClient:   aws_client.NewMockedAwsClientWithSubnets(),
 				log:         testr.New(t),
 				clusterInfo: &clusterInfo{},
@@ -95,6 +126,244 @@ func TestVpcEndpointReconciler_cleanupAwsResources(t *testing.T) {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Empty(t, test.resource.Status.ResourceRecordSet)
+				assert.Empty(t, test.resource.Status.ResourceRecords)
+			}
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_cleanupAwsResources_keepsUserSecurityGroups(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mock1",
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			SecurityGroup: avov1alpha2.SecurityGroup{
+				Ids: []string{"sg-user"},
+			},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			SecurityGroupId:      aws_client.MockSecurityGroupId,
+			UserSecurityGroupIds: []string{"sg-user"},
+		},
+	}
+
+	client := testutil.NewTestMock(t, resource).Client
+	ec2Client := &aws_client.MockedEC2{}
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:   client,
+			Scheme:   client.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		},
+		awsClient:   aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
+		log:         testr.New(t),
+		clusterInfo: &clusterInfo{},
+	}
+
+	assert.NoError(t, r.cleanupAwsResources(context.TODO(), resource))
+	assert.Equal(t, []string{aws_client.MockSecurityGroupId}, ec2Client.DeletedSecurityGroupIds)
+}
+
+func TestVpcEndpointReconciler_cleanupAwsResources_deletesRoutes(t *testing.T) {
+	route := avov1alpha2.GatewayLoadBalancerRoute{RouteTableId: aws_client.MockRouteTableId, DestinationCidrBlock: "0.0.0.0/0"}
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mock1",
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			Type:   avov1alpha2.VpcEndpointTypeGatewayLoadBalancer,
+			Routes: []avov1alpha2.GatewayLoadBalancerRoute{route},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			VPCEndpointId: testutil.MockVpcEndpointId,
+			Routes:        []avov1alpha2.GatewayLoadBalancerRoute{route},
+		},
+	}
+
+	client := testutil.NewTestMock(t, resource).Client
+	ec2Client := &aws_client.MockedEC2{
+		RouteTables: []ec2Types.RouteTable{
+			{
+				RouteTableId: aws.String(aws_client.MockRouteTableId),
+				Routes: []ec2Types.Route{
+					{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String(testutil.MockVpcEndpointId)},
+				},
+			},
+		},
+	}
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:   client,
+			Scheme:   client.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		},
+		awsClient:   aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
+		log:         testr.New(t),
+		clusterInfo: &clusterInfo{},
+	}
+
+	assert.NoError(t, r.cleanupAwsResources(context.TODO(), resource))
+	assert.Len(t, ec2Client.DeleteRouteInputs, 1)
+	assert.Equal(t, aws.String("0.0.0.0/0"), ec2Client.DeleteRouteInputs[0].DestinationCidrBlock)
+	assert.Empty(t, resource.Status.Routes)
+}
+
+func TestDeletionPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   avov1alpha2.DeletionPolicy
+		expected avov1alpha2.DeletionPolicy
+	}{
+		{
+			name: "defaults",
+			expected: avov1alpha2.DeletionPolicy{
+				VpcEndpoint:    avov1alpha2.DeletionPolicyDelete,
+				SecurityGroup:  avov1alpha2.DeletionPolicyDelete,
+				HostedZone:     avov1alpha2.DeletionPolicyDelete,
+				Route53Records: avov1alpha2.DeletionPolicyDelete,
+			},
+		},
+		{
+			name: "dependencies of kept resources are kept",
+			policy: avov1alpha2.DeletionPolicy{
+				VpcEndpoint:    avov1alpha2.DeletionPolicyRetain,
+				Route53Records: avov1alpha2.DeletionPolicyOrphan,
+			},
+			expected: avov1alpha2.DeletionPolicy{
+				VpcEndpoint:    avov1alpha2.DeletionPolicyRetain,
+				SecurityGroup:  avov1alpha2.DeletionPolicyRetain,
+				HostedZone:     avov1alpha2.DeletionPolicyOrphan,
+				Route53Records: avov1alpha2.DeletionPolicyOrphan,
+			},
+		},
+		{
+			name: "dependencies may be kept alone",
+			policy: avov1alpha2.DeletionPolicy{
+				SecurityGroup: avov1alpha2.DeletionPolicyOrphan,
+				HostedZone:    avov1alpha2.DeletionPolicyRetain,
+			},
+			expected: avov1alpha2.DeletionPolicy{
+				VpcEndpoint:    avov1alpha2.DeletionPolicyDelete,
+				SecurityGroup:  avov1alpha2.DeletionPolicyOrphan,
+				HostedZone:     avov1alpha2.DeletionPolicyRetain,
+				Route53Records: avov1alpha2.DeletionPolicyDelete,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, deletionPolicy(&avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{DeletionPolicy: test.policy},
+			}))
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_cleanupAwsResources_deletionPolicy(t *testing.T) {
+	tests := []struct {
+		name                   string
+		policy                 avov1alpha2.DeletionPolicy
+		expectedVpcEndpointIds []string
+		expectedSgIds          []string
+		expectedHostedZoneIds  []string
+		expectedRecordChanges  int
+		expectedRetainedIds    []string
+		expectedOrphanedIds    []string
+		expectedZoneTagChanges int
+	}{
+		{
+			name:                   "retain everything",
+			policy:                 avov1alpha2.DeletionPolicy{VpcEndpoint: avov1alpha2.DeletionPolicyRetain, Route53Records: avov1alpha2.DeletionPolicyRetain},
+			expectedRetainedIds:    []string{testutil.MockVpcEndpointId, aws_client.MockSecurityGroupId},
+			expectedZoneTagChanges: 1,
+		},
+		{
+			name:                   "orphan everything",
+			policy:                 avov1alpha2.DeletionPolicy{VpcEndpoint: avov1alpha2.DeletionPolicyOrphan, Route53Records: avov1alpha2.DeletionPolicyOrphan},
+			expectedOrphanedIds:    []string{testutil.MockVpcEndpointId, aws_client.MockSecurityGroupId},
+			expectedZoneTagChanges: 1,
+		},
+		{
+			name:                   "retain the security group only",
+			policy:                 avov1alpha2.DeletionPolicy{SecurityGroup: avov1alpha2.DeletionPolicyRetain},
+			expectedVpcEndpointIds: []string{testutil.MockVpcEndpointId},
+			expectedHostedZoneIds:  []string{aws_client.MockHostedZoneId},
+			expectedRecordChanges:  1,
+			expectedRetainedIds:    []string{aws_client.MockSecurityGroupId},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{Name: "mock1", Namespace: "default"},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					DeletionPolicy: test.policy,
+					CustomDns: avov1alpha2.CustomDns{
+						Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{DomainName: "example.com"},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					InfraId:         testutil.MockInfrastructureName,
+					VPCEndpointId:   testutil.MockVpcEndpointId,
+					SecurityGroupId: aws_client.MockSecurityGroupId,
+					HostedZoneId:    aws_client.MockHostedZoneId,
+					ResourceRecords: []avov1alpha2.ResourceRecordStatus{{Hostname: "mock", Name: "mock.example.com", Type: "CNAME"}},
+					Conditions: []metav1.Condition{
+						{Type: avov1alpha2.AWSRoute53RecordCondition, Status: metav1.ConditionTrue},
+					},
+				},
+			}
+			client := testutil.NewTestMock(t, resource, &configv1.DNS{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       configv1.DNSSpec{BaseDomain: testutil.MockDomainName},
+			}).Client
+
+			ec2Client := &aws_client.MockedEC2{}
+			route53Client := &aws_client.MockedRoute53{
+				ResourceRecordSets: map[string][]route53Types.ResourceRecordSet{
+					aws_client.MockHostedZoneId: {{Name: aws.String("mock.example.com."), Type: route53Types.RRTypeCname}},
+				},
+			}
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:   client,
+					Scheme:   client.Scheme(),
+					Recorder: record.NewFakeRecorder(10),
+				},
+				awsClient:   aws_client.NewAwsClientWithServiceClients(ec2Client, route53Client),
+				log:         testr.New(t),
+				clusterInfo: &clusterInfo{},
+			}
+
+			assert.NoError(t, r.cleanupAwsResources(context.TODO(), resource))
+			assert.Equal(t, test.expectedVpcEndpointIds, ec2Client.DeletedVpcEndpointIds)
+			assert.Equal(t, test.expectedSgIds, ec2Client.DeletedSecurityGroupIds)
+			assert.Equal(t, test.expectedHostedZoneIds, route53Client.DeletedHostedZoneIds)
+			assert.Len(t, route53Client.ChangeResourceRecordSetsInputs, test.expectedRecordChanges)
+			assert.Len(t, route53Client.ChangeTagsInputs, test.expectedZoneTagChanges)
+
+			// Retained resources are marked as retained instead of managed and lose the cluster tag, orphaned resources
+			// keep their tags and are marked as orphaned so that the garbage collector ignores them
+			var retainedIds, orphanedIds []string
+			for _, input := range ec2Client.CreateTagsInputs {
+				switch aws.ToString(input.Tags[0].Key) {
+				case util.OperatorTagKey:
+					assert.Equal(t, util.OperatorTagRetainedValue, aws.ToString(input.Tags[0].Value))
+					retainedIds = append(retainedIds, input.Resources...)
+				case util.OrphanedTagKey:
+					assert.Equal(t, "default/mock1", aws.ToString(input.Tags[0].Value))
+					orphanedIds = append(orphanedIds, input.Resources...)
+				}
+			}
+			assert.Equal(t, test.expectedRetainedIds, retainedIds)
+			assert.Equal(t, test.expectedOrphanedIds, orphanedIds)
+			assert.Len(t, ec2Client.DeleteTagsInputs, len(test.expectedRetainedIds))
+			for _, input := range ec2Client.DeleteTagsInputs {
+				assert.Equal(t, aws_client.MockLegacyClusterTag, aws.ToString(input.Tags[0].Key))
 			}
 		})
 	}
@@ -16,9 +16,40 @@ limitations under the License.
 
 package vpcendpoint
 
+import "time"
+
 const (
 	// avoFinalizer is added to the VpcEndpoint object to prevent its deletion until all AWS resources
 	// have been cleaned up
 	avoFinalizer   = "vpcendpoint.avo.openshift.io/finalizer"
 	ControllerName = "VpcEndpoint"
+
+	// assumeRoleSessionTagNamespace and assumeRoleSessionTagName are the session tag keys used to identify the
+	// VpcEndpoint that an assumed role session is reconciling
+	assumeRoleSessionTagNamespace = "avo.openshift.io/namespace"
+	assumeRoleSessionTagName      = "avo.openshift.io/name"
+
+	// vpcEndpointRejectedReason is the AWSVpcEndpointReady condition reason used after a rejected VPC Endpoint
+	// has been deleted, until it is recreated
+	vpcEndpointRejectedReason = "Rejected"
+
+	// networkCidrsNotFoundReason is the AWSSecurityGroupReady condition reason used when a security group rule's
+	// networkCidrs don't resolve to any CIDRs
+	networkCidrsNotFoundReason = "NetworkCidrsNotFound"
+
+	// route53RecordsNotGeneratedReason is the AWSRoute53RecordReady condition reason used when the expected Route53
+	// records can't be generated, e.g. before the VPC Endpoint is available
+	route53RecordsNotGeneratedReason = "RecordsNotGenerated"
+
+	// defaultRejectionInitialBackoff and maxRejectionBackoff bound the delay before a rejected VPC Endpoint
+	// is recreated
+	defaultRejectionInitialBackoff = time.Minute
+	maxRejectionBackoff            = time.Hour
+
+	// defaultRoute53RecordTTL is the TTL in seconds of a Route 53 record when .spec.customDns.route53PrivateHostedZone.record.ttl
+	// is unset
+	defaultRoute53RecordTTL int64 = 300
+
+	// assumeRoleSessionNameMaxLength is the maximum length of an sts:AssumeRole role session name
+	assumeRoleSessionNameMaxLength = 64
 )
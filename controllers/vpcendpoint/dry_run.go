@@ -0,0 +1,166 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+)
+
+// dryRunScope returns a copy of the reconcile scope whose Kubernetes writes are sent with dryRun=All and whose events
+// describing changes as if they had been made are dropped
+func (r *reconcileScope) dryRunScope() *reconcileScope {
+	reconciler := *r.VpcEndpointReconciler
+	reconciler.Client = client.NewDryRunClient(r.Client)
+	reconciler.Recorder = &dryRunEventRecorder{EventRecorder: r.Recorder}
+	dryRun := *r
+	dryRun.VpcEndpointReconciler = &reconciler
+
+	return &dryRun
+}
+
+// isDryRun returns true if the VpcEndpoint CR is reconciled in dry-run mode by the avo.openshift.io/dry-run annotation
+func isDryRun(vpce *avov1alpha2.VpcEndpoint) bool {
+	return vpce.Annotations[avov1alpha2.DryRunAnnotation] == "true"
+}
+
+// reconcileDryRun runs the validations, or the cleanup of a VpcEndpoint CR being deleted, with AWS mutations recorded
+// instead of made and Kubernetes writes sent with dryRun=All. The validations and cleanup update planned, a copy of the
+// CR, in memory as if the changes had been made. Only the recorded plan is written to the CR's status.
+func (r *reconcileScope) reconcileDryRun(ctx context.Context, vpce, planned *avov1alpha2.VpcEndpoint) (ctrl.Result, error) {
+	r.log.V(0).Info("Reconciling VpcEndpoint in dry-run mode", "vpcEndpoint", vpce.Name, "namespace", vpce.Namespace)
+
+	recorder := aws_client.NewDryRunRecorder()
+	dryRun := r.dryRunScope()
+	dryRun.awsClient = aws_client.NewDryRunAwsClient(r.awsClient, recorder)
+	dryRun.dryRunRecorder = recorder
+
+	var err error
+	switch {
+	case vpce.DeletionTimestamp.IsZero():
+		err = dryRun.validateResources(ctx, planned, dryRun.validations())
+	case controllerutil.ContainsFinalizer(vpce, avoFinalizer):
+		err = dryRun.cleanupAwsResources(ctx, planned)
+	}
+
+	var requeueErr *requeueAfterError
+	switch {
+	case err == nil, errors.Is(err, aws_client.ErrDryRun):
+		err = nil
+	case errors.As(err, &requeueErr):
+		r.log.V(0).Info(requeueErr.reason, "vpcEndpoint", vpce.Name, "namespace", vpce.Namespace, "requeueAfter", requeueErr.after)
+		err = nil
+	default:
+		r.log.V(0).Error(err, "Dry-run stopped before the end of the plan", "vpcEndpoint", vpce.Name, "namespace", vpce.Namespace)
+	}
+
+	if reportErr := r.reportPlannedChanges(ctx, vpce, recorder.Changes()); reportErr != nil {
+		return ctrl.Result{}, reportErr
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: time.Minute * 15}, nil
+}
+
+// reportPlannedChanges writes the planned changes to the VpcEndpoint CR's status and emits an event for each of them
+// when the plan changes
+func (r *reconcileScope) reportPlannedChanges(ctx context.Context, vpce *avov1alpha2.VpcEndpoint, changes []avov1alpha2.PlannedChange) error {
+	if equality.Semantic.DeepEqual(vpce.Status.PlannedChanges, changes) {
+		return nil
+	}
+
+	r.log.V(0).Info("Planned AWS changes", "vpcEndpoint", vpce.Name, "namespace", vpce.Namespace, "changes", len(changes))
+	if len(changes) == 0 {
+		r.Recorder.Event(vpce, corev1.EventTypeNormal, "NoChangesPlanned", "No AWS changes planned")
+	}
+	for _, change := range changes {
+		r.Recorder.Event(vpce, corev1.EventTypeNormal, "PlannedChange", formatPlannedChange(change))
+	}
+
+	vpce.Status.PlannedChanges = changes
+	if err := r.Status().Update(ctx, vpce); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// clearPlannedChanges removes the planned changes from the status once a VpcEndpoint CR leaves dry-run mode
+func (r *reconcileScope) clearPlannedChanges(ctx context.Context, vpce *avov1alpha2.VpcEndpoint) error {
+	if len(vpce.Status.PlannedChanges) == 0 {
+		return nil
+	}
+
+	vpce.Status.PlannedChanges = nil
+	if err := r.Status().Update(ctx, vpce); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// formatPlannedChange returns a planned change as a single line, e.g. "ModifyVpcEndpoint vpce-12345: addSubnets=subnet-1"
+func formatPlannedChange(change avov1alpha2.PlannedChange) string {
+	message := change.Operation
+	if change.Resource != "" {
+		message += " " + change.Resource
+	}
+	if change.Details != "" {
+		message += ": " + change.Details
+	}
+
+	return message
+}
+
+// dryRunEventRecorder drops the Normal events that describe changes as if they had been made in dry-run mode, so that
+// only the PlannedChange events and Warnings are emitted
+type dryRunEventRecorder struct {
+	record.EventRecorder
+}
+
+func (d *dryRunEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if eventtype != corev1.EventTypeNormal {
+		d.EventRecorder.Event(object, eventtype, reason, message)
+	}
+}
+
+func (d *dryRunEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	if eventtype != corev1.EventTypeNormal {
+		d.EventRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+	}
+}
+
+func (d *dryRunEventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	if eventtype != corev1.EventTypeNormal {
+		d.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+	}
+}
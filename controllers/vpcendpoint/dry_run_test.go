@@ -0,0 +1,193 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr/testr"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+)
+
+func TestVpcEndpointReconciler_reconcileDryRun(t *testing.T) {
+	tests := []struct {
+		name              string
+		deleting          bool
+		status            avov1alpha2.VpcEndpointStatus
+		vpcEndpoints      []ec2Types.VpcEndpoint
+		expectedOperation string
+	}{
+		{
+			name: "create",
+			status: avov1alpha2.VpcEndpointStatus{
+				VPCId:                  aws_client.MockVpcId,
+				VPCEndpointServiceName: aws_client.MockVpcEndpointServiceName,
+				InfraId:                testutil.MockInfrastructureName,
+			},
+			vpcEndpoints:      []ec2Types.VpcEndpoint{},
+			expectedOperation: "CreateVpcEndpoint",
+		},
+		{
+			name:     "delete",
+			deleting: true,
+			status: avov1alpha2.VpcEndpointStatus{
+				VPCEndpointId:   testutil.MockVpcEndpointId,
+				SecurityGroupId: aws_client.MockSecurityGroupId,
+				InfraId:         testutil.MockInfrastructureName,
+			},
+			expectedOperation: "DeleteVpcEndpoints",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vpce := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "mock",
+					Namespace:   "default",
+					Annotations: map[string]string{avov1alpha2.DryRunAnnotation: "true"},
+					Finalizers:  []string{avoFinalizer},
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: avov1alpha2.SecurityGroup{
+						ManagedSecurityGroup: avov1alpha2.ManagedSecurityGroupDisabled,
+					},
+				},
+				Status: test.status,
+			}
+			if test.deleting {
+				vpce.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			}
+
+			client := testutil.NewTestMock(t, vpce).Client
+			ec2Client := &aws_client.MockedEC2{VpcEndpoints: test.vpcEndpoints}
+			route53Client := &aws_client.MockedRoute53{}
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:   client,
+					Scheme:   client.Scheme(),
+					Recorder: record.NewFakeRecorder(20),
+				},
+				awsClient: aws_client.NewAwsClientWithServiceClients(ec2Client, route53Client),
+				log:       testr.New(t),
+				clusterInfo: &clusterInfo{
+					clusterTag: aws_client.MockLegacyClusterTag,
+				},
+			}
+
+			result, err := r.reconcileDryRun(context.TODO(), vpce, vpce.DeepCopy())
+			assert.NoError(t, err)
+			assert.Equal(t, 15*time.Minute, result.RequeueAfter)
+
+			// Nothing was changed in AWS
+			assert.Nil(t, ec2Client.LastCreateVpcEndpointInput)
+			assert.Empty(t, ec2Client.DeletedVpcEndpointIds)
+			assert.Empty(t, ec2Client.DeletedSecurityGroupIds)
+			assert.Empty(t, ec2Client.CreateTagsInputs)
+
+			// Only the plan was written to the CR
+			actual := &avov1alpha2.VpcEndpoint{}
+			assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: "mock", Namespace: "default"}, actual))
+			assert.Equal(t, []string{avoFinalizer}, actual.Finalizers)
+			assert.Equal(t, test.status.VPCEndpointId, actual.Status.VPCEndpointId)
+			assert.Equal(t, test.status.SecurityGroupId, actual.Status.SecurityGroupId)
+			assert.Empty(t, actual.Status.Conditions)
+			if assert.NotEmpty(t, actual.Status.PlannedChanges) {
+				assert.Equal(t, test.expectedOperation, actual.Status.PlannedChanges[len(actual.Status.PlannedChanges)-1].Operation)
+			}
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_clearPlannedChanges(t *testing.T) {
+	vpce := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "mock", Namespace: "default"},
+		Status: avov1alpha2.VpcEndpointStatus{
+			PlannedChanges: []avov1alpha2.PlannedChange{{Operation: "CreateVpcEndpoint"}},
+		},
+	}
+
+	client := testutil.NewTestMock(t, vpce).Client
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client: client,
+		},
+		log: testr.New(t),
+	}
+
+	assert.NoError(t, r.clearPlannedChanges(context.TODO(), vpce))
+
+	actual := &avov1alpha2.VpcEndpoint{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: "mock", Namespace: "default"}, actual))
+	assert.Empty(t, actual.Status.PlannedChanges)
+}
+
+func TestVpcEndpointReconciler_dryRunScope(t *testing.T) {
+	vpce := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "mock",
+			Namespace:   "default",
+			Annotations: map[string]string{avov1alpha2.DryRunAnnotation: "true"},
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			ServiceName: aws_client.MockVpcEndpointServiceName,
+			Region:      testutil.MockAWSRegion,
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			VPCId: aws_client.MockVpcId,
+		},
+	}
+	infrastructure := &configv1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Status: configv1.InfrastructureStatus{
+			InfrastructureName: testutil.MockInfrastructureName,
+		},
+	}
+
+	client := testutil.NewTestMock(t, vpce, infrastructure).Client
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:   client,
+			Scheme:   client.Scheme(),
+			Recorder: record.NewFakeRecorder(20),
+		},
+		log: testr.New(t),
+	}
+
+	// The status written while parsing the cluster info of a VpcEndpoint in dry-run mode is only set on the copy
+	planned := vpce.DeepCopy()
+	dryRun := r.dryRunScope()
+	assert.NoError(t, dryRun.parseClusterInfo(context.TODO(), planned, false))
+	assert.Equal(t, testutil.MockInfrastructureName, planned.Status.InfraId)
+	assert.Equal(t, aws_client.MockVpcEndpointServiceName, planned.Status.VPCEndpointServiceName)
+
+	actual := &avov1alpha2.VpcEndpoint{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: "mock", Namespace: "default"}, actual))
+	assert.Empty(t, actual.Status.InfraId)
+	assert.Empty(t, actual.Status.VPCEndpointServiceName)
+}
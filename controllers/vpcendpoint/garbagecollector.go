@@ -0,0 +1,516 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/smithy-go"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/openshift/aws-vpce-operator/pkg/dnses"
+	"github.com/openshift/aws-vpce-operator/pkg/infrastructures"
+	"github.com/openshift/aws-vpce-operator/pkg/util"
+)
+
+const (
+	// GarbageCollectorName is the name of the garbage collector in logs and events
+	GarbageCollectorName = "vpcendpoint-garbage-collector"
+
+	// DefaultGarbageCollectorInterval is how often the garbage collector runs unless configured otherwise
+	DefaultGarbageCollectorInterval = time.Hour
+
+	// duplicateVpcEndpointGracePeriod is how long a VPC Endpoint whose Name tag matches a VpcEndpoint CR, but which
+	// isn't the CR's .status.vpcEndpointId, is left alone, since the status is only updated after the VPC Endpoint
+	// has been created, e.g. when it's recreated by the rejection policy
+	duplicateVpcEndpointGracePeriod = time.Hour
+)
+
+// Resource types of orphaned AWS resources, used as metric labels
+const (
+	orphanTypeVpcEndpoint   = "vpc_endpoint"
+	orphanTypeSecurityGroup = "security_group"
+	orphanTypeHostedZone    = "hosted_zone"
+	orphanTypeRoute53Record = "route53_record"
+)
+
+// GarbageCollector periodically looks for the AWS resources managed by AVO for this cluster whose VpcEndpoint CR no
+// longer exists, e.g. after its finalizer was removed by hand, its cleanup failed or a duplicate was created, and
+// reports them through metrics and Warning events on the cluster's Infrastructure. Orphaned resources are only
+// deleted when DeleteOrphans is true.
+// Only the resources in the cluster's region that are visible with the controller's default AWS credentials are
+// considered, so resources created with a credential override, assumed role or region override are not.
+type GarbageCollector struct {
+	client.Client
+	Recorder record.EventRecorder
+
+	// Interval is how often the garbage collector runs
+	Interval time.Duration
+
+	// DeleteOrphans allows the garbage collector to delete the orphaned AWS resources it finds
+	DeleteOrphans bool
+
+	// AWSClientPool shares AWS clients with the controllers, a nil AWSClientPool builds new AWS clients every run
+	AWSClientPool *aws_client.ClientPool
+
+	log       logr.Logger
+	awsClient *aws_client.AWSClient
+}
+
+// orphanInventory are the AWS resources found by the garbage collector that don't belong to a VpcEndpoint CR
+type orphanInventory struct {
+	vpcEndpoints   []ec2Types.VpcEndpoint
+	securityGroups []ec2Types.SecurityGroup
+	hostedZones    []route53Types.HostedZone
+
+	// records are the orphaned Route53 records by hosted zone id, including those in orphaned hosted zones
+	records map[string][]route53Types.ResourceRecordSet
+}
+
+// liveResources identifies the AWS resources that belong to existing VpcEndpoint CRs
+type liveResources struct {
+	vpcEndpointIds   map[string]bool
+	securityGroupIds map[string]bool
+	hostedZoneIds    map[string]bool
+	domainNames      map[string]bool
+
+	// vpcEndpointNames are the expected Name tags of VPC Endpoints, mapped to whether a VpcEndpoint CR with that name
+	// has no .status.vpcEndpointId yet
+	vpcEndpointNames map[string]bool
+	// securityGroupNames are the expected Name tags of managed security groups
+	securityGroupNames map[string]bool
+
+	// records are the Route53 records owned by VpcEndpoint CRs by hosted zone id
+	records map[string][]avov1alpha2.ResourceRecordStatus
+}
+
+// SetupWithManager adds the garbage collector to the manager, which runs it while it's the leader
+func (g *GarbageCollector) SetupWithManager(mgr ctrl.Manager) error {
+	if g.Interval <= 0 {
+		g.Interval = DefaultGarbageCollectorInterval
+	}
+
+	return mgr.Add(g)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so only the leader collects garbage
+func (g *GarbageCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the garbage collector every Interval until the context is cancelled
+func (g *GarbageCollector) Start(ctx context.Context) error {
+	g.log = ctrl.Log.WithName("controller").WithName(GarbageCollectorName)
+	g.log.V(0).Info("Starting garbage collector", "interval", g.Interval, "deleteOrphans", g.DeleteOrphans)
+
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := g.collect(ctx, true); err != nil {
+			garbageCollectionFailure.Inc()
+			awsUnauthorizedOperationMetricHandler(err)
+			g.log.V(0).Error(err, "garbage collection failed")
+		}
+	}, g.Interval)
+
+	return nil
+}
+
+// collect finds, reports and, if enabled, deletes orphaned AWS resources.
+// Generally, refreshAWSSession is only set to false during testing to mock the AWS client.
+func (g *GarbageCollector) collect(ctx context.Context, refreshAWSSession bool) error {
+	infra, err := infrastructures.GetInfrastructure(ctx, g.Client)
+	if err != nil {
+		return err
+	}
+
+	clusterTag, err := util.GetClusterLegacyTagKey(infra.Status.InfrastructureName)
+	if err != nil {
+		return err
+	}
+
+	baseDomain, err := dnses.GetPrivateHostedZoneDomainName(ctx, g.Client, dnses.DefaultDnsesName)
+	if err != nil {
+		return fmt.Errorf("failed to get the cluster's base domain: %w", err)
+	}
+
+	if refreshAWSSession {
+		region, err := infrastructures.GetAWSRegion(ctx, g.Client)
+		if err != nil {
+			return err
+		}
+
+		clients, err := g.AWSClientPool.Get(ctx, aws_client.ClientPoolKey{
+			Region:           region,
+			CredentialSource: aws_client.DefaultCredentialSource,
+		}, func(ctx context.Context) (aws.Config, error) {
+			return config.LoadDefaultConfig(ctx, config.WithRegion(region))
+		})
+		if err != nil {
+			return err
+		}
+		g.awsClient = clients.AwsClient()
+	}
+
+	// Inventory the AWS resources before listing the VpcEndpoint CRs, so that resources created for a new
+	// VpcEndpoint CR in the meantime are never mistaken for orphans
+	vpcEndpoints, err := g.awsClient.FilterVPCEndpointsByClusterTag(ctx, clusterTag)
+	if err != nil {
+		return fmt.Errorf("failed to list VPC Endpoints: %w", err)
+	}
+
+	securityGroups, err := g.awsClient.FilterSecurityGroupsByClusterTag(ctx, clusterTag)
+	if err != nil {
+		return fmt.Errorf("failed to list security groups: %w", err)
+	}
+
+	hostedZones, keptHostedZoneIds, err := g.listManagedHostedZones(ctx, clusterTag)
+	if err != nil {
+		return fmt.Errorf("failed to list hosted zones: %w", err)
+	}
+
+	vpceList := new(avov1alpha2.VpcEndpointList)
+	if err := g.List(ctx, vpceList); err != nil {
+		return fmt.Errorf("failed to list VpcEndpoints: %w", err)
+	}
+	live := newLiveResources(vpceList.Items, infra.Status.InfrastructureName)
+
+	orphans := &orphanInventory{records: map[string][]route53Types.ResourceRecordSet{}}
+	now := time.Now()
+	// Resources kept with the Orphan deletion policy are left for a VpcEndpoint CR with the same name to pick up
+	for _, vpce := range vpcEndpoints {
+		if ec2TagValue(vpce.Tags, util.OrphanedTagKey) == "" && !live.ownsVpcEndpoint(vpce, now) {
+			orphans.vpcEndpoints = append(orphans.vpcEndpoints, vpce)
+		}
+	}
+	for _, sg := range securityGroups {
+		if ec2TagValue(sg.Tags, util.OrphanedTagKey) == "" &&
+			!live.securityGroupIds[aws.ToString(sg.GroupId)] && !live.securityGroupNames[ec2TagValue(sg.Tags, "Name")] {
+			orphans.securityGroups = append(orphans.securityGroups, sg)
+		}
+	}
+
+	// Records can't be tagged, so only the records pointing to orphaned VPC Endpoints are considered orphaned
+	orphanedDnsNames := map[string]bool{}
+	for _, vpce := range orphans.vpcEndpoints {
+		for _, dnsEntry := range vpce.DnsEntries {
+			orphanedDnsNames[normalizeDnsName(aws.ToString(dnsEntry.DnsName))] = true
+		}
+	}
+
+	for _, hz := range hostedZones {
+		id := strings.TrimPrefix(aws.ToString(hz.Id), "/hostedzone/")
+		// The cluster's private hosted zone is tagged when VpcEndpoint CRs use it, but must never be deleted, and hosted
+		// zones kept with the Orphan deletion policy are left for a VpcEndpoint CR with the same name to pick up
+		isOrphan := !live.hostedZoneIds[id] && !live.domainNames[normalizeDnsName(aws.ToString(hz.Name))] &&
+			normalizeDnsName(aws.ToString(hz.Name)) != normalizeDnsName(baseDomain) && !keptHostedZoneIds[id]
+		if isOrphan {
+			orphans.hostedZones = append(orphans.hostedZones, hz)
+		}
+
+		if len(orphanedDnsNames) == 0 {
+			continue
+		}
+
+		// Every page of records is listed, orphaned records may be anywhere in a large hosted zone
+		resp, err := g.awsClient.ListResourceRecordSets(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to list records in hosted zone %s: %w", id, err)
+		}
+
+		for _, rrs := range resp.ResourceRecordSets {
+			if orphanedDnsNames[route53RecordTarget(rrs)] && !isOwnedRoute53Record(rrs, live.records[id]) {
+				orphans.records[id] = append(orphans.records[id], rrs)
+			}
+		}
+	}
+
+	g.report(infra, orphans)
+
+	if !g.DeleteOrphans {
+		return nil
+	}
+
+	return g.deleteOrphans(ctx, infra, orphans)
+}
+
+// listManagedHostedZones returns the private hosted zones with AVO's default tags for the cluster, along with the ids
+// of those kept with the Orphan deletion policy
+func (g *GarbageCollector) listManagedHostedZones(ctx context.Context, clusterTag string) ([]route53Types.HostedZone, map[string]bool, error) {
+	hostedZones, err := g.awsClient.ListPrivateHostedZones(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]string, 0, len(hostedZones))
+	for _, hz := range hostedZones {
+		ids = append(ids, strings.TrimPrefix(aws.ToString(hz.Id), "/hostedzone/"))
+	}
+
+	// Tags are fetched in batches, one call per zone would run into Route53's rate limit in accounts with many zones
+	tagsById, err := g.awsClient.FetchPrivateZonesTags(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var managed []route53Types.HostedZone
+	kept := map[string]bool{}
+	for i, hz := range hostedZones {
+		var hasOperatorTag, hasClusterTag, isKept bool
+		for _, tag := range tagsById[ids[i]] {
+			switch aws.ToString(tag.Key) {
+			case util.OperatorTagKey:
+				hasOperatorTag = aws.ToString(tag.Value) == util.OperatorTagValue
+			case clusterTag:
+				hasClusterTag = true
+			case util.OrphanedTagKey:
+				isKept = true
+			}
+		}
+
+		if hasOperatorTag && hasClusterTag {
+			managed = append(managed, hz)
+			kept[ids[i]] = isKept
+		}
+	}
+
+	return managed, kept, nil
+}
+
+// report updates the orphaned resources metric and emits a Warning event for every orphaned resource
+func (g *GarbageCollector) report(infra client.Object, orphans *orphanInventory) {
+	records := 0
+	for id, rrsets := range orphans.records {
+		for _, rrs := range rrsets {
+			g.log.V(0).Info("Found orphaned Route53 record", "hostedZoneId", id, "name", aws.ToString(rrs.Name), "type", rrs.Type)
+			g.Recorder.Eventf(infra, corev1.EventTypeWarning, "OrphanedResource",
+				"Found orphaned Route53 record %s (%s) in hosted zone %s", aws.ToString(rrs.Name), rrs.Type, id)
+		}
+		records += len(rrsets)
+	}
+
+	for _, vpce := range orphans.vpcEndpoints {
+		g.log.V(0).Info("Found orphaned VPC Endpoint", "id", aws.ToString(vpce.VpcEndpointId), "name", ec2TagValue(vpce.Tags, "Name"))
+		g.Recorder.Eventf(infra, corev1.EventTypeWarning, "OrphanedResource",
+			"Found orphaned VPC Endpoint %s (%s) without a VpcEndpoint", aws.ToString(vpce.VpcEndpointId), ec2TagValue(vpce.Tags, "Name"))
+	}
+
+	for _, sg := range orphans.securityGroups {
+		g.log.V(0).Info("Found orphaned security group", "id", aws.ToString(sg.GroupId), "name", ec2TagValue(sg.Tags, "Name"))
+		g.Recorder.Eventf(infra, corev1.EventTypeWarning, "OrphanedResource",
+			"Found orphaned security group %s (%s) without a VpcEndpoint", aws.ToString(sg.GroupId), ec2TagValue(sg.Tags, "Name"))
+	}
+
+	for _, hz := range orphans.hostedZones {
+		g.log.V(0).Info("Found orphaned hosted zone", "id", aws.ToString(hz.Id), "name", aws.ToString(hz.Name))
+		g.Recorder.Eventf(infra, corev1.EventTypeWarning, "OrphanedResource",
+			"Found orphaned hosted zone %s (%s) without a VpcEndpoint", aws.ToString(hz.Id), aws.ToString(hz.Name))
+	}
+
+	orphanedResources.WithLabelValues(orphanTypeVpcEndpoint).Set(float64(len(orphans.vpcEndpoints)))
+	orphanedResources.WithLabelValues(orphanTypeSecurityGroup).Set(float64(len(orphans.securityGroups)))
+	orphanedResources.WithLabelValues(orphanTypeHostedZone).Set(float64(len(orphans.hostedZones)))
+	orphanedResources.WithLabelValues(orphanTypeRoute53Record).Set(float64(records))
+}
+
+// deleteOrphans deletes the orphaned resources in dependency order: records, VPC Endpoints, security groups and
+// then hosted zones. Security groups and hosted zones that are still in use, e.g. by a VPC Endpoint that is still
+// being deleted, are left for a later run.
+func (g *GarbageCollector) deleteOrphans(ctx context.Context, infra client.Object, orphans *orphanInventory) error {
+	var errs []error
+
+	for id, rrsets := range orphans.records {
+		for _, rrs := range rrsets {
+			rr := rrs
+			if _, err := g.awsClient.DeleteResourceRecordSet(ctx, &rr, id); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete Route53 record %s in hosted zone %s: %w", aws.ToString(rrs.Name), id, err))
+				continue
+			}
+			g.deleted(infra, orphanTypeRoute53Record, "Route53 record %s (%s) in hosted zone %s", aws.ToString(rrs.Name), rrs.Type, id)
+		}
+	}
+
+	for _, vpce := range orphans.vpcEndpoints {
+		id := aws.ToString(vpce.VpcEndpointId)
+		if _, err := g.awsClient.DeleteVPCEndpoint(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete VPC Endpoint %s: %w", id, err))
+			continue
+		}
+		g.deleted(infra, orphanTypeVpcEndpoint, "VPC Endpoint %s", id)
+	}
+
+	for _, sg := range orphans.securityGroups {
+		id := aws.ToString(sg.GroupId)
+		if _, err := g.awsClient.DeleteSecurityGroup(ctx, id); err != nil {
+			var ae smithy.APIError
+			if errors.As(err, &ae) && ae.ErrorCode() == "DependencyViolation" {
+				g.log.V(1).Info("Orphaned security group is still in use, retrying later", "id", id)
+				continue
+			}
+			errs = append(errs, fmt.Errorf("failed to delete security group %s: %w", id, err))
+			continue
+		}
+		g.deleted(infra, orphanTypeSecurityGroup, "security group %s", id)
+	}
+
+	for _, hz := range orphans.hostedZones {
+		id := strings.TrimPrefix(aws.ToString(hz.Id), "/hostedzone/")
+		resp, err := g.awsClient.ListResourceRecordSets(ctx, id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list records in hosted zone %s: %w", id, err))
+			continue
+		}
+
+		// Hosted zones can only be deleted once they're empty, and records that weren't created for an orphaned
+		// VPC Endpoint aren't deleted
+		empty := true
+		for _, rrs := range resp.ResourceRecordSets {
+			if rrs.Type != route53Types.RRTypeSoa && rrs.Type != route53Types.RRTypeNs {
+				empty = false
+				break
+			}
+		}
+		if !empty {
+			g.log.V(0).Info("Skipping deletion of orphaned hosted zone that still has records", "id", id)
+			continue
+		}
+
+		if _, err := g.awsClient.DeleteHostedZone(ctx, id); err != nil {
+			var ae smithy.APIError
+			if errors.As(err, &ae) && ae.ErrorCode() == "HostedZoneNotEmpty" {
+				g.log.V(1).Info("Orphaned hosted zone is not empty, retrying later", "id", id)
+				continue
+			}
+			errs = append(errs, fmt.Errorf("failed to delete hosted zone %s: %w", id, err))
+			continue
+		}
+		g.deleted(infra, orphanTypeHostedZone, "hosted zone %s", id)
+	}
+
+	return errors.Join(errs...)
+}
+
+// deleted records the deletion of an orphaned resource in the logs, metrics and events
+func (g *GarbageCollector) deleted(infra client.Object, orphanType, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	g.log.V(0).Info("Deleted orphaned resource", "resource", msg)
+	g.Recorder.Eventf(infra, corev1.EventTypeNormal, "Deleted", "Deleted orphaned %s", msg)
+	orphanedResourcesDeleted.WithLabelValues(orphanType).Inc()
+}
+
+// newLiveResources collects the AWS resources that belong to the provided VpcEndpoint CRs. infraName is used to
+// generate the expected Name tags of VpcEndpoint CRs that haven't populated .status.infraId yet.
+func newLiveResources(vpces []avov1alpha2.VpcEndpoint, infraName string) *liveResources {
+	live := &liveResources{
+		vpcEndpointIds:     map[string]bool{},
+		securityGroupIds:   map[string]bool{},
+		hostedZoneIds:      map[string]bool{},
+		domainNames:        map[string]bool{},
+		vpcEndpointNames:   map[string]bool{},
+		securityGroupNames: map[string]bool{},
+		records:            map[string][]avov1alpha2.ResourceRecordStatus{},
+	}
+
+	for i := range vpces {
+		vpce := &vpces[i]
+
+		if vpce.Status.VPCEndpointId != "" {
+			live.vpcEndpointIds[vpce.Status.VPCEndpointId] = true
+		}
+		if vpce.Status.SecurityGroupId != "" {
+			live.securityGroupIds[vpce.Status.SecurityGroupId] = true
+		}
+		if vpce.Status.HostedZoneId != "" {
+			live.hostedZoneIds[vpce.Status.HostedZoneId] = true
+			live.records[vpce.Status.HostedZoneId] = append(live.records[vpce.Status.HostedZoneId], ownedRoute53Records(vpce)...)
+		}
+		if vpce.Spec.CustomDns.Route53PrivateHostedZone.DomainName != "" {
+			live.domainNames[normalizeDnsName(vpce.Spec.CustomDns.Route53PrivateHostedZone.DomainName)] = true
+		}
+
+		infraId := vpce.Status.InfraId
+		if infraId == "" {
+			infraId = infraName
+		}
+		if name, err := util.GenerateVPCEndpointName(infraId, vpce.Name); err == nil {
+			live.vpcEndpointNames[name] = live.vpcEndpointNames[name] || vpce.Status.VPCEndpointId == ""
+		}
+		if name, err := util.GenerateSecurityGroupName(infraId, vpce.Name); err == nil {
+			live.securityGroupNames[name] = true
+		}
+	}
+
+	return live
+}
+
+// ownsVpcEndpoint returns true if the VPC Endpoint belongs to a VpcEndpoint CR, either by its id or by its Name tag.
+// A VPC Endpoint matching a VpcEndpoint CR that already has a different VPC Endpoint is a duplicate, and only
+// considered orphaned once it's older than duplicateVpcEndpointGracePeriod.
+func (l *liveResources) ownsVpcEndpoint(vpce ec2Types.VpcEndpoint, now time.Time) bool {
+	if l.vpcEndpointIds[aws.ToString(vpce.VpcEndpointId)] {
+		return true
+	}
+
+	pending, ok := l.vpcEndpointNames[ec2TagValue(vpce.Tags, "Name")]
+	if !ok {
+		return false
+	}
+
+	return pending || vpce.CreationTimestamp == nil || now.Sub(*vpce.CreationTimestamp) < duplicateVpcEndpointGracePeriod
+}
+
+// ec2TagValue returns the value of the tag with the provided key, or an empty string if there is none
+func ec2TagValue(tags []ec2Types.Tag, key string) string {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == key {
+			return aws.ToString(tag.Value)
+		}
+	}
+
+	return ""
+}
+
+// route53RecordTarget returns the normalized DNS name a CNAME or alias record points to, or an empty string for
+// other records
+func route53RecordTarget(rrs route53Types.ResourceRecordSet) string {
+	switch {
+	case rrs.AliasTarget != nil:
+		return normalizeDnsName(aws.ToString(rrs.AliasTarget.DNSName))
+	case rrs.Type == route53Types.RRTypeCname && len(rrs.ResourceRecords) > 0:
+		return normalizeDnsName(aws.ToString(rrs.ResourceRecords[0].Value))
+	default:
+		return ""
+	}
+}
+
+// normalizeDnsName lowercases a DNS name and removes its trailing dot
+func normalizeDnsName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
@@ -0,0 +1,342 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+	"github.com/openshift/aws-vpce-operator/pkg/util"
+)
+
+func mockManagedEc2Tags(name string) []ec2Types.Tag {
+	return []ec2Types.Tag{
+		{Key: aws.String(util.OperatorTagKey), Value: aws.String(util.OperatorTagValue)},
+		{Key: aws.String(aws_client.MockLegacyClusterTag), Value: aws.String("owned")},
+		{Key: aws.String("Name"), Value: aws.String(name)},
+	}
+}
+
+func mockGarbageCollectorAws() (*aws_client.MockedEC2, *aws_client.MockedRoute53) {
+	managedZoneTags := []route53Types.Tag{
+		{Key: aws.String(util.OperatorTagKey), Value: aws.String(util.OperatorTagValue)},
+		{Key: aws.String(aws_client.MockLegacyClusterTag), Value: aws.String("owned")},
+	}
+	privateZone := &route53Types.HostedZoneConfig{PrivateZone: true}
+	cname := func(name, value string) route53Types.ResourceRecordSet {
+		return route53Types.ResourceRecordSet{
+			Name:            aws.String(name),
+			Type:            route53Types.RRTypeCname,
+			ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(value)}},
+		}
+	}
+
+	ec2Client := &aws_client.MockedEC2{
+		VpcEndpoints: []ec2Types.VpcEndpoint{
+			{VpcEndpointId: aws.String("vpce-live"), Tags: mockManagedEc2Tags("mock-12345-live-vpce")},
+			{VpcEndpointId: aws.String("vpce-pending"), Tags: mockManagedEc2Tags("mock-12345-pending-vpce")},
+			{
+				VpcEndpointId: aws.String("vpce-orphan"),
+				Tags:          mockManagedEc2Tags("mock-12345-deleted-vpce"),
+				DnsEntries:    []ec2Types.DnsEntry{{DnsName: aws.String("vpce-orphan.vpce-svc-12345.us-east-1.vpce.amazonaws.com")}},
+			},
+			{
+				VpcEndpointId:     aws.String("vpce-duplicate"),
+				Tags:              mockManagedEc2Tags("mock-12345-live-vpce"),
+				CreationTimestamp: aws.Time(time.Now().Add(-2 * duplicateVpcEndpointGracePeriod)),
+			},
+			{
+				VpcEndpointId:     aws.String("vpce-recreated"),
+				Tags:              mockManagedEc2Tags("mock-12345-live-vpce"),
+				CreationTimestamp: aws.Time(time.Now()),
+			},
+			{VpcEndpointId: aws.String("vpce-unmanaged"), Tags: []ec2Types.Tag{{Key: aws.String("Name"), Value: aws.String("unmanaged")}}},
+		},
+		SecurityGroups: []ec2Types.SecurityGroup{
+			{GroupId: aws.String("sg-live"), Tags: mockManagedEc2Tags("mock-12345-live-sg")},
+			{GroupId: aws.String("sg-pending"), Tags: mockManagedEc2Tags("mock-12345-pending-sg")},
+			{GroupId: aws.String("sg-orphan"), Tags: mockManagedEc2Tags("mock-12345-deleted-sg")},
+		},
+	}
+
+	route53Client := &aws_client.MockedRoute53{
+		HostedZones: []route53Types.HostedZone{
+			{Id: aws.String("/hostedzone/ZLIVE"), Name: aws.String("example.com."), Config: privateZone},
+			{Id: aws.String("/hostedzone/ZCLUSTER"), Name: aws.String(testutil.MockDomainName + "."), Config: privateZone},
+			{Id: aws.String("/hostedzone/ZORPHAN"), Name: aws.String("orphan.com."), Config: privateZone},
+			{Id: aws.String("/hostedzone/ZUNMANAGED"), Name: aws.String("unmanaged.com."), Config: privateZone},
+		},
+		HostedZoneTagsById: map[string][]route53Types.Tag{
+			"ZLIVE":    managedZoneTags,
+			"ZCLUSTER": managedZoneTags,
+			"ZORPHAN":  managedZoneTags,
+		},
+		ResourceRecordSets: map[string][]route53Types.ResourceRecordSet{
+			"ZLIVE": {
+				{Name: aws.String("example.com."), Type: route53Types.RRTypeSoa},
+				cname("live.example.com.", "vpce-orphan.vpce-svc-12345.us-east-1.vpce.amazonaws.com"),
+				cname("stale.example.com.", "vpce-orphan.vpce-svc-12345.us-east-1.vpce.amazonaws.com."),
+				cname("other.example.com.", "vpce-other.vpce-svc-12345.us-east-1.vpce.amazonaws.com"),
+			},
+			"ZCLUSTER": {
+				{Name: aws.String(testutil.MockDomainName + "."), Type: route53Types.RRTypeSoa},
+			},
+			"ZORPHAN": {
+				{Name: aws.String("orphan.com."), Type: route53Types.RRTypeSoa},
+				{Name: aws.String("orphan.com."), Type: route53Types.RRTypeNs},
+			},
+		},
+	}
+
+	return ec2Client, route53Client
+}
+
+func mockGarbageCollectorVpcEndpoints() []client.Object {
+	return []client.Object{
+		&avov1alpha2.VpcEndpoint{
+			ObjectMeta: metav1.ObjectMeta{Name: "live", Namespace: "default"},
+			Spec: avov1alpha2.VpcEndpointSpec{
+				CustomDns: avov1alpha2.CustomDns{
+					Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+						DomainName: "example.com",
+					},
+				},
+			},
+			Status: avov1alpha2.VpcEndpointStatus{
+				InfraId:         testutil.MockInfrastructureName,
+				VPCEndpointId:   "vpce-live",
+				SecurityGroupId: "sg-live",
+				HostedZoneId:    "ZLIVE",
+				ResourceRecords: []avov1alpha2.ResourceRecordStatus{
+					{Name: "live.example.com", Type: "CNAME"},
+				},
+			},
+		},
+		&avov1alpha2.VpcEndpoint{
+			ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"},
+		},
+	}
+}
+
+func TestGarbageCollector_collect(t *testing.T) {
+	tests := []struct {
+		name                   string
+		deleteOrphans          bool
+		pageSize               int
+		expectedVpcEndpointIds []string
+		expectedSgIds          []string
+		expectedHostedZoneIds  []string
+		expectedRecordChanges  int
+	}{
+		{
+			name:          "report only",
+			deleteOrphans: false,
+		},
+		{
+			name:                   "delete orphans",
+			deleteOrphans:          true,
+			expectedVpcEndpointIds: []string{"vpce-orphan", "vpce-duplicate"},
+			expectedSgIds:          []string{"sg-orphan"},
+			expectedHostedZoneIds:  []string{"ZORPHAN"},
+			expectedRecordChanges:  1,
+		},
+		{
+			name:                   "delete orphans past the first page of records",
+			deleteOrphans:          true,
+			pageSize:               1,
+			expectedVpcEndpointIds: []string{"vpce-orphan", "vpce-duplicate"},
+			expectedSgIds:          []string{"sg-orphan"},
+			expectedHostedZoneIds:  []string{"ZORPHAN"},
+			expectedRecordChanges:  1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock, err := testutil.NewDefaultMock()
+			assert.NoError(t, err)
+			for _, obj := range mockGarbageCollectorVpcEndpoints() {
+				assert.NoError(t, mock.Client.Create(context.TODO(), obj))
+			}
+
+			ec2Client, route53Client := mockGarbageCollectorAws()
+			route53Client.ResourceRecordSetsPageSize = test.pageSize
+			recorder := record.NewFakeRecorder(10)
+			g := &GarbageCollector{
+				Client:        mock.Client,
+				Recorder:      recorder,
+				DeleteOrphans: test.deleteOrphans,
+				log:           testr.New(t),
+				awsClient:     aws_client.NewAwsClientWithServiceClients(ec2Client, route53Client),
+			}
+
+			assert.NoError(t, g.collect(context.TODO(), false))
+
+			assert.Equal(t, test.expectedVpcEndpointIds, ec2Client.DeletedVpcEndpointIds)
+			assert.Equal(t, test.expectedSgIds, ec2Client.DeletedSecurityGroupIds)
+			assert.Equal(t, test.expectedHostedZoneIds, route53Client.DeletedHostedZoneIds)
+			assert.Equal(t, test.expectedRecordChanges, len(route53Client.ChangeResourceRecordSetsInputs))
+			// The tags of all hosted zones are fetched at once
+			assert.Equal(t, 1, len(route53Client.ListTagsForResourcesInputs))
+			if test.expectedRecordChanges > 0 {
+				assert.Equal(t, "stale.example.com.", *route53Client.ChangeResourceRecordSetsInputs[0].ChangeBatch.Changes[0].ResourceRecordSet.Name)
+			}
+
+			// One Warning event for each of the two orphaned VPC Endpoints, the orphaned security group, hosted zone
+			// and record, and a Normal event for each deletion
+			expectedEvents := 5
+			if test.deleteOrphans {
+				expectedEvents += 5
+			}
+			assert.Equal(t, expectedEvents, len(recorder.Events))
+		})
+	}
+}
+
+func TestGarbageCollector_collectKeepsOrphanedResources(t *testing.T) {
+	mock, err := testutil.NewDefaultMock()
+	assert.NoError(t, err)
+	for _, obj := range mockGarbageCollectorVpcEndpoints() {
+		assert.NoError(t, mock.Client.Create(context.TODO(), obj))
+	}
+
+	// Resources kept by a deleted VpcEndpoint CR with the Orphan deletion policy
+	orphanedTag := []ec2Types.Tag{{Key: aws.String(util.OrphanedTagKey), Value: aws.String("default/kept")}}
+	ec2Client, route53Client := mockGarbageCollectorAws()
+	ec2Client.VpcEndpoints = append(ec2Client.VpcEndpoints, ec2Types.VpcEndpoint{
+		VpcEndpointId: aws.String("vpce-kept"),
+		Tags:          append(mockManagedEc2Tags("mock-12345-kept-vpce"), orphanedTag...),
+		DnsEntries:    []ec2Types.DnsEntry{{DnsName: aws.String("vpce-kept.vpce-svc-12345.us-east-1.vpce.amazonaws.com")}},
+	})
+	ec2Client.SecurityGroups = append(ec2Client.SecurityGroups, ec2Types.SecurityGroup{
+		GroupId: aws.String("sg-kept"),
+		Tags:    append(mockManagedEc2Tags("mock-12345-kept-sg"), orphanedTag...),
+	})
+	route53Client.HostedZones = append(route53Client.HostedZones, route53Types.HostedZone{
+		Id:     aws.String("/hostedzone/ZKEPT"),
+		Name:   aws.String("kept.com."),
+		Config: &route53Types.HostedZoneConfig{PrivateZone: true},
+	})
+	route53Client.HostedZoneTagsById["ZKEPT"] = append(route53Client.HostedZoneTagsById["ZORPHAN"],
+		route53Types.Tag{Key: aws.String(util.OrphanedTagKey), Value: aws.String("default/kept")})
+	route53Client.ResourceRecordSets["ZKEPT"] = []route53Types.ResourceRecordSet{
+		{Name: aws.String("kept.com."), Type: route53Types.RRTypeSoa},
+		{
+			Name:            aws.String("api.kept.com."),
+			Type:            route53Types.RRTypeCname,
+			ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String("vpce-kept.vpce-svc-12345.us-east-1.vpce.amazonaws.com")}},
+		},
+	}
+
+	g := &GarbageCollector{
+		Client:        mock.Client,
+		Recorder:      record.NewFakeRecorder(20),
+		DeleteOrphans: true,
+		log:           testr.New(t),
+		awsClient:     aws_client.NewAwsClientWithServiceClients(ec2Client, route53Client),
+	}
+
+	assert.NoError(t, g.collect(context.TODO(), false))
+	assert.Equal(t, []string{"vpce-orphan", "vpce-duplicate"}, ec2Client.DeletedVpcEndpointIds)
+	assert.Equal(t, []string{"sg-orphan"}, ec2Client.DeletedSecurityGroupIds)
+	assert.Equal(t, []string{"ZORPHAN"}, route53Client.DeletedHostedZoneIds)
+	assert.Equal(t, 1, len(route53Client.ChangeResourceRecordSetsInputs))
+}
+
+func TestGarbageCollector_collectWithoutInfrastructure(t *testing.T) {
+	ec2Client, route53Client := mockGarbageCollectorAws()
+	g := &GarbageCollector{
+		Client:    testutil.NewTestMock(t).Client,
+		Recorder:  record.NewFakeRecorder(1),
+		log:       testr.New(t),
+		awsClient: aws_client.NewAwsClientWithServiceClients(ec2Client, route53Client),
+	}
+
+	assert.Error(t, g.collect(context.TODO(), false))
+}
+
+func TestLiveResources_ownsVpcEndpoint(t *testing.T) {
+	now := time.Now()
+	live := newLiveResources([]avov1alpha2.VpcEndpoint{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "created"},
+			Status:     avov1alpha2.VpcEndpointStatus{VPCEndpointId: "vpce-created"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "creating"},
+		},
+	}, testutil.MockInfrastructureName)
+
+	tests := []struct {
+		name     string
+		vpce     ec2Types.VpcEndpoint
+		expected bool
+	}{
+		{
+			name:     "by id",
+			vpce:     ec2Types.VpcEndpoint{VpcEndpointId: aws.String("vpce-created")},
+			expected: true,
+		},
+		{
+			name:     "by name without a status id",
+			vpce:     ec2Types.VpcEndpoint{VpcEndpointId: aws.String("vpce-new"), Tags: mockManagedEc2Tags("mock-12345-creating-vpce")},
+			expected: true,
+		},
+		{
+			name: "recent duplicate",
+			vpce: ec2Types.VpcEndpoint{
+				VpcEndpointId:     aws.String("vpce-new"),
+				Tags:              mockManagedEc2Tags("mock-12345-created-vpce"),
+				CreationTimestamp: aws.Time(now.Add(-time.Minute)),
+			},
+			expected: true,
+		},
+		{
+			name: "old duplicate",
+			vpce: ec2Types.VpcEndpoint{
+				VpcEndpointId:     aws.String("vpce-old"),
+				Tags:              mockManagedEc2Tags("mock-12345-created-vpce"),
+				CreationTimestamp: aws.Time(now.Add(-2 * duplicateVpcEndpointGracePeriod)),
+			},
+			expected: false,
+		},
+		{
+			name:     "unknown name",
+			vpce:     ec2Types.VpcEndpoint{VpcEndpointId: aws.String("vpce-unknown"), Tags: mockManagedEc2Tags("mock-12345-deleted-vpce")},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, live.ownsVpcEndpoint(test.vpce, now))
+		})
+	}
+}
@@ -20,8 +20,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"sort"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -30,6 +32,7 @@ import (
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/aws/smithy-go"
 
 	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
@@ -37,10 +40,12 @@ import (
 	"github.com/openshift/aws-vpce-operator/pkg/dnses"
 	"github.com/openshift/aws-vpce-operator/pkg/hostedcontrolplanes"
 	"github.com/openshift/aws-vpce-operator/pkg/infrastructures"
+	"github.com/openshift/aws-vpce-operator/pkg/networks"
 	"github.com/openshift/aws-vpce-operator/pkg/secrets"
 	"github.com/openshift/aws-vpce-operator/pkg/util"
 	hyperv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,12 +55,10 @@ import (
 // parseClusterInfo fills in the clusterInfo struct values inside the VpcEndpointReconciler
 // and gets a new AWS session if refreshAWSSession is true.
 // Generally, refreshAWSSession is only set to false during testing to mock the AWS client.
-func (r *VpcEndpointReconciler) parseClusterInfo(ctx context.Context, vpce *avov1alpha2.VpcEndpoint, refreshAWSSession bool) error {
+func (r *reconcileScope) parseClusterInfo(ctx context.Context, vpce *avov1alpha2.VpcEndpoint, refreshAWSSession bool) error {
 	r.clusterInfo = new(clusterInfo)
 
-	if vpce.Spec.CustomDns.Route53PrivateHostedZone.DomainNameRef != nil &&
-		vpce.Spec.CustomDns.Route53PrivateHostedZone.DomainNameRef.ValueFrom != nil &&
-		vpce.Spec.CustomDns.Route53PrivateHostedZone.DomainNameRef.ValueFrom.HostedControlPlaneRef != nil {
+	if usesHostedControlPlane(vpce) {
 		// For HyperShift, use the infra id from the hostedcontrolplane
 		infraName, err := hostedcontrolplanes.GetInfraId(ctx, r.Client, vpce.Namespace)
 		if err != nil {
@@ -107,22 +110,14 @@ func (r *VpcEndpointReconciler) parseClusterInfo(ctx context.Context, vpce *avov
 		r.log.V(1).Info("Parsed region from infrastructure", "region", region)
 	}
 
-	if vpce.Spec.AWSCredentialOverrideRef != nil {
-		// Use the provided override credentials for this specific vpcendpoint
-		cfg, err := secrets.ParseAWSCredentialOverride(ctx, r.APIReader, r.clusterInfo.region, vpce.Spec.AWSCredentialOverrideRef)
+	// Credential overrides are always loaded, otherwise the default AWS credentials available to the controller
+	// are only loaded when refreshing the AWS session
+	if vpce.Spec.AWSCredentialOverrideRef != nil || refreshAWSSession {
+		clients, err := r.loadAWSClients(ctx, vpce, r.clusterInfo.region)
 		if err != nil {
 			return err
 		}
-		r.awsClient = aws_client.NewAwsClient(cfg)
-	} else {
-		// Load the default AWS credentials that are available to the controller
-		if refreshAWSSession {
-			cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(r.clusterInfo.region))
-			if err != nil {
-				return err
-			}
-			r.awsClient = aws_client.NewAwsClient(cfg)
-		}
+		r.awsClient = r.newAwsClient(clients)
 	}
 
 	// If .status.vpcId is empty, we need to populate it
@@ -150,6 +145,13 @@ func (r *VpcEndpointReconciler) parseClusterInfo(ctx context.Context, vpce *avov
 			}
 			vpcId = v
 			r.log.V(1).Info("Selecting vpc id", "vpcId", vpcId)
+		case vpcEndpointType(vpce) == avov1alpha2.VpcEndpointTypeGateway && len(vpce.Spec.RouteTables.Ids) > 0:
+			v, err := r.awsClient.GetRouteTablesVpcId(ctx, vpce.Spec.RouteTables.Ids)
+			if err != nil {
+				return err
+			}
+			vpcId = v
+			r.log.V(1).Info("Found vpc id:", "vpcId", vpcId)
 		case vpce.Spec.Vpc.AutoDiscoverSubnets:
 			resp, err := r.awsClient.AutodiscoverPrivateSubnets(ctx, r.clusterInfo.clusterTag, vpce.Spec.Vpc.SubnetTags...)
 			if err != nil {
@@ -185,6 +187,136 @@ func (r *VpcEndpointReconciler) parseClusterInfo(ctx context.Context, vpce *avov
 	return nil
 }
 
+// usesHostedControlPlane returns true if the VpcEndpoint CR belongs to a HyperShift hosted cluster, identified by its
+// domain name being read from the namespace's HostedControlPlane
+func usesHostedControlPlane(vpce *avov1alpha2.VpcEndpoint) bool {
+	return vpce.Spec.CustomDns.Route53PrivateHostedZone.DomainNameRef != nil &&
+		vpce.Spec.CustomDns.Route53PrivateHostedZone.DomainNameRef.ValueFrom != nil &&
+		vpce.Spec.CustomDns.Route53PrivateHostedZone.DomainNameRef.ValueFrom.HostedControlPlaneRef != nil
+}
+
+// loadAWSClients returns the pooled AWS clients used to reconcile the provided VpcEndpoint in the given region.
+// The source credentials come from AWSCredentialOverrideRef if specified, otherwise the controller's default
+// credentials. If AssumeRoleArn is specified, the role is then assumed with the source credentials and the
+// outcome is reflected in the AWSAssumeRoleReady condition.
+func (r *reconcileScope) loadAWSClients(ctx context.Context, vpce *avov1alpha2.VpcEndpoint, region string) (*aws_client.Clients, error) {
+	key := aws_client.ClientPoolKey{
+		Region:           region,
+		CredentialSource: aws_client.DefaultCredentialSource,
+		RoleArn:          vpce.Spec.AssumeRoleArn,
+	}
+
+	var secret *corev1.Secret
+	if vpce.Spec.AWSCredentialOverrideRef != nil {
+		// The secret is read on every reconcile so that the pooled clients are replaced when it changes
+		s, err := secrets.GetAWSCredentialOverride(ctx, r.APIReader, vpce.Spec.AWSCredentialOverrideRef)
+		if err != nil {
+			return nil, err
+		}
+		secret = s
+		key.CredentialSource = secrets.CredentialSource(secret)
+		key.SecretResourceVersion = secret.ResourceVersion
+	}
+
+	assumeRoleOptions := secrets.AssumeRoleOptions{
+		ExternalId:  vpce.Spec.AssumeRoleExternalId,
+		SessionName: generateAssumeRoleSessionName(vpce),
+		SessionTags: map[string]string{
+			assumeRoleSessionTagNamespace: vpce.Namespace,
+			assumeRoleSessionTagName:      vpce.Name,
+		},
+	}
+	if vpce.Spec.AssumeRoleArn != "" {
+		key.AssumeRoleOptions = assumeRoleOptions.ClientPoolKeyOptions()
+	}
+
+	clients, err := r.AWSClientPool.Get(ctx, key, func(ctx context.Context) (aws.Config, error) {
+		var (
+			cfg aws.Config
+			err error
+		)
+
+		if secret != nil {
+			// Use the provided override credentials for this specific vpcendpoint
+			cfg, err = secrets.AWSConfigFromSecret(ctx, region, secret)
+		} else {
+			// Load the default AWS credentials that are available to the controller
+			cfg, err = config.LoadDefaultConfig(ctx, config.WithRegion(region))
+		}
+		if err != nil {
+			return aws.Config{}, err
+		}
+
+		if vpce.Spec.AssumeRoleArn != "" {
+			cfg.Credentials = secrets.NewAssumeRoleCredentials(sts.NewFromConfig(cfg), vpce.Spec.AssumeRoleArn, assumeRoleOptions)
+		}
+
+		return cfg, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if vpce.Spec.AssumeRoleArn == "" {
+		if meta.FindStatusCondition(vpce.Status.Conditions, avov1alpha2.AWSAssumeRoleCondition) != nil {
+			meta.RemoveStatusCondition(&vpce.Status.Conditions, avov1alpha2.AWSAssumeRoleCondition)
+			if err := r.Status().Update(ctx, vpce); err != nil {
+				return nil, fmt.Errorf("failed to update status: %w", err)
+			}
+		}
+		return clients, nil
+	}
+
+	// Credentials are lazily retrieved, so retrieve them now to surface failures to assume the role. Pooled
+	// credentials are cached until they expire, so this only calls sts:AssumeRole when needed.
+	if _, err := clients.Config().Credentials.Retrieve(ctx); err != nil {
+		r.log.V(0).Error(err, "failed to assume role", "roleArn", vpce.Spec.AssumeRoleArn)
+		r.AWSClientPool.Invalidate(key)
+		meta.SetStatusCondition(&vpce.Status.Conditions, metav1.Condition{
+			Type:               avov1alpha2.AWSAssumeRoleCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "AssumeRoleFailed",
+			Message:            err.Error(),
+			ObservedGeneration: vpce.Generation,
+		})
+		if statusErr := r.Status().Update(ctx, vpce); statusErr != nil {
+			r.log.V(0).Error(statusErr, "failed to update status")
+		}
+		r.Recorder.Eventf(vpce, corev1.EventTypeWarning, "AssumeRoleFailed", "Failed to assume role %s: %v", vpce.Spec.AssumeRoleArn, err)
+		return nil, fmt.Errorf("failed to assume role %s: %w", vpce.Spec.AssumeRoleArn, err)
+	}
+
+	if !meta.IsStatusConditionTrue(vpce.Status.Conditions, avov1alpha2.AWSAssumeRoleCondition) {
+		meta.SetStatusCondition(&vpce.Status.Conditions, metav1.Condition{
+			Type:               avov1alpha2.AWSAssumeRoleCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Validated",
+			Message:            fmt.Sprintf("Assumed role %s", vpce.Spec.AssumeRoleArn),
+			ObservedGeneration: vpce.Generation,
+		})
+		if err := r.Status().Update(ctx, vpce); err != nil {
+			return nil, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+
+	return clients, nil
+}
+
+// generateAssumeRoleSessionName returns the AssumeRoleSessionName if specified, otherwise a role session name
+// derived from the VpcEndpoint's namespace and name, truncated to the 64 character limit of sts:AssumeRole.
+func generateAssumeRoleSessionName(vpce *avov1alpha2.VpcEndpoint) string {
+	if vpce.Spec.AssumeRoleSessionName != "" {
+		return vpce.Spec.AssumeRoleSessionName
+	}
+
+	name := fmt.Sprintf("avo-%s-%s", vpce.Namespace, vpce.Name)
+	if len(name) > assumeRoleSessionNameMaxLength {
+		name = name[:assumeRoleSessionNameMaxLength]
+	}
+
+	return name
+}
+
 // awsUnauthorizedOperationMetricHandler determines if an error is an AWS UnauthorizedOperation or AccessDenied and
 // increments the aws_vpce_operator_unauthorized_operation_total metric accordingly
 func awsUnauthorizedOperationMetricHandler(err error) {
@@ -202,7 +334,7 @@ func awsUnauthorizedOperationMetricHandler(err error) {
 }
 
 // getVpcEndpointServiceName determines the VPC Endpoint Service name from an avov1alpha2 VpcEndpoint
-func (r *VpcEndpointReconciler) getVpcEndpointServiceName(ctx context.Context, vpce *avov1alpha2.VpcEndpoint) error {
+func (r *reconcileScope) getVpcEndpointServiceName(ctx context.Context, vpce *avov1alpha2.VpcEndpoint) error {
 	var vpceServiceName string
 	if vpce.Spec.ServiceName != "" {
 		vpceServiceName = vpce.Spec.ServiceName
@@ -240,10 +372,17 @@ func (r *VpcEndpointReconciler) getVpcEndpointServiceName(ctx context.Context, v
 // findOrCreateSecurityGroup queries AWS and returns the Security Group for the provided CR and updates its status.
 // It first tries to use the Security Group ID that may be in the resource's status and falls back on
 // searching for the VPC Endpoint by tags in case the status is lost. If it still cannot find a Security Group,
-// it gets created.
-func (r *VpcEndpointReconciler) findOrCreateSecurityGroup(ctx context.Context, resource *avov1alpha2.VpcEndpoint) (*ec2Types.SecurityGroup, error) {
+// it gets created. A security group in .spec.adopt is adopted first.
+func (r *reconcileScope) findOrCreateSecurityGroup(ctx context.Context, resource *avov1alpha2.VpcEndpoint) (*ec2Types.SecurityGroup, error) {
 	var sg *ec2Types.SecurityGroup
 
+	if resource.Spec.Adopt != nil && resource.Spec.Adopt.SecurityGroupId != "" &&
+		!isAdopted(resource, avov1alpha2.AdoptedResourceTypeSecurityGroup, resource.Spec.Adopt.SecurityGroupId) {
+		if err := r.adoptSecurityGroup(ctx, resource); err != nil {
+			return nil, err
+		}
+	}
+
 	r.log.V(1).Info("Searching for security group by ID", "id", resource.Status.SecurityGroupId)
 	resp, err := r.awsClient.FilterSecurityGroupById(ctx, resource.Status.SecurityGroupId)
 	if err != nil {
@@ -266,7 +405,11 @@ func (r *VpcEndpointReconciler) findOrCreateSecurityGroup(ctx context.Context, r
 
 		// If there are still no security groups found, it needs to be created
 		if resp == nil || len(resp.SecurityGroups) == 0 {
-			createResp, err := r.awsClient.CreateSecurityGroup(ctx, sgName, resource.Status.VPCId, r.clusterInfo.clusterTag)
+			if err := r.recordCreationTags(ctx, resource); err != nil {
+				return nil, err
+			}
+
+			createResp, err := r.awsClient.CreateSecurityGroup(ctx, sgName, resource.Status.VPCId, r.clusterInfo.clusterTag, r.userTags(resource))
 			if err != nil {
 				return nil, err
 			}
@@ -302,43 +445,222 @@ func (r *VpcEndpointReconciler) findOrCreateSecurityGroup(ctx context.Context, r
 	return sg, nil
 }
 
-// createMissingSecurityGroupTags ensures the expected AWS tags exist on a VpcEndpoint CR's Security Group.
-// It will not delete any extra tags and only create missing ones.
-func (r *VpcEndpointReconciler) createMissingSecurityGroupTags(ctx context.Context, sg *ec2Types.SecurityGroup, resource *avov1alpha2.VpcEndpoint) error {
+// managedSecurityGroupEnabled returns true if AVO creates and manages a security group for the VpcEndpoint CR
+func managedSecurityGroupEnabled(resource *avov1alpha2.VpcEndpoint) bool {
+	return resource.Spec.SecurityGroup.ManagedSecurityGroup != avov1alpha2.ManagedSecurityGroupDisabled
+}
+
+// findUserSecurityGroups returns the sorted ids of the user's security groups selected by the VpcEndpoint CR's
+// .spec.securityGroup.ids and .spec.securityGroup.tags, which must be in the VPC Endpoint's VPC
+func (r *reconcileScope) findUserSecurityGroups(ctx context.Context, resource *avov1alpha2.VpcEndpoint) ([]string, error) {
+	var ids []string
+
+	if len(resource.Spec.SecurityGroup.Ids) > 0 {
+		sgs, err := r.awsClient.DescribeSecurityGroupsById(ctx, resource.Spec.SecurityGroup.Ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find security groups %v: %w", resource.Spec.SecurityGroup.Ids, err)
+		}
+
+		for _, sg := range sgs {
+			if resource.Status.VPCId != "" && aws.ToString(sg.VpcId) != resource.Status.VPCId {
+				return nil, fmt.Errorf("security group %s is not in the VPC Endpoint's VPC %s", *sg.GroupId, resource.Status.VPCId)
+			}
+			ids = append(ids, *sg.GroupId)
+		}
+	}
+
+	if len(resource.Spec.SecurityGroup.Tags) > 0 {
+		sgs, err := r.awsClient.FilterSecurityGroupsByTags(ctx, resource.Status.VPCId, resource.Spec.SecurityGroup.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find security groups by tags: %w", err)
+		}
+		if len(sgs) == 0 {
+			return nil, fmt.Errorf("no security groups found when filtering by tags: %v", resource.Spec.SecurityGroup.Tags)
+		}
+
+		for _, sg := range sgs {
+			// The managed security group is never a user's security group, even if it has the selected tags
+			if *sg.GroupId == resource.Status.SecurityGroupId {
+				continue
+			}
+			ids = append(ids, *sg.GroupId)
+		}
+	}
+
+	slices.Sort(ids)
+	return slices.Compact(ids), nil
+}
+
+// deleteManagedSecurityGroup deletes the managed security group after it has been disabled and detached from the
+// VPC Endpoint
+func (r *reconcileScope) deleteManagedSecurityGroup(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+	sgId := resource.Status.SecurityGroupId
+	r.log.V(0).Info("Deleting disabled managed security group", "securityGroupId", sgId)
+	if _, err := r.awsClient.DeleteSecurityGroup(ctx, sgId); err != nil {
+		var ae smithy.APIError
+		if !errors.As(err, &ae) {
+			return fmt.Errorf("unexpected error while deleting security group: %w", err)
+		}
+
+		switch ae.ErrorCode() {
+		case "InvalidGroup.NotFound":
+			r.log.V(0).Info("Security group already deleted", "securityGroupId", sgId)
+		case "DependencyViolation":
+			// The VPC Endpoint's network interfaces take a bit of time to be updated after it is detached
+			return &requeueAfterError{
+				after:  30 * time.Second,
+				reason: fmt.Sprintf("Security group %s is still in use, retrying deletion", sgId),
+			}
+		default:
+			return err
+		}
+	} else {
+		r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Deleted", "Deleted security group: %s", sgId)
+	}
+
+	resource.Status.SecurityGroupId = ""
+	if err := r.Status().Update(ctx, resource); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// ensureSecurityGroupTags ensures the expected AWS tags, including user tags, exist on a VpcEndpoint CR's Security Group.
+// Extra tags are only removed if they are user tags that were previously applied by AVO.
+func (r *reconcileScope) ensureSecurityGroupTags(ctx context.Context, sg *ec2Types.SecurityGroup, resource *avov1alpha2.VpcEndpoint) error {
 	sgName, err := util.GenerateSecurityGroupName(resource.Status.InfraId, resource.Name)
 	if err != nil {
 		return fmt.Errorf("failed to generate security group name: %w", err)
 	}
 
-	defaultTagsMap, err := util.GenerateAwsTagsAsMap(sgName, r.clusterInfo.clusterTag)
+	expectedTags, err := util.GenerateAwsTagsAsMap(sgName, r.clusterInfo.clusterTag)
 	if err != nil {
 		return err
 	}
+	maps.Copy(expectedTags, r.userTags(resource))
 
-	// Fix tags if any are missing
-	if !tagsContains(sg.Tags, defaultTagsMap) {
-		r.log.V(1).Info("Adding missing security group tags")
-		defaultTags, err := util.GenerateAwsTags(sgName, r.clusterInfo.clusterTag)
-		if err != nil {
-			return fmt.Errorf("failed to generate expected tags: %w", err)
+	updated, err := r.ensureEc2Tags(ctx, resource, *sg.GroupId, sg.Tags, expectedTags)
+	if err != nil {
+		return err
+	}
+	if updated {
+		r.log.V(1).Info("Updated security group tags", "id", *sg.GroupId)
+		r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Updated", "Updated security group tags: %s", *sg.GroupId)
+	}
+
+	return nil
+}
+
+// userTags returns the user tags to apply to the AWS resources managed for a VpcEndpoint CR: the operator's default
+// tags overridden by the CR's spec.tags, without any of the tags reserved by AVO
+func (r *reconcileScope) userTags(resource *avov1alpha2.VpcEndpoint) map[string]string {
+	return util.MergeUserTags(r.DefaultTags, resource.Spec.Tags)
+}
+
+// recordCreationTags adds the user tags an AWS resource is about to be created with to the tags recorded in the
+// status, so that they can still be removed later if recording the created resource in the status fails. The
+// previously recorded tags are kept until validateTags records the tags applied to every AWS resource.
+func (r *reconcileScope) recordCreationTags(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+	tags := maps.Clone(resource.Status.Tags)
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	maps.Copy(tags, r.userTags(resource))
+	if maps.Equal(tags, resource.Status.Tags) {
+		return nil
+	}
+
+	resource.Status.Tags = tags
+	if err := r.Status().Update(ctx, resource); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// diffTags compares the actual tags of an AWS resource with the expected tags, returning the tags that need to be
+// created or updated and the keys of the previously applied user tags that are no longer expected and need to be
+// removed. Tags that were not applied by AVO are never removed, except for the tag marking a resource orphaned by a
+// previous VpcEndpoint CR with the same name, which is removed once the resource is picked up again.
+func diffTags(actual, expected, applied map[string]string) (map[string]string, []string) {
+	toUpdate := map[string]string{}
+	for k, v := range expected {
+		if actualValue, ok := actual[k]; !ok || actualValue != v {
+			toUpdate[k] = v
+		}
+	}
+
+	var toRemove []string
+	for k := range applied {
+		if _, ok := expected[k]; ok {
+			continue
 		}
+		if _, ok := actual[k]; ok {
+			toRemove = append(toRemove, k)
+		}
+	}
+	if _, ok := actual[util.OrphanedTagKey]; ok {
+		toRemove = append(toRemove, util.OrphanedTagKey)
+	}
+	sort.Strings(toRemove)
+
+	return toUpdate, toRemove
+}
+
+// ensureEc2Tags creates or updates the expected tags on the EC2 resource with the given id and removes the user tags
+// previously applied by AVO that are no longer expected, returning true if any tags were changed
+func (r *reconcileScope) ensureEc2Tags(ctx context.Context, resource *avov1alpha2.VpcEndpoint, id string, tags []ec2Types.Tag, expectedTags map[string]string) (bool, error) {
+	actualTags := map[string]string{}
+	for _, tag := range tags {
+		actualTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	toUpdate, toRemove := diffTags(actualTags, expectedTags, resource.Status.Tags)
+
+	if len(toUpdate) > 0 {
+		keys := make([]string, 0, len(toUpdate))
+		for k := range toUpdate {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		createTags := make([]ec2Types.Tag, len(keys))
+		for i, k := range keys {
+			createTags[i] = ec2Types.Tag{Key: aws.String(k), Value: aws.String(toUpdate[k])}
+		}
+
+		r.log.V(1).Info("Creating tags", "id", id, "keys", keys)
 		if _, err := r.awsClient.CreateTags(ctx, &ec2.CreateTagsInput{
-			Resources: []string{*sg.GroupId},
-			Tags:      defaultTags,
+			Resources: []string{id},
+			Tags:      createTags,
 		}); err != nil {
-			return fmt.Errorf("failed to create tags: %w", err)
+			return false, fmt.Errorf("failed to create tags: %w", err)
 		}
+	}
 
-		r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Updated", "Updated security group tags: %s", *sg.GroupId)
+	if len(toRemove) > 0 {
+		deleteTags := make([]ec2Types.Tag, len(toRemove))
+		for i, k := range toRemove {
+			deleteTags[i] = ec2Types.Tag{Key: aws.String(k)}
+		}
+
+		r.log.V(1).Info("Deleting tags", "id", id, "keys", toRemove)
+		if _, err := r.awsClient.DeleteTags(ctx, &ec2.DeleteTagsInput{
+			Resources: []string{id},
+			Tags:      deleteTags,
+		}); err != nil {
+			return false, fmt.Errorf("failed to delete tags: %w", err)
+		}
 	}
 
-	return nil
+	return len(toUpdate) > 0 || len(toRemove) > 0, nil
 }
 
 // generateMissingSecurityGroupRules ensures that the cluster's worker and master security groups are allowed ingresses
 // to the VPC Endpoint security group as well as and other configured rules from the CR.
 // It will not remove an extra security group rules and only create missing ones.
-func (r *VpcEndpointReconciler) generateMissingSecurityGroupRules(ctx context.Context, sg *ec2Types.SecurityGroup, resource *avov1alpha2.VpcEndpoint) (
+func (r *reconcileScope) generateMissingSecurityGroupRules(ctx context.Context, sg *ec2Types.SecurityGroup, resource *avov1alpha2.VpcEndpoint) (
 	*ec2.AuthorizeSecurityGroupIngressInput, *ec2.AuthorizeSecurityGroupEgressInput, error) {
 	if sg == nil || resource == nil {
 		return nil, nil, fmt.Errorf("security group and resource must not be nil")
@@ -349,199 +671,26 @@ func (r *VpcEndpointReconciler) generateMissingSecurityGroupRules(ctx context.Co
 		return nil, nil, err
 	}
 
-	sourceSgResp, err := r.awsClient.FilterClusterNodeSecurityGroupsByDefaultTags(ctx, resource.Status.InfraId)
+	sources, err := r.getSecurityGroupRuleSources(ctx, resource)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	sourceSgIds := make([]*string, len(sourceSgResp.SecurityGroups))
-	for i := range sourceSgResp.SecurityGroups {
-		sourceSgIds[i] = sourceSgResp.SecurityGroups[i].GroupId
-	}
-
-	if len(sourceSgIds) == 0 {
-		r.log.V(0).Info("Unable to find source security groups")
-	}
-
-	// When UseVpcCidr is true, look up the VPC CIDR block to use instead of source SG IDs
-	var vpcCidr string
-	if resource.Spec.SecurityGroup.UseVpcCidr {
-		if resource.Status.VPCId == "" {
-			return nil, nil, fmt.Errorf("cannot use VPC CIDR: VPC ID is not set in status")
-		}
-		cidr, err := r.awsClient.GetVpcCidrBlock(ctx, resource.Status.VPCId)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get VPC CIDR block: %w", err)
-		}
-		vpcCidr = cidr
-		r.log.V(1).Info("Using VPC CIDR for security group rules", "vpcCidr", vpcCidr)
-	}
-
 	// Ensure ingress/egress rules
 	var (
 		ingressRules []ec2Types.IpPermission
 		egressRules  []ec2Types.IpPermission
 	)
 
-	for i := range resource.Spec.SecurityGroup.IngressRules {
-		switch {
-		case resource.Spec.SecurityGroup.IngressRules[i].CidrIp != "":
-			create := true
-			for _, rule := range rulesResp.SecurityGroupRules {
-				if avoAndAwsSecurityGroupRuleCandidate(false, resource.Spec.SecurityGroup.IngressRules[i], rule) {
-					// If we find a rule with the correct protocol, fromPort, and toPort, check CidrIP
-					if rule.CidrIpv4 != nil && resource.Spec.SecurityGroup.IngressRules[i].CidrIp == *rule.CidrIpv4 {
-						create = false
-						break
-					}
-				}
-			}
-
-			if create {
-				ingressRules = append(ingressRules, ec2Types.IpPermission{
-					IpProtocol: aws.String(resource.Spec.SecurityGroup.IngressRules[i].Protocol),
-					FromPort:   aws.Int32(resource.Spec.SecurityGroup.IngressRules[i].FromPort),
-					ToPort:     aws.Int32(resource.Spec.SecurityGroup.IngressRules[i].ToPort),
-					IpRanges: []ec2Types.IpRange{
-						{
-							CidrIp: aws.String(resource.Spec.SecurityGroup.IngressRules[i].CidrIp),
-						},
-					},
-				})
-			}
-		case resource.Spec.SecurityGroup.UseVpcCidr && vpcCidr != "":
-			create := true
-			for _, rule := range rulesResp.SecurityGroupRules {
-				if avoAndAwsSecurityGroupRuleCandidate(false, resource.Spec.SecurityGroup.IngressRules[i], rule) {
-					if rule.CidrIpv4 != nil && vpcCidr == *rule.CidrIpv4 {
-						create = false
-						break
-					}
-				}
-			}
-
-			if create {
-				ingressRules = append(ingressRules, ec2Types.IpPermission{
-					IpProtocol: aws.String(resource.Spec.SecurityGroup.IngressRules[i].Protocol),
-					FromPort:   aws.Int32(resource.Spec.SecurityGroup.IngressRules[i].FromPort),
-					ToPort:     aws.Int32(resource.Spec.SecurityGroup.IngressRules[i].ToPort),
-					IpRanges: []ec2Types.IpRange{
-						{
-							CidrIp: aws.String(vpcCidr),
-						},
-					},
-				})
-			}
-		case len(sourceSgIds) > 0:
-			for _, sourceSgId := range sourceSgIds {
-				create := true
-				for _, rule := range rulesResp.SecurityGroupRules {
-					if avoAndAwsSecurityGroupRuleCandidate(false, resource.Spec.SecurityGroup.IngressRules[i], rule) {
-						if rule.ReferencedGroupInfo == nil {
-							continue
-						}
-						if *rule.ReferencedGroupInfo.GroupId == *sourceSgId {
-							// If we find a rule with the correct protocol, fromPort, and toPort, check the source security group
-							create = false
-							break
-						}
-					}
-				}
-
-				if create {
-					ingressRules = append(ingressRules, ec2Types.IpPermission{
-						IpProtocol: aws.String(resource.Spec.SecurityGroup.IngressRules[i].Protocol),
-						FromPort:   aws.Int32(resource.Spec.SecurityGroup.IngressRules[i].FromPort),
-						ToPort:     aws.Int32(resource.Spec.SecurityGroup.IngressRules[i].ToPort),
-						UserIdGroupPairs: []ec2Types.UserIdGroupPair{
-							{
-								GroupId: sourceSgId,
-							},
-						},
-					})
-				}
-			}
+	for _, avoRule := range resolveSecurityGroupRules(resource.Spec.SecurityGroup.IngressRules, resource.Spec.SecurityGroup.UseVpcCidr, sources) {
+		if !securityGroupRuleExists(false, avoRule, rulesResp.SecurityGroupRules) {
+			ingressRules = append(ingressRules, securityGroupRuleIpPermission(avoRule))
 		}
 	}
 
-	for i := range resource.Spec.SecurityGroup.EgressRules {
-		switch {
-		case resource.Spec.SecurityGroup.EgressRules[i].CidrIp != "":
-			create := true
-			for _, rule := range rulesResp.SecurityGroupRules {
-				if avoAndAwsSecurityGroupRuleCandidate(true, resource.Spec.SecurityGroup.EgressRules[i], rule) {
-					// If we find a rule with the correct protocol, fromPort, and toPort, check CidrIP
-					if rule.CidrIpv4 != nil && resource.Spec.SecurityGroup.EgressRules[i].CidrIp == *rule.CidrIpv4 {
-						create = false
-						break
-					}
-				}
-			}
-
-			if create {
-				egressRules = append(egressRules, ec2Types.IpPermission{
-					IpProtocol: aws.String(resource.Spec.SecurityGroup.EgressRules[i].Protocol),
-					FromPort:   aws.Int32(resource.Spec.SecurityGroup.EgressRules[i].FromPort),
-					ToPort:     aws.Int32(resource.Spec.SecurityGroup.EgressRules[i].ToPort),
-					IpRanges: []ec2Types.IpRange{
-						{
-							CidrIp: aws.String(resource.Spec.SecurityGroup.EgressRules[i].CidrIp),
-						},
-					},
-				})
-			}
-		case resource.Spec.SecurityGroup.UseVpcCidr && vpcCidr != "":
-			create := true
-			for _, rule := range rulesResp.SecurityGroupRules {
-				if avoAndAwsSecurityGroupRuleCandidate(true, resource.Spec.SecurityGroup.EgressRules[i], rule) {
-					if rule.CidrIpv4 != nil && vpcCidr == *rule.CidrIpv4 {
-						create = false
-						break
-					}
-				}
-			}
-
-			if create {
-				egressRules = append(egressRules, ec2Types.IpPermission{
-					IpProtocol: aws.String(resource.Spec.SecurityGroup.EgressRules[i].Protocol),
-					FromPort:   aws.Int32(resource.Spec.SecurityGroup.EgressRules[i].FromPort),
-					ToPort:     aws.Int32(resource.Spec.SecurityGroup.EgressRules[i].ToPort),
-					IpRanges: []ec2Types.IpRange{
-						{
-							CidrIp: aws.String(vpcCidr),
-						},
-					},
-				})
-			}
-		case len(sourceSgIds) > 0:
-			for _, sourceSgId := range sourceSgIds {
-				create := true
-				for _, rule := range rulesResp.SecurityGroupRules {
-					if avoAndAwsSecurityGroupRuleCandidate(true, resource.Spec.SecurityGroup.EgressRules[i], rule) {
-						if rule.ReferencedGroupInfo == nil {
-							continue
-						}
-						if *rule.ReferencedGroupInfo.GroupId == *sourceSgId {
-							// If we find a rule with the correct protocol, fromPort, and toPort, check the source security group
-							create = false
-							break
-						}
-					}
-				}
-
-				if create {
-					egressRules = append(egressRules, ec2Types.IpPermission{
-						IpProtocol: aws.String(resource.Spec.SecurityGroup.EgressRules[i].Protocol),
-						FromPort:   aws.Int32(resource.Spec.SecurityGroup.EgressRules[i].FromPort),
-						ToPort:     aws.Int32(resource.Spec.SecurityGroup.EgressRules[i].ToPort),
-						UserIdGroupPairs: []ec2Types.UserIdGroupPair{
-							{
-								GroupId: sourceSgId,
-							},
-						},
-					})
-				}
-			}
+	for _, avoRule := range resolveSecurityGroupRules(resource.Spec.SecurityGroup.EgressRules, resource.Spec.SecurityGroup.UseVpcCidr, sources) {
+		if !securityGroupRuleExists(true, avoRule, rulesResp.SecurityGroupRules) {
+			egressRules = append(egressRules, securityGroupRuleIpPermission(avoRule))
 		}
 	}
 
@@ -603,58 +752,423 @@ func (r *VpcEndpointReconciler) generateMissingSecurityGroupRules(ctx context.Co
 	return ingressInput, egressInput, nil
 }
 
-// avoAndAwsSecurityGroupRuleCandidate checks if an avov1alpha2 SecurityGroupRule and an EC2 SecurityGroupRule
-// are mostly similar. It does not perform checks on fields such as CidrIP and SourceSecurityGroup.
-func avoAndAwsSecurityGroupRuleCandidate(isEgress bool, avoRule avov1alpha2.SecurityGroupRule, awsRule ec2Types.SecurityGroupRule) bool {
-	if isEgress != *awsRule.IsEgress {
-		return false
+// securityGroupRuleSources are the sources resolved from the cluster and VPC that security group rules without an
+// explicit CidrIp, CidrIpv6, PrefixListId, or SourceSecurityGroupId allow
+type securityGroupRuleSources struct {
+	// sourceSgIds are the cluster's master and worker security group ids
+	sourceSgIds []*string
+	// vpcCidr is the VPC's CIDR block, only set when UseVpcCidr is true and the VPC Endpoint has IPv4 addresses
+	vpcCidr string
+	// vpcIpv6Cidrs are the VPC's IPv6 CIDR blocks, only set when UseVpcCidr is true and the VPC Endpoint has IPv6
+	// addresses
+	vpcIpv6Cidrs []string
+	// networkCidrs are the CIDRs of the cluster's networks referenced by rules' NetworkCidrs
+	networkCidrs map[avov1alpha2.NetworkCidrSource][]string
+}
+
+// getSecurityGroupRuleSources returns the cluster's source security group ids, the VPC CIDR blocks when UseVpcCidr is
+// set, and the CIDRs of the cluster's networks referenced by the CR's rules.
+func (r *reconcileScope) getSecurityGroupRuleSources(ctx context.Context, resource *avov1alpha2.VpcEndpoint) (*securityGroupRuleSources, error) {
+	sourceSgResp, err := r.awsClient.FilterClusterNodeSecurityGroupsByDefaultTags(ctx, resource.Status.InfraId)
+	if err != nil {
+		return nil, err
 	}
 
-	if *awsRule.IpProtocol != avoRule.Protocol ||
-		*awsRule.FromPort != avoRule.FromPort ||
-		*awsRule.ToPort != avoRule.ToPort {
-		return false
+	sources := &securityGroupRuleSources{
+		sourceSgIds:  make([]*string, len(sourceSgResp.SecurityGroups)),
+		networkCidrs: map[avov1alpha2.NetworkCidrSource][]string{},
+	}
+	for i := range sourceSgResp.SecurityGroups {
+		sources.sourceSgIds[i] = sourceSgResp.SecurityGroups[i].GroupId
 	}
 
-	return true
-}
+	if len(sources.sourceSgIds) == 0 {
+		r.log.V(0).Info("Unable to find source security groups")
+	}
 
-// findOrCreateVpcEndpoint queries AWS and returns the VPC Endpoint for the provided CR and updates its status.
-// It first tries to use the VPC Endpoint ID that may be in the resource's status and falls back on
-// searching for the VPC Endpoint by tags in case the status is lost. If it still cannot find a VPC Endpoint,
-// it gets created.
-func (r *VpcEndpointReconciler) findOrCreateVpcEndpoint(ctx context.Context, resource *avov1alpha2.VpcEndpoint) (*ec2Types.VpcEndpoint, error) {
-	var vpce *ec2Types.VpcEndpoint
+	// When UseVpcCidr is true, look up the VPC CIDR blocks of the VPC Endpoint's IP address type to use instead of
+	// source SG IDs
+	if resource.Spec.SecurityGroup.UseVpcCidr {
+		if resource.Status.VPCId == "" {
+			return nil, fmt.Errorf("cannot use VPC CIDR: VPC ID is not set in status")
+		}
 
-	r.log.V(1).Info("Searching for VPC Endpoint by ID", "id", resource.Status.VPCEndpointId)
-	resp, err := r.awsClient.DescribeSingleVPCEndpointById(ctx, resource.Status.VPCEndpointId)
-	if err != nil {
-		return nil, err
+		if ipAddressType(resource) != avov1alpha2.IpAddressTypeIpv6 {
+			cidr, err := r.awsClient.GetVpcCidrBlock(ctx, resource.Status.VPCId)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get VPC CIDR block: %w", err)
+			}
+			sources.vpcCidr = cidr
+		}
+
+		if ipAddressType(resource) != avov1alpha2.IpAddressTypeIpv4 {
+			cidrs, err := r.awsClient.GetVpcIpv6CidrBlocks(ctx, resource.Status.VPCId)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get VPC IPv6 CIDR blocks: %w", err)
+			}
+			if len(cidrs) == 0 {
+				return nil, fmt.Errorf("cannot use VPC CIDR: VPC %s has no IPv6 CIDR blocks", resource.Status.VPCId)
+			}
+			sources.vpcIpv6Cidrs = cidrs
+		}
+		r.log.V(1).Info("Using VPC CIDR for security group rules", "vpcCidr", sources.vpcCidr, "vpcIpv6Cidrs", sources.vpcIpv6Cidrs)
 	}
 
-	// If there's no VPC Endpoint returned by ID, look for one by tag
-	// first, generate the VPC Endpoint name to search tags or use it later to create it
-	if resp == nil || len(resp.VpcEndpoints) == 0 {
-		vpceName, err := util.GenerateVPCEndpointName(resource.Status.InfraId, resource.Name)
-		if err != nil {
-			return nil, err
+	for _, avoRule := range append(resource.Spec.SecurityGroup.IngressRules, resource.Spec.SecurityGroup.EgressRules...) {
+		if avoRule.NetworkCidrs == "" {
+			continue
+		}
+		if _, ok := sources.networkCidrs[avoRule.NetworkCidrs]; ok {
+			continue
 		}
 
-		r.log.V(1).Info("Searching for VPC Endpoint by tags")
-		resp, err = r.awsClient.FilterVPCEndpointByDefaultTags(ctx, r.clusterInfo.clusterTag, vpceName)
+		cidrs, err := r.getNetworkCidrs(ctx, resource, avoRule.NetworkCidrs)
 		if err != nil {
+			return nil, fmt.Errorf("failed to get %s CIDRs: %w", avoRule.NetworkCidrs, err)
+		}
+		// Rules would silently be dropped without any CIDRs, and with them the revocation of the rules no longer
+		// expected, see generateExtraSecurityGroupRules
+		if len(cidrs) == 0 {
+			err := fmt.Errorf("no %s CIDRs found for security group rules", avoRule.NetworkCidrs)
+			meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+				Type:               avov1alpha2.AWSSecurityGroupCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             networkCidrsNotFoundReason,
+				Message:            err.Error(),
+				ObservedGeneration: resource.Generation,
+			})
 			return nil, err
 		}
+		sources.networkCidrs[avoRule.NetworkCidrs] = cidrs
+		r.log.V(1).Info("Using network CIDRs for security group rules", "network", avoRule.NetworkCidrs, "cidrs", cidrs)
+	}
 
-		// If there are still no VPC Endpoints found, it needs to be created
+	return sources, nil
+}
+
+// getNetworkCidrs returns the CIDRs of one of the cluster's networks, from the HostedControlPlane for HyperShift and
+// from the cluster's network configuration otherwise
+func (r *reconcileScope) getNetworkCidrs(ctx context.Context, resource *avov1alpha2.VpcEndpoint, network avov1alpha2.NetworkCidrSource) ([]string, error) {
+	switch network {
+	case avov1alpha2.NetworkCidrSourceClusterNetwork:
+		if usesHostedControlPlane(resource) {
+			return hostedcontrolplanes.GetClusterNetworkCidrs(ctx, r.Client, resource.Namespace)
+		}
+		return networks.GetClusterNetworkCidrs(ctx, r.Client)
+	case avov1alpha2.NetworkCidrSourceMachineNetwork:
+		if usesHostedControlPlane(resource) {
+			return hostedcontrolplanes.GetMachineNetworkCidrs(ctx, r.Client, resource.Namespace)
+		}
+		return networks.GetMachineNetworkCidrs(ctx, r.APIReader)
+	default:
+		return nil, fmt.Errorf("unsupported network: %s", network)
+	}
+}
+
+// resolveSecurityGroupRules expands the CR's security group rules into rules that each have exactly one explicit
+// source, so that they map one-to-one onto EC2 security group rules. Rules without an explicit source allow the
+// VPC's CIDR blocks when useVpcCidr is set and the cluster's source security groups otherwise. Rules whose sources
+// can't be resolved are dropped.
+func resolveSecurityGroupRules(avoRules []avov1alpha2.SecurityGroupRule, useVpcCidr bool, sources *securityGroupRuleSources) []avov1alpha2.SecurityGroupRule {
+	var resolved []avov1alpha2.SecurityGroupRule
+	for _, avoRule := range avoRules {
+		switch {
+		case avoRule.CidrIp != "", avoRule.CidrIpv6 != "", avoRule.PrefixListId != "", avoRule.SourceSecurityGroupId != "":
+			resolved = append(resolved, avoRule)
+		case avoRule.NetworkCidrs != "":
+			for _, cidr := range sources.networkCidrs[avoRule.NetworkCidrs] {
+				rule := avov1alpha2.SecurityGroupRule{
+					FromPort: avoRule.FromPort,
+					ToPort:   avoRule.ToPort,
+					Protocol: avoRule.Protocol,
+				}
+				if strings.Contains(cidr, ":") {
+					rule.CidrIpv6 = cidr
+				} else {
+					rule.CidrIp = cidr
+				}
+				resolved = append(resolved, rule)
+			}
+		case useVpcCidr && (sources.vpcCidr != "" || len(sources.vpcIpv6Cidrs) > 0):
+			if sources.vpcCidr != "" {
+				rule := avoRule
+				rule.CidrIp = sources.vpcCidr
+				resolved = append(resolved, rule)
+			}
+			for _, cidr := range sources.vpcIpv6Cidrs {
+				rule := avoRule
+				rule.CidrIpv6 = cidr
+				resolved = append(resolved, rule)
+			}
+		default:
+			for _, sourceSgId := range sources.sourceSgIds {
+				avoRule.SourceSecurityGroupId = *sourceSgId
+				resolved = append(resolved, avoRule)
+			}
+		}
+	}
+
+	return resolved
+}
+
+// securityGroupRuleIpPermission returns the EC2 IpPermission for a security group rule with an explicit source
+func securityGroupRuleIpPermission(avoRule avov1alpha2.SecurityGroupRule) ec2Types.IpPermission {
+	permission := ec2Types.IpPermission{
+		IpProtocol: aws.String(avoRule.Protocol),
+		FromPort:   aws.Int32(avoRule.FromPort),
+		ToPort:     aws.Int32(avoRule.ToPort),
+	}
+
+	switch {
+	case avoRule.CidrIp != "":
+		permission.IpRanges = []ec2Types.IpRange{{CidrIp: aws.String(avoRule.CidrIp)}}
+	case avoRule.CidrIpv6 != "":
+		permission.Ipv6Ranges = []ec2Types.Ipv6Range{{CidrIpv6: aws.String(avoRule.CidrIpv6)}}
+	case avoRule.PrefixListId != "":
+		permission.PrefixListIds = []ec2Types.PrefixListId{{PrefixListId: aws.String(avoRule.PrefixListId)}}
+	case avoRule.SourceSecurityGroupId != "":
+		userId, groupId := parseSourceSecurityGroupId(avoRule.SourceSecurityGroupId)
+		pair := ec2Types.UserIdGroupPair{GroupId: aws.String(groupId)}
+		if userId != "" {
+			pair.UserId = aws.String(userId)
+		}
+		permission.UserIdGroupPairs = []ec2Types.UserIdGroupPair{pair}
+	}
+
+	return permission
+}
+
+// parseSourceSecurityGroupId splits a security group reference of the form [<account id>/]<security group id>
+func parseSourceSecurityGroupId(ref string) (string, string) {
+	if userId, groupId, found := strings.Cut(ref, "/"); found {
+		return userId, groupId
+	}
+
+	return "", ref
+}
+
+// generateExtraSecurityGroupRules returns the ids of ingress and egress rules on the VPC Endpoint security group
+// that are no longer described by the CR. Only rules created by this operator are returned, unless
+// StrictRuleManagement is enabled, in which case every rule not described by the CR is returned.
+func (r *reconcileScope) generateExtraSecurityGroupRules(ctx context.Context, sg *ec2Types.SecurityGroup, resource *avov1alpha2.VpcEndpoint) (
+	[]string, []string, error) {
+	if sg == nil || resource == nil {
+		return nil, nil, fmt.Errorf("security group and resource must not be nil")
+	}
+
+	rulesResp, err := r.awsClient.DescribeSecurityGroupRules(ctx, *sg.GroupId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sources, err := r.getSecurityGroupRuleSources(ctx, resource)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If a rule depends on sources that can't be found, e.g. the cluster's source security groups, we cannot tell
+	// which existing rules are still expected, so don't revoke anything.
+	for _, avoRule := range append(resource.Spec.SecurityGroup.IngressRules, resource.Spec.SecurityGroup.EgressRules...) {
+		if len(resolveSecurityGroupRules([]avov1alpha2.SecurityGroupRule{avoRule}, resource.Spec.SecurityGroup.UseVpcCidr, sources)) == 0 {
+			r.log.V(0).Info("Skipping security group rule revocation, unable to resolve rule sources")
+			return nil, nil, nil
+		}
+	}
+
+	ingressRules := resolveSecurityGroupRules(resource.Spec.SecurityGroup.IngressRules, resource.Spec.SecurityGroup.UseVpcCidr, sources)
+	egressRules := resolveSecurityGroupRules(resource.Spec.SecurityGroup.EgressRules, resource.Spec.SecurityGroup.UseVpcCidr, sources)
+
+	var ingressRuleIds, egressRuleIds []string
+	for _, rule := range rulesResp.SecurityGroupRules {
+		if rule.SecurityGroupRuleId == nil {
+			continue
+		}
+
+		if !resource.Spec.SecurityGroup.StrictRuleManagement &&
+			!tagsContains(rule.Tags, map[string]string{util.OperatorTagKey: util.OperatorTagValue}) {
+			continue
+		}
+
+		isEgress := aws.ToBool(rule.IsEgress)
+		avoRules := ingressRules
+		if isEgress {
+			avoRules = egressRules
+		}
+
+		if securityGroupRuleExpected(isEgress, avoRules, rule) {
+			continue
+		}
+
+		if isEgress {
+			egressRuleIds = append(egressRuleIds, *rule.SecurityGroupRuleId)
+		} else {
+			ingressRuleIds = append(ingressRuleIds, *rule.SecurityGroupRuleId)
+		}
+	}
+
+	if len(ingressRuleIds) > 0 {
+		r.log.V(1).Info("Need to revoke ingress rules", "ingressRuleIds", ingressRuleIds)
+	}
+	if len(egressRuleIds) > 0 {
+		r.log.V(1).Info("Need to revoke egress rules", "egressRuleIds", egressRuleIds)
+	}
+
+	return ingressRuleIds, egressRuleIds, nil
+}
+
+// securityGroupRuleExpected returns true if the EC2 SecurityGroupRule is described by one of the provided
+// avov1alpha2 SecurityGroupRules, which must already be resolved by resolveSecurityGroupRules.
+func securityGroupRuleExpected(isEgress bool, avoRules []avov1alpha2.SecurityGroupRule, awsRule ec2Types.SecurityGroupRule) bool {
+	for _, avoRule := range avoRules {
+		if avoAndAwsSecurityGroupRuleCandidate(isEgress, avoRule, awsRule) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// securityGroupRuleExists returns true if one of the EC2 SecurityGroupRules is described by the provided
+// avov1alpha2 SecurityGroupRule, which must already be resolved by resolveSecurityGroupRules.
+func securityGroupRuleExists(isEgress bool, avoRule avov1alpha2.SecurityGroupRule, awsRules []ec2Types.SecurityGroupRule) bool {
+	for _, awsRule := range awsRules {
+		if avoAndAwsSecurityGroupRuleCandidate(isEgress, avoRule, awsRule) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// avoAndAwsSecurityGroupRuleCandidate checks if an avov1alpha2 SecurityGroupRule and an EC2 SecurityGroupRule
+// match. The direction, protocol, and port range must always match, and the source must match when the avov1alpha2
+// SecurityGroupRule has an explicit CidrIp, CidrIpv6, PrefixListId, or SourceSecurityGroupId.
+func avoAndAwsSecurityGroupRuleCandidate(isEgress bool, avoRule avov1alpha2.SecurityGroupRule, awsRule ec2Types.SecurityGroupRule) bool {
+	if isEgress != *awsRule.IsEgress {
+		return false
+	}
+
+	if *awsRule.IpProtocol != avoRule.Protocol ||
+		*awsRule.FromPort != avoRule.FromPort ||
+		*awsRule.ToPort != avoRule.ToPort {
+		return false
+	}
+
+	switch {
+	case avoRule.CidrIp != "":
+		return aws.ToString(awsRule.CidrIpv4) == avoRule.CidrIp
+	case avoRule.CidrIpv6 != "":
+		return aws.ToString(awsRule.CidrIpv6) == avoRule.CidrIpv6
+	case avoRule.PrefixListId != "":
+		return aws.ToString(awsRule.PrefixListId) == avoRule.PrefixListId
+	case avoRule.SourceSecurityGroupId != "":
+		if awsRule.ReferencedGroupInfo == nil {
+			return false
+		}
+		userId, groupId := parseSourceSecurityGroupId(avoRule.SourceSecurityGroupId)
+		if aws.ToString(awsRule.ReferencedGroupInfo.GroupId) != groupId {
+			return false
+		}
+		// Security groups in the same account are reported with the account's id, so only compare it when specified
+		return userId == "" || aws.ToString(awsRule.ReferencedGroupInfo.UserId) == userId
+	}
+
+	return true
+}
+
+// findOrCreateVpcEndpoint queries AWS and returns the VPC Endpoint for the provided CR and updates its status.
+// It first tries to use the VPC Endpoint ID that may be in the resource's status and falls back on
+// searching for the VPC Endpoint by tags in case the status is lost. If it still cannot find a VPC Endpoint,
+// it gets created. A VPC Endpoint in .spec.adopt is adopted first.
+func (r *reconcileScope) findOrCreateVpcEndpoint(ctx context.Context, resource *avov1alpha2.VpcEndpoint) (*ec2Types.VpcEndpoint, error) {
+	var vpce *ec2Types.VpcEndpoint
+
+	if resource.Spec.Adopt != nil && resource.Spec.Adopt.VpcEndpointId != "" &&
+		!isAdopted(resource, avov1alpha2.AdoptedResourceTypeVpcEndpoint, resource.Spec.Adopt.VpcEndpointId) {
+		if err := r.adoptVpcEndpoint(ctx, resource); err != nil {
+			return nil, err
+		}
+	}
+
+	r.log.V(1).Info("Searching for VPC Endpoint by ID", "id", resource.Status.VPCEndpointId)
+	resp, err := r.awsClient.DescribeSingleVPCEndpointById(ctx, resource.Status.VPCEndpointId)
+	if err != nil {
+		return nil, err
+	}
+
+	// If there's no VPC Endpoint returned by ID, look for one by tag
+	// first, generate the VPC Endpoint name to search tags or use it later to create it
+	if resp == nil || len(resp.VpcEndpoints) == 0 {
+		vpceName, err := util.GenerateVPCEndpointName(resource.Status.InfraId, resource.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		r.log.V(1).Info("Searching for VPC Endpoint by tags")
+		resp, err = r.awsClient.FilterVPCEndpointByDefaultTags(ctx, r.clusterInfo.clusterTag, vpceName)
+		if err != nil {
+			return nil, err
+		}
+
+		// If there are still no VPC Endpoints found, it needs to be created
 		if resp == nil || len(resp.VpcEndpoints) == 0 {
+			// A rejected VPC Endpoint is only recreated as allowed by its rejection policy
+			recreating := false
+			if cond := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSVpcEndpointCondition); cond != nil && cond.Reason == vpcEndpointRejectedReason {
+				if err := r.applyRejectionPolicy(ctx, resource); err != nil {
+					return nil, err
+				}
+				recreating = true
+			}
 
-			creationResp, err := r.awsClient.CreateDefaultInterfaceVPCEndpoint(ctx, vpceName, resource.Status.VPCId, resource.Status.VPCEndpointServiceName, r.clusterInfo.clusterTag)
+			// The client token ensures that if a previous attempt created the VPC Endpoint, but failed to record it
+			// in the status, AWS returns the existing VPC Endpoint instead of creating a duplicate.
+			// The previous VPC Endpoint ID, if any, recreation attempts and the time of the last rejection are
+			// included so that a VPC Endpoint that no longer exists, e.g. after each rejection, can be recreated.
+			lastRejection := ""
+			if resource.Status.LastRejectionTime != nil {
+				lastRejection = fmt.Sprint(resource.Status.LastRejectionTime.Unix())
+			}
+			clientToken := util.GenerateClientToken(string(resource.UID), resource.Generation, "vpce", resource.Status.VPCEndpointId, fmt.Sprint(resource.Status.RecreateAttempts), lastRejection)
+			// Without the optional policy document, the VPC Endpoint is created with the default policy, unless it
+			// replaces one that had a policy applied, e.g. after a rejection
+			policyDocument, err := r.getVpcEndpointPolicyDocument(ctx, resource)
+			if errors.Is(err, errPolicyDocumentNotFound) && resource.Status.PolicyHash == "" {
+				err = nil
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if err := r.recordCreationTags(ctx, resource); err != nil {
+				return nil, err
+			}
+
+			var creationResp *ec2.CreateVpcEndpointOutput
+			switch vpcEndpointType(resource) {
+			case avov1alpha2.VpcEndpointTypeGateway:
+				creationResp, err = r.awsClient.CreateDefaultGatewayVPCEndpoint(ctx, vpceName, resource.Status.VPCId, resource.Status.VPCEndpointServiceName, r.clusterInfo.clusterTag, r.userTags(resource), clientToken, policyDocument)
+			case avov1alpha2.VpcEndpointTypeGatewayLoadBalancer:
+				if len(resource.Spec.Vpc.SubnetIds) != 1 {
+					return nil, fmt.Errorf("GatewayLoadBalancer VPC Endpoints require exactly one subnet, got: %v", resource.Spec.Vpc.SubnetIds)
+				}
+				creationResp, err = r.awsClient.CreateDefaultGatewayLoadBalancerVPCEndpoint(ctx, vpceName, resource.Status.VPCId, resource.Status.VPCEndpointServiceName, resource.Spec.Vpc.SubnetIds[0], r.clusterInfo.clusterTag, r.userTags(resource), clientToken)
+			default:
+				creationResp, err = r.awsClient.CreateDefaultInterfaceVPCEndpoint(ctx, vpceName, resource.Status.VPCId, resource.Status.VPCEndpointServiceName, r.clusterInfo.clusterTag, r.userTags(resource), clientToken, policyDocument,
+					ec2Types.IpAddressType(ipAddressType(resource)), ec2Types.DnsRecordIpType(dnsRecordIpType(resource)))
+			}
 			if err != nil {
 				return nil, fmt.Errorf("failed to create vpc endpoint: %w", err)
 			}
 
 			vpce = creationResp.VpcEndpoint
+			if recreating {
+				// Only count attempts that created a VPC Endpoint, not ones that failed, e.g. when throttled.
+				// The attempt is recorded in the status along with the new VPC Endpoint ID below.
+				resource.Status.RecreateAttempts++
+				r.log.V(0).Info("Recreated rejected VPC endpoint", "attempt", resource.Status.RecreateAttempts)
+				r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Recreating", "Recreated rejected VPC endpoint, attempt %d", resource.Status.RecreateAttempts)
+			}
 			r.log.V(0).Info("Created VPC endpoint:", "vpcEndpoint", *vpce.VpcEndpointId)
 			r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Created", "Created VPC endpoint: %s", *vpce.VpcEndpointId)
 		} else {
@@ -679,29 +1193,60 @@ func (r *VpcEndpointReconciler) findOrCreateVpcEndpoint(ctx context.Context, res
 }
 
 // ensureVpcEndpointSubnets ensures that the subnets attached to the VPC Endpoint are the expected subnet ids
-func (r *VpcEndpointReconciler) ensureVpcEndpointSubnets(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
-	var (
-		subnetsToAdd, subnetsToRemove []string
-	)
+func (r *reconcileScope) ensureVpcEndpointSubnets(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+	expectedSubnetIds, err := r.expectedVpcEndpointSubnetIds(ctx, resource)
+	if err != nil {
+		return err
+	}
+	subnetsToAdd, subnetsToRemove := util.StringSliceTwoWayDiff(vpce.SubnetIds, expectedSubnetIds)
+
+	// Removing subnets first before adding to avoid
+	// DuplicateSubnetsInSameZone: Found another VPC endpoint subnet in the availability zone of <existing subnet>
+	if len(subnetsToRemove) > 0 {
+		r.log.V(1).Info("Removing subnet(s) from VPC Endpoint", "subnetsToRemove", subnetsToRemove)
+		if _, err := r.awsClient.ModifyVpcEndpoint(ctx, &ec2.ModifyVpcEndpointInput{
+			RemoveSubnetIds: subnetsToRemove,
+			VpcEndpointId:   vpce.VpcEndpointId,
+		}); err != nil {
+			return fmt.Errorf("failed to remove subnets: %v with error: %w", subnetsToRemove, err)
+		}
+	}
+
+	if len(subnetsToAdd) > 0 {
+		r.log.V(1).Info("Adding subnet(s) to VPC Endpoint", "subnetsToAdd", subnetsToAdd)
+		if _, err := r.awsClient.ModifyVpcEndpoint(ctx, &ec2.ModifyVpcEndpointInput{
+			AddSubnetIds:  subnetsToAdd,
+			VpcEndpointId: vpce.VpcEndpointId,
+		}); err != nil {
+			return fmt.Errorf("failed to add subnets: %v with error: %w", subnetsToAdd, err)
+		}
+	}
+
+	return nil
+}
 
+// expectedVpcEndpointSubnetIds returns the ids of the subnets a VpcEndpoint CR's VPC Endpoint should be attached to:
+// the auto-discovered private subnets in the Availability Zones supported by the VPC Endpoint Service, or exactly
+// the subnets in .spec.vpc.subnetIds
+func (r *reconcileScope) expectedVpcEndpointSubnetIds(ctx context.Context, resource *avov1alpha2.VpcEndpoint) ([]string, error) {
 	if resource.Spec.Vpc.AutoDiscoverSubnets {
 		var discoveredSubnets []ec2Types.Subnet
 		if len(resource.Spec.Vpc.Ids) > 0 || len(resource.Spec.Vpc.Tags) > 0 {
 			// Do not expect private subnets to have the cluster id when load balancing vpc ids
 			privateSubnets, err := r.awsClient.AutodiscoverPrivateSubnets(ctx, "", resource.Spec.Vpc.SubnetTags...)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			r.log.V(1).Info("Discovered private subnet(s):", "subnets", privateSubnets)
 			discoveredSubnets = privateSubnets
 		} else {
 			if r.clusterInfo == nil || r.clusterInfo.clusterTag == "" {
-				return fmt.Errorf("unable to parse cluster tag: %v", r.clusterInfo)
+				return nil, fmt.Errorf("unable to parse cluster tag: %v", r.clusterInfo)
 			}
 
 			privateSubnets, err := r.awsClient.AutodiscoverPrivateSubnets(ctx, r.clusterInfo.clusterTag, resource.Spec.Vpc.SubnetTags...)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			r.log.V(1).Info("Discovered private subnet(s):", "subnets", privateSubnets)
 			discoveredSubnets = privateSubnets
@@ -711,11 +1256,16 @@ func (r *VpcEndpointReconciler) ensureVpcEndpointSubnets(ctx context.Context, vp
 		// Service should be attached
 		allowedAZs, err := r.awsClient.GetVpcEndpointServiceAZs(ctx, resource.Status.VPCEndpointServiceName)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		var expectedSubnetIds []string
 		for _, subnet := range discoveredSubnets {
+			if !subnetSupportsIpAddressType(subnet, ipAddressType(resource)) {
+				r.log.V(1).Info("Skipping subnet not supporting the VPC Endpoint's IP address type", "subnet", aws.ToString(subnet.SubnetId), "ipAddressType", ipAddressType(resource))
+				continue
+			}
+
 			for _, az := range allowedAZs {
 				if *subnet.AvailabilityZone == az {
 					if resource.Status.VPCId != "" {
@@ -732,41 +1282,431 @@ func (r *VpcEndpointReconciler) ensureVpcEndpointSubnets(ctx context.Context, vp
 			}
 		}
 
-		r.log.V(1).Info("Private subnet(s) in availability zones supported by the VPC Endpoint Service:", "subnets", expectedSubnetIds, "serviceName", resource.Status.VPCEndpointServiceName)
-		subnetsToAdd, subnetsToRemove = util.StringSliceTwoWayDiff(vpce.SubnetIds, expectedSubnetIds)
-	} else {
-		// When subnet ids are specified, use exactly those subnets
-		subnetsToAdd, subnetsToRemove = util.StringSliceTwoWayDiff(vpce.SubnetIds, resource.Spec.Vpc.SubnetIds)
+		r.log.V(1).Info("Private subnet(s) in availability zones supported by the VPC Endpoint Service:", "subnets", expectedSubnetIds, "serviceName", resource.Status.VPCEndpointServiceName)
+		return expectedSubnetIds, nil
+	}
+
+	// When subnet ids are specified, use exactly those subnets
+	return resource.Spec.Vpc.SubnetIds, nil
+}
+
+// vpcEndpointType returns the type of a VpcEndpoint CR, defaulting to Interface
+func vpcEndpointType(resource *avov1alpha2.VpcEndpoint) avov1alpha2.VpcEndpointType {
+	if resource.Spec.Type == "" {
+		return avov1alpha2.VpcEndpointTypeInterface
+	}
+
+	return resource.Spec.Type
+}
+
+// ensureVpcEndpointRouteTables ensures that the route tables associated with a Gateway VPC Endpoint are the expected
+// route tables and records them in the resource's status
+func (r *reconcileScope) ensureVpcEndpointRouteTables(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+	expectedRouteTableIds := resource.Spec.RouteTables.Ids
+	if len(expectedRouteTableIds) == 0 {
+		tags := resource.Spec.RouteTables.Tags
+		// Do not expect route tables to have the cluster tag when load balancing vpc ids, like subnets
+		if len(resource.Spec.Vpc.Ids) == 0 && len(resource.Spec.Vpc.Tags) == 0 {
+			if r.clusterInfo == nil || r.clusterInfo.clusterTag == "" {
+				return fmt.Errorf("unable to parse cluster tag: %v", r.clusterInfo)
+			}
+			tags = append([]avov1alpha2.Tag{{Key: r.clusterInfo.clusterTag}}, tags...)
+		}
+
+		routeTables, err := r.awsClient.FilterRouteTablesByTags(ctx, resource.Status.VPCId, tags...)
+		if err != nil {
+			return err
+		}
+		if len(routeTables) == 0 {
+			return fmt.Errorf("failed to find route tables in %s with tags: %v", resource.Status.VPCId, tags)
+		}
+
+		expectedRouteTableIds = make([]string, len(routeTables))
+		for i, rt := range routeTables {
+			expectedRouteTableIds[i] = aws.ToString(rt.RouteTableId)
+		}
+		r.log.V(1).Info("Discovered route table(s):", "routeTables", expectedRouteTableIds)
+	}
+
+	routeTablesToAdd, routeTablesToRemove := util.StringSliceTwoWayDiff(vpce.RouteTableIds, expectedRouteTableIds)
+	if len(routeTablesToAdd) > 0 || len(routeTablesToRemove) > 0 {
+		r.log.V(1).Info("Modifying VPC Endpoint route tables", "routeTablesToAdd", routeTablesToAdd, "routeTablesToRemove", routeTablesToRemove)
+		if _, err := r.awsClient.ModifyVpcEndpoint(ctx, &ec2.ModifyVpcEndpointInput{
+			AddRouteTableIds:    routeTablesToAdd,
+			RemoveRouteTableIds: routeTablesToRemove,
+			VpcEndpointId:       vpce.VpcEndpointId,
+		}); err != nil {
+			return fmt.Errorf("failed to modify route tables, adding: %v, removing: %v with error: %w", routeTablesToAdd, routeTablesToRemove, err)
+		}
+		r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Updated", "Updated VPC endpoint route tables, added: %v, removed: %v", routeTablesToAdd, routeTablesToRemove)
+	}
+
+	routeTableIds := slices.Clone(expectedRouteTableIds)
+	slices.Sort(routeTableIds)
+	if !slices.Equal(resource.Status.RouteTableIds, routeTableIds) {
+		resource.Status.RouteTableIds = routeTableIds
+		if err := r.Status().Update(ctx, resource); err != nil {
+			return fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ensureVpcEndpointRoutes ensures that the routes in .spec.routes send traffic through a GatewayLoadBalancer VPC
+// Endpoint, replacing existing routes for the same destination, removes the previously created routes that are no
+// longer expected and records the created routes in the resource's status. The original target of a replaced route
+// is recorded before replacing it, so that it can be restored instead of deleting the route.
+func (r *reconcileScope) ensureVpcEndpointRoutes(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+	vpceId := aws.ToString(vpce.VpcEndpointId)
+
+	for _, route := range resource.Status.Routes {
+		if slices.Contains(resource.Spec.Routes, route) {
+			continue
+		}
+
+		if err := r.deleteVpcEndpointRoute(ctx, resource, route, vpceId); err != nil {
+			return err
+		}
+	}
+
+	for _, route := range resource.Spec.Routes {
+		routeTables, err := r.awsClient.DescribeRouteTablesById(ctx, []string{route.RouteTableId})
+		if err != nil {
+			return fmt.Errorf("failed to describe route table %s: %w", route.RouteTableId, err)
+		}
+		if len(routeTables) == 0 {
+			return fmt.Errorf("route table %s not found", route.RouteTableId)
+		}
+
+		existing := aws_client.FindRoute(routeTables[0], route)
+		switch {
+		case existing == nil:
+			r.log.V(1).Info("Creating route through VPC Endpoint", "routeTableId", route.RouteTableId, "destination", routeDestination(route))
+			if _, err := r.awsClient.CreateVpcEndpointRoute(ctx, route, vpceId); err != nil {
+				return fmt.Errorf("failed to create route to %s in route table %s: %w", routeDestination(route), route.RouteTableId, err)
+			}
+			r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Created", "Created route to %s in route table %s through VPC endpoint: %s", routeDestination(route), route.RouteTableId, vpceId)
+		case aws.ToString(existing.GatewayId) == vpceId:
+			// AWS reports the VPC Endpoint a route sends traffic through as its gateway
+			continue
+		default:
+			if targetType, targetId := aws_client.GetRouteTarget(*existing); targetType != "" {
+				// Record the original target before replacing it, it would otherwise be lost if the status update failed
+				setReplacedRoute(resource, avov1alpha2.ReplacedRoute{Route: route, TargetType: targetType, TargetId: targetId})
+				if err := r.Status().Update(ctx, resource); err != nil {
+					return fmt.Errorf("failed to update status: %w", err)
+				}
+			}
+
+			r.log.V(1).Info("Replacing route through VPC Endpoint", "routeTableId", route.RouteTableId, "destination", routeDestination(route))
+			if _, err := r.awsClient.ReplaceVpcEndpointRoute(ctx, route, vpceId); err != nil {
+				return fmt.Errorf("failed to replace route to %s in route table %s: %w", routeDestination(route), route.RouteTableId, err)
+			}
+			r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Updated", "Replaced route to %s in route table %s through VPC endpoint: %s", routeDestination(route), route.RouteTableId, vpceId)
+		}
+	}
+
+	if !slices.Equal(resource.Status.Routes, resource.Spec.Routes) {
+		resource.Status.Routes = slices.Clone(resource.Spec.Routes)
+		if err := r.Status().Update(ctx, resource); err != nil {
+			return fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// deleteVpcEndpointRoute deletes a route created for a GatewayLoadBalancer VPC Endpoint, unless it no longer exists or
+// has since been changed to send traffic elsewhere. A route that existed before AVO replaced it is restored to its
+// original target instead.
+func (r *reconcileScope) deleteVpcEndpointRoute(ctx context.Context, resource *avov1alpha2.VpcEndpoint, route avov1alpha2.GatewayLoadBalancerRoute, vpceId string) error {
+	routeTables, err := r.awsClient.DescribeRouteTablesById(ctx, []string{route.RouteTableId})
+	if err != nil {
+		var ae smithy.APIError
+		if errors.As(err, &ae) && ae.ErrorCode() == "InvalidRouteTableID.NotFound" {
+			r.log.V(0).Info("Route table already deleted", "routeTableId", route.RouteTableId)
+			removeReplacedRoute(resource, route)
+			return nil
+		}
+		return fmt.Errorf("failed to describe route table %s: %w", route.RouteTableId, err)
+	}
+
+	var existing *ec2Types.Route
+	if len(routeTables) > 0 {
+		existing = aws_client.FindRoute(routeTables[0], route)
+	}
+	if existing == nil || aws.ToString(existing.GatewayId) != vpceId {
+		r.log.V(0).Info("Route no longer sends traffic through the VPC Endpoint, skipping deletion", "routeTableId", route.RouteTableId, "destination", routeDestination(route))
+		removeReplacedRoute(resource, route)
+		return nil
+	}
+
+	if replaced := findReplacedRoute(resource, route); replaced != nil {
+		r.log.V(0).Info("Restoring route's original target", "routeTableId", route.RouteTableId, "destination", routeDestination(route), "targetId", replaced.TargetId)
+		if _, err := r.awsClient.ReplaceRouteTarget(ctx, route, replaced.TargetType, replaced.TargetId); err != nil {
+			return fmt.Errorf("failed to restore route to %s in route table %s to %s: %w", routeDestination(route), route.RouteTableId, replaced.TargetId, err)
+		}
+		r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Updated", "Restored route to %s in route table %s to %s", routeDestination(route), route.RouteTableId, replaced.TargetId)
+		removeReplacedRoute(resource, route)
+		return nil
+	}
+
+	r.log.V(0).Info("Deleting route through VPC Endpoint", "routeTableId", route.RouteTableId, "destination", routeDestination(route))
+	if _, err := r.awsClient.DeleteRoute(ctx, route); err != nil {
+		return fmt.Errorf("failed to delete route to %s in route table %s: %w", routeDestination(route), route.RouteTableId, err)
+	}
+	r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Deleted", "Deleted route to %s in route table %s", routeDestination(route), route.RouteTableId)
+
+	return nil
+}
+
+// findReplacedRoute returns the recorded original target of a route replaced by AVO, or nil if AVO created the route
+func findReplacedRoute(resource *avov1alpha2.VpcEndpoint, route avov1alpha2.GatewayLoadBalancerRoute) *avov1alpha2.ReplacedRoute {
+	for i := range resource.Status.ReplacedRoutes {
+		if resource.Status.ReplacedRoutes[i].Route == route {
+			return &resource.Status.ReplacedRoutes[i]
+		}
+	}
+
+	return nil
+}
+
+// setReplacedRoute records the original target of a route replaced by AVO in the resource's status
+func setReplacedRoute(resource *avov1alpha2.VpcEndpoint, replaced avov1alpha2.ReplacedRoute) {
+	if existing := findReplacedRoute(resource, replaced.Route); existing != nil {
+		*existing = replaced
+		return
+	}
+
+	resource.Status.ReplacedRoutes = append(resource.Status.ReplacedRoutes, replaced)
+}
+
+// removeReplacedRoute removes the recorded original target of a route from the resource's status
+func removeReplacedRoute(resource *avov1alpha2.VpcEndpoint, route avov1alpha2.GatewayLoadBalancerRoute) {
+	resource.Status.ReplacedRoutes = slices.DeleteFunc(resource.Status.ReplacedRoutes, func(replaced avov1alpha2.ReplacedRoute) bool {
+		return replaced.Route == route
+	})
+	if len(resource.Status.ReplacedRoutes) == 0 {
+		resource.Status.ReplacedRoutes = nil
+	}
+}
+
+// routeDestination returns the destination CIDR block of a route
+func routeDestination(route avov1alpha2.GatewayLoadBalancerRoute) string {
+	if route.DestinationIpv6CidrBlock != "" {
+		return route.DestinationIpv6CidrBlock
+	}
+
+	return route.DestinationCidrBlock
+}
+
+// errPolicyDocumentNotFound is returned when an optional policy ConfigMap or its key is missing
+var errPolicyDocumentNotFound = errors.New("policy document not found")
+
+// getVpcEndpointPolicyDocument returns the normalized policy document from .spec.policy, or an empty string when the
+// VpcEndpoint has no policy. errPolicyDocumentNotFound is returned when an optional ConfigMap or key is missing.
+func (r *reconcileScope) getVpcEndpointPolicyDocument(ctx context.Context, resource *avov1alpha2.VpcEndpoint) (string, error) {
+	if resource.Spec.Policy == nil {
+		return "", nil
+	}
+
+	document := resource.Spec.Policy.Document
+	if ref := resource.Spec.Policy.ConfigMapRef; ref != nil {
+		optional := ref.Optional != nil && *ref.Optional
+		cm := new(corev1.ConfigMap)
+		// Like the AWS credential override secrets, ConfigMaps are read without a cache to minimize the RBAC needed
+		if err := r.APIReader.Get(ctx, client.ObjectKey{Namespace: resource.Namespace, Name: ref.Name}, cm); err != nil {
+			if kerr.IsNotFound(err) && optional {
+				return "", fmt.Errorf("%w: ConfigMap %s not found", errPolicyDocumentNotFound, ref.Name)
+			}
+			return "", fmt.Errorf("failed to get the policy ConfigMap %s: %w", ref.Name, err)
+		}
+
+		d, ok := cm.Data[ref.Key]
+		if !ok {
+			if optional {
+				return "", fmt.Errorf("%w: key %s not found in ConfigMap %s", errPolicyDocumentNotFound, ref.Key, ref.Name)
+			}
+			return "", fmt.Errorf("key %s not found in the policy ConfigMap %s", ref.Key, ref.Name)
+		}
+		document = d
+	}
+
+	normalized, err := util.NormalizePolicyDocument(document)
+	if err != nil {
+		return "", fmt.Errorf("invalid VPC Endpoint policy document: %w", err)
+	}
+
+	return normalized, nil
+}
+
+// ensureVpcEndpointPolicy ensures that the VPC Endpoint's policy matches .spec.policy and records the hash of the
+// applied policy in the resource's status. Policies are only reset to the default when AVO previously applied one, so
+// that the policies of VPC Endpoints without .spec.policy are left as is. When an optional policy ConfigMap or key
+// goes missing, the applied policy is kept rather than replaced by the default full access policy, and reported in
+// the AWSVpcEndpointPolicyReady condition.
+func (r *reconcileScope) ensureVpcEndpointPolicy(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+	policyDocument, err := r.getVpcEndpointPolicyDocument(ctx, resource)
+	if errors.Is(err, errPolicyDocumentNotFound) {
+		if resource.Status.PolicyHash == "" {
+			return r.updatePolicyStatus(ctx, resource, "", nil)
+		}
+
+		r.log.V(0).Info("Keeping the applied VPC Endpoint policy", "id", aws.ToString(vpce.VpcEndpointId), "reason", err.Error())
+		return r.updatePolicyStatus(ctx, resource, resource.Status.PolicyHash, &metav1.Condition{
+			Type:               avov1alpha2.AWSVpcEndpointPolicyCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "PolicyDocumentNotFound",
+			Message:            fmt.Sprintf("Keeping the applied policy: %s", err),
+			ObservedGeneration: resource.Generation,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if policyDocument == "" {
+		if resource.Status.PolicyHash != "" {
+			r.log.V(1).Info("Resetting VPC Endpoint policy", "id", aws.ToString(vpce.VpcEndpointId))
+			if _, err := r.awsClient.ModifyVpcEndpoint(ctx, &ec2.ModifyVpcEndpointInput{
+				ResetPolicy:   aws.Bool(true),
+				VpcEndpointId: vpce.VpcEndpointId,
+			}); err != nil {
+				return fmt.Errorf("failed to reset VPC Endpoint policy: %w", err)
+			}
+			r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Updated", "Reset VPC endpoint policy to the default: %s", aws.ToString(vpce.VpcEndpointId))
+		}
+
+		return r.updatePolicyStatus(ctx, resource, "", nil)
+	}
+
+	if !policyDocumentMatches(vpce, policyDocument) {
+		r.log.V(1).Info("Updating VPC Endpoint policy", "id", aws.ToString(vpce.VpcEndpointId))
+		if _, err := r.awsClient.ModifyVpcEndpoint(ctx, &ec2.ModifyVpcEndpointInput{
+			PolicyDocument: aws.String(policyDocument),
+			VpcEndpointId:  vpce.VpcEndpointId,
+		}); err != nil {
+			return fmt.Errorf("failed to update VPC Endpoint policy: %w", err)
+		}
+		r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Updated", "Updated VPC endpoint policy: %s", aws.ToString(vpce.VpcEndpointId))
+	}
+
+	return r.updatePolicyStatus(ctx, resource, util.PolicyDocumentHash(policyDocument), &metav1.Condition{
+		Type:               avov1alpha2.AWSVpcEndpointPolicyCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Applied",
+		Message:            "Applied",
+		ObservedGeneration: resource.Generation,
+	})
+}
+
+// policyDocumentMatches returns true if the VPC Endpoint's policy is the normalized policyDocument. AWS may reformat
+// the policy document, so documents are compared after normalizing them.
+func policyDocumentMatches(vpce *ec2Types.VpcEndpoint, policyDocument string) bool {
+	actual, err := util.NormalizePolicyDocument(aws.ToString(vpce.PolicyDocument))
+	return err == nil && actual == policyDocument
+}
+
+// updatePolicyStatus records the hash of the applied policy and sets the AWSVpcEndpointPolicyReady condition, or
+// removes it when condition is nil, only updating the status if either changed
+func (r *reconcileScope) updatePolicyStatus(ctx context.Context, resource *avov1alpha2.VpcEndpoint, hash string, condition *metav1.Condition) error {
+	changed := resource.Status.PolicyHash != hash
+	resource.Status.PolicyHash = hash
+	if condition != nil {
+		changed = meta.SetStatusCondition(&resource.Status.Conditions, *condition) || changed
+	} else {
+		changed = meta.RemoveStatusCondition(&resource.Status.Conditions, avov1alpha2.AWSVpcEndpointPolicyCondition) || changed
+	}
+
+	if !changed {
+		return nil
+	}
+
+	if err := r.Status().Update(ctx, resource); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// ipAddressType returns the IP address type of a VpcEndpoint CR, defaulting to ipv4
+func ipAddressType(resource *avov1alpha2.VpcEndpoint) avov1alpha2.IpAddressType {
+	if resource.Spec.IpAddressType == "" {
+		return avov1alpha2.IpAddressTypeIpv4
+	}
+
+	return resource.Spec.IpAddressType
+}
+
+// dnsRecordIpType returns the DNS record IP type of a VpcEndpoint CR, defaulting to its IP address type
+func dnsRecordIpType(resource *avov1alpha2.VpcEndpoint) avov1alpha2.DnsRecordIpType {
+	if resource.Spec.DnsRecordIpType == "" {
+		return avov1alpha2.DnsRecordIpType(ipAddressType(resource))
 	}
 
-	// Removing subnets first before adding to avoid
-	// DuplicateSubnetsInSameZone: Found another VPC endpoint subnet in the availability zone of <existing subnet>
-	if len(subnetsToRemove) > 0 {
-		r.log.V(1).Info("Removing subnet(s) from VPC Endpoint", "subnetsToRemove", subnetsToRemove)
-		if _, err := r.awsClient.ModifyVpcEndpoint(ctx, &ec2.ModifyVpcEndpointInput{
-			RemoveSubnetIds: subnetsToRemove,
-			VpcEndpointId:   vpce.VpcEndpointId,
-		}); err != nil {
-			return fmt.Errorf("failed to remove subnets: %v with error: %w", subnetsToRemove, err)
+	return resource.Spec.DnsRecordIpType
+}
+
+// subnetSupportsIpAddressType returns true if network interfaces with the provided IP address type can be created in
+// the subnet: dualstack requires an IPv6 CIDR block along with the IPv4 one and ipv6 requires an IPv6-only subnet
+func subnetSupportsIpAddressType(subnet ec2Types.Subnet, ipAddressType avov1alpha2.IpAddressType) bool {
+	hasIpv6 := false
+	for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+		if association.Ipv6CidrBlockState != nil && association.Ipv6CidrBlockState.State == ec2Types.SubnetCidrBlockStateCodeAssociated {
+			hasIpv6 = true
+			break
 		}
 	}
+	ipv6Native := aws.ToBool(subnet.Ipv6Native)
 
-	if len(subnetsToAdd) > 0 {
-		r.log.V(1).Info("Adding subnet(s) to VPC Endpoint", "subnetsToAdd", subnetsToAdd)
-		if _, err := r.awsClient.ModifyVpcEndpoint(ctx, &ec2.ModifyVpcEndpointInput{
-			AddSubnetIds:  subnetsToAdd,
-			VpcEndpointId: vpce.VpcEndpointId,
-		}); err != nil {
-			return fmt.Errorf("failed to add subnets: %v with error: %w", subnetsToAdd, err)
-		}
+	switch ipAddressType {
+	case avov1alpha2.IpAddressTypeDualstack:
+		return hasIpv6 && !ipv6Native
+	case avov1alpha2.IpAddressTypeIpv6:
+		return ipv6Native
+	default:
+		return !ipv6Native
+	}
+}
+
+// ensureVpcEndpointIpAddressType ensures that the VPC Endpoint's IP address type and DNS record IP type are the
+// expected ones. VPC Endpoints reporting no IP address type or DNS record IP type use ipv4.
+func (r *reconcileScope) ensureVpcEndpointIpAddressType(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+	expectedIpAddressType := ec2Types.IpAddressType(ipAddressType(resource))
+	expectedDnsRecordIpType := ec2Types.DnsRecordIpType(dnsRecordIpType(resource))
+
+	actualIpAddressType := vpce.IpAddressType
+	if actualIpAddressType == "" {
+		actualIpAddressType = ec2Types.IpAddressTypeIpv4
+	}
+	actualDnsRecordIpType := ec2Types.DnsRecordIpTypeIpv4
+	if vpce.DnsOptions != nil && vpce.DnsOptions.DnsRecordIpType != "" {
+		actualDnsRecordIpType = vpce.DnsOptions.DnsRecordIpType
+	}
+
+	if actualIpAddressType == expectedIpAddressType && actualDnsRecordIpType == expectedDnsRecordIpType {
+		return nil
 	}
 
+	r.log.V(1).Info("Modifying VPC Endpoint IP address type", "ipAddressType", expectedIpAddressType, "dnsRecordIpType", expectedDnsRecordIpType)
+	if _, err := r.awsClient.ModifyVpcEndpoint(ctx, &ec2.ModifyVpcEndpointInput{
+		VpcEndpointId: vpce.VpcEndpointId,
+		IpAddressType: expectedIpAddressType,
+		DnsOptions: &ec2Types.DnsOptionsSpecification{
+			DnsRecordIpType: expectedDnsRecordIpType,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to modify IP address type to %s: %w", expectedIpAddressType, err)
+	}
+	r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Updated", "Updated VPC endpoint IP address type to %s with %s DNS records", expectedIpAddressType, expectedDnsRecordIpType)
+
 	return nil
 }
 
-// ensureVpcEndpointSecurityGroups ensures that the security group associated with the VPC Endpoint
-// is only the expected one.
-func (r *VpcEndpointReconciler) ensureVpcEndpointSecurityGroups(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+// ensureVpcEndpointSecurityGroups ensures that the security groups associated with the VPC Endpoint
+// are only the expected ones: the managed security group, unless disabled, and the user's security groups.
+func (r *reconcileScope) ensureVpcEndpointSecurityGroups(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
 	sgToAdd, sgToRemove := r.diffVpcEndpointSecurityGroups(vpce, resource)
 
 	if len(sgToAdd) > 0 {
@@ -792,10 +1732,160 @@ func (r *VpcEndpointReconciler) ensureVpcEndpointSecurityGroups(ctx context.Cont
 	return nil
 }
 
+// ensureVpcEndpointTags ensures that the user tags exist on the VPC Endpoint and its network interfaces. The default
+// tags are applied when the VPC Endpoint is created and are used to find it, so they are left as is.
+func (r *reconcileScope) ensureVpcEndpointTags(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+	userTags := r.userTags(resource)
+
+	updated, err := r.ensureEc2Tags(ctx, resource, *vpce.VpcEndpointId, vpce.Tags, userTags)
+	if err != nil {
+		return err
+	}
+	if updated {
+		r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Updated", "Updated VPC endpoint tags: %s", *vpce.VpcEndpointId)
+	}
+
+	enis, err := r.describeVpcEndpointNetworkInterfaces(ctx, vpce)
+	if err != nil {
+		return err
+	}
+
+	for _, eni := range enis {
+		updated, err := r.ensureEc2Tags(ctx, resource, *eni.NetworkInterfaceId, eni.TagSet, userTags)
+		if err != nil {
+			return err
+		}
+		if updated {
+			r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Updated", "Updated network interface tags: %s", *eni.NetworkInterfaceId)
+		}
+	}
+
+	return nil
+}
+
+// describeVpcEndpointNetworkInterfaces returns the network interfaces of a VPC Endpoint, if it has any
+func (r *reconcileScope) describeVpcEndpointNetworkInterfaces(ctx context.Context, vpce *ec2Types.VpcEndpoint) ([]ec2Types.NetworkInterface, error) {
+	if len(vpce.NetworkInterfaceIds) == 0 {
+		return nil, nil
+	}
+
+	resp, err := r.awsClient.DescribeNetworkInterfacesById(ctx, vpce.NetworkInterfaceIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.NetworkInterfaces, nil
+}
+
+// updateVpcEndpointObservedState refreshes the VPC Endpoint's owner, private DNS setting, policy, subnets, network
+// interfaces and DNS entries in the VpcEndpoint CR's status, without updating it. The subnets and network interfaces
+// are only described when their IDs differ from the ones in the status, so that a reconcile of an unchanged VPC
+// Endpoint doesn't make any additional EC2 calls.
+func (r *reconcileScope) updateVpcEndpointObservedState(ctx context.Context, vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) error {
+	resource.Status.OwnerId = aws.ToString(vpce.OwnerId)
+	resource.Status.PrivateDnsEnabled = aws.ToBool(vpce.PrivateDnsEnabled)
+
+	resource.Status.ObservedPolicyHash = ""
+	if vpce.PolicyDocument != nil {
+		if normalized, err := util.NormalizePolicyDocument(*vpce.PolicyDocument); err == nil {
+			resource.Status.ObservedPolicyHash = util.PolicyDocumentHash(normalized)
+		}
+	}
+
+	statusNetworkInterfaceIds := make([]string, 0, len(resource.Status.NetworkInterfaces))
+	for _, eni := range resource.Status.NetworkInterfaces {
+		statusNetworkInterfaceIds = append(statusNetworkInterfaceIds, eni.Id)
+	}
+	if toAdd, toRemove := util.StringSliceTwoWayDiff(statusNetworkInterfaceIds, vpce.NetworkInterfaceIds); len(toAdd) > 0 || len(toRemove) > 0 {
+		enis, err := r.describeVpcEndpointNetworkInterfaces(ctx, vpce)
+		if err != nil {
+			return err
+		}
+
+		resource.Status.NetworkInterfaces = nil
+		for _, eni := range enis {
+			status := avov1alpha2.VpcEndpointNetworkInterface{
+				Id:               aws.ToString(eni.NetworkInterfaceId),
+				SubnetId:         aws.ToString(eni.SubnetId),
+				AvailabilityZone: aws.ToString(eni.AvailabilityZone),
+				PrivateIpAddress: aws.ToString(eni.PrivateIpAddress),
+			}
+			for _, ip := range eni.Ipv6Addresses {
+				status.Ipv6Addresses = append(status.Ipv6Addresses, aws.ToString(ip.Ipv6Address))
+			}
+			resource.Status.NetworkInterfaces = append(resource.Status.NetworkInterfaces, status)
+		}
+		sort.Slice(resource.Status.NetworkInterfaces, func(i, j int) bool {
+			a, b := resource.Status.NetworkInterfaces[i], resource.Status.NetworkInterfaces[j]
+			return a.AvailabilityZone < b.AvailabilityZone || (a.AvailabilityZone == b.AvailabilityZone && a.Id < b.Id)
+		})
+	}
+
+	statusSubnetIds := make([]string, 0, len(resource.Status.Subnets))
+	for _, subnet := range resource.Status.Subnets {
+		statusSubnetIds = append(statusSubnetIds, subnet.Id)
+	}
+	if toAdd, toRemove := util.StringSliceTwoWayDiff(statusSubnetIds, vpce.SubnetIds); len(toAdd) > 0 || len(toRemove) > 0 {
+		// Network interfaces don't report the ID of their Availability Zone, so the subnets are described instead
+		resource.Status.Subnets = nil
+		if len(vpce.SubnetIds) > 0 {
+			subnets, err := r.awsClient.DescribeSubnetsById(ctx, vpce.SubnetIds)
+			if err != nil {
+				return err
+			}
+
+			for _, subnet := range subnets {
+				resource.Status.Subnets = append(resource.Status.Subnets, avov1alpha2.VpcEndpointSubnet{
+					Id:                 aws.ToString(subnet.SubnetId),
+					AvailabilityZone:   aws.ToString(subnet.AvailabilityZone),
+					AvailabilityZoneId: aws.ToString(subnet.AvailabilityZoneId),
+				})
+			}
+			sort.Slice(resource.Status.Subnets, func(i, j int) bool {
+				a, b := resource.Status.Subnets[i], resource.Status.Subnets[j]
+				return a.AvailabilityZone < b.AvailabilityZone || (a.AvailabilityZone == b.AvailabilityZone && a.Id < b.Id)
+			})
+		}
+	}
+
+	// AWS lists the regional DNS entry first, followed by the zonal ones
+	resource.Status.DnsEntries = nil
+	for _, dnsEntry := range vpce.DnsEntries {
+		resource.Status.DnsEntries = append(resource.Status.DnsEntries, avov1alpha2.VpcEndpointDnsEntry{
+			DnsName:      aws.ToString(dnsEntry.DnsName),
+			HostedZoneId: aws.ToString(dnsEntry.HostedZoneId),
+		})
+	}
+
+	return nil
+}
+
+// clearVpcEndpointObservedState removes the state of a deleted VPC Endpoint from the VpcEndpoint CR's status, without
+// updating it
+func clearVpcEndpointObservedState(resource *avov1alpha2.VpcEndpoint) {
+	resource.Status.OwnerId = ""
+	resource.Status.PrivateDnsEnabled = false
+	resource.Status.ObservedPolicyHash = ""
+	resource.Status.Subnets = nil
+	resource.Status.NetworkInterfaces = nil
+	resource.Status.DnsEntries = nil
+}
+
 // diffVpcEndpointSecurityGroups compares the security groups associated with the VPC Endpoint with
-// the security group ID recorded in the resource's status, returning security groups that need to be added
+// the security group IDs recorded in the resource's status, returning security groups that need to be added
 // and security groups that need to be removed from the VPC Endpoint.
-func (r *VpcEndpointReconciler) diffVpcEndpointSecurityGroups(vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) ([]string, []string) {
+func (r *reconcileScope) diffVpcEndpointSecurityGroups(vpce *ec2Types.VpcEndpoint, resource *avov1alpha2.VpcEndpoint) ([]string, []string) {
+	var expectedSgIds []string
+	if managedSecurityGroupEnabled(resource) && resource.Status.SecurityGroupId != "" {
+		expectedSgIds = append(expectedSgIds, resource.Status.SecurityGroupId)
+	}
+	expectedSgIds = append(expectedSgIds, resource.Status.UserSecurityGroupIds...)
+
+	// A VPC Endpoint must keep at least one security group, so wait until the expected ones are known
+	if len(expectedSgIds) == 0 {
+		return nil, nil
+	}
+
 	vpceSgIds := make([]string, len(vpce.Groups))
 	for i := range vpce.Groups {
 		vpceSgIds[i] = *vpce.Groups[i].GroupId
@@ -803,14 +1893,14 @@ func (r *VpcEndpointReconciler) diffVpcEndpointSecurityGroups(vpce *ec2Types.Vpc
 
 	sgToAdd, sgToRemove := util.StringSliceTwoWayDiff(
 		vpceSgIds,
-		[]string{resource.Status.SecurityGroupId},
+		expectedSgIds,
 	)
 
 	return sgToAdd, sgToRemove
 }
 
 // findOrCreatePrivateHostedZone ensures the existence of a Route53 Private Hosted Zone given a custom domain name
-func (r *VpcEndpointReconciler) findOrCreatePrivateHostedZone(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+func (r *reconcileScope) findOrCreatePrivateHostedZone(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
 	if resource == nil {
 		// Should never happen
 		return errors.New("resource must be specified")
@@ -905,8 +1995,10 @@ func (r *VpcEndpointReconciler) findOrCreatePrivateHostedZone(ctx context.Contex
 			}
 		}
 
-		// No existing zone found, create one
-		createResp, err := r.awsClient.CreateHostedZone(ctx, domainName, resource.Status.VPCId, r.clusterInfo.region)
+		// No existing zone found, create one. The caller reference ensures that if a previous attempt created the
+		// hosted zone, but failed to record it in the status, the existing hosted zone is returned instead.
+		callerReference := util.GenerateClientToken(string(resource.UID), resource.Generation, "hostedzone", domainName)
+		createResp, err := r.awsClient.CreateHostedZone(ctx, domainName, resource.Status.VPCId, r.clusterInfo.region, callerReference)
 		if err != nil {
 			return fmt.Errorf("failed to create hosted zone: %w", err)
 		}
@@ -927,8 +2019,63 @@ func (r *VpcEndpointReconciler) findOrCreatePrivateHostedZone(ctx context.Contex
 	return nil
 }
 
-// generateRoute53Record generates the expected Route53 Record for a provided VpcEndpoint CR
-func (r *VpcEndpointReconciler) generateRoute53Record(ctx context.Context, resource *avov1alpha2.VpcEndpoint) (*route53Types.ResourceRecord, error) {
+// route53Records returns the Route53 Records configured for a provided VpcEndpoint CR: .record, if it has a hostname,
+// followed by .records
+func route53Records(resource *avov1alpha2.VpcEndpoint) []avov1alpha2.Route53HostedZoneRecord {
+	var records []avov1alpha2.Route53HostedZoneRecord
+	if resource.Spec.CustomDns.Route53PrivateHostedZone.Record.Hostname != "" {
+		records = append(records, resource.Spec.CustomDns.Route53PrivateHostedZone.Record)
+	}
+
+	return append(records, resource.Spec.CustomDns.Route53PrivateHostedZone.Records...)
+}
+
+// ownedRoute53Records returns the Route53 Records created for a provided VpcEndpoint CR. VpcEndpoints reconciled
+// before .status.resourceRecords existed only track .status.resourceRecordSet, whose record may be of any type.
+func ownedRoute53Records(resource *avov1alpha2.VpcEndpoint) []avov1alpha2.ResourceRecordStatus {
+	if len(resource.Status.ResourceRecords) == 0 && resource.Status.ResourceRecordSet != "" {
+		return []avov1alpha2.ResourceRecordStatus{{Name: resource.Status.ResourceRecordSet}}
+	}
+
+	return resource.Status.ResourceRecords
+}
+
+// isOwnedRoute53Record returns true if the provided Route53 Record is one of the owned records
+func isOwnedRoute53Record(rrs route53Types.ResourceRecordSet, owned []avov1alpha2.ResourceRecordStatus) bool {
+	for _, o := range owned {
+		if rrs.Name != nil && aws_client.Route53RecordNameEqual(*rrs.Name, o.Name) && (o.Type == "" || string(rrs.Type) == o.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// route53Record is a Route53 Record expected for a VpcEndpoint CR, along with its status
+type route53Record struct {
+	rrs    route53Types.ResourceRecordSet
+	status avov1alpha2.ResourceRecordStatus
+}
+
+// vpceZone is an Availability Zone of a VPC Endpoint, along with the VPC Endpoint's zonal DNS entry in it
+type vpceZone struct {
+	name     string
+	id       string
+	dnsEntry ec2Types.DnsEntry
+}
+
+// label returns how the Availability Zone is identified in the hostname of a zonal record
+func (z vpceZone) label(label avov1alpha2.ZonalRecordLabel) string {
+	if label == avov1alpha2.ZonalRecordLabelAvailabilityZoneId {
+		return z.id
+	}
+
+	return z.name
+}
+
+// generateRoute53Records generates the expected Route53 Records in the hosted zone with the provided domain name for
+// a provided VpcEndpoint CR, in the same order as route53Records, each followed by its zonal records if enabled
+func (r *reconcileScope) generateRoute53Records(ctx context.Context, resource *avov1alpha2.VpcEndpoint, domainName string) ([]route53Record, error) {
 	if resource.Status.VPCEndpointId == "" {
 		return nil, fmt.Errorf("VPCEndpointID status is missing")
 	}
@@ -940,45 +2087,348 @@ func (r *VpcEndpointReconciler) generateRoute53Record(ctx context.Context, resou
 
 	// VPCEndpoint doesn't exist anymore for some reason
 	if vpceResp == nil || len(vpceResp.VpcEndpoints) == 0 {
-		return nil, nil //nolint:nilnil
+		return nil, nil
 	}
+	vpce := vpceResp.VpcEndpoints[0]
 
 	// DNSEntries won't be populated until the state is available
-	if string(vpceResp.VpcEndpoints[0].State) != "available" {
+	if string(vpce.State) != "available" {
 		return nil, fmt.Errorf("VPCEndpoint is not in the available state")
 	}
 
-	if len(vpceResp.VpcEndpoints[0].DnsEntries) == 0 {
+	if len(vpce.DnsEntries) == 0 {
 		if !resource.DeletionTimestamp.IsZero() {
 			// When we're deleting the VPC Endpoint, handle the edge case where it doesn't have any subnets attached anymore
-			return nil, nil //nolint:nilnil
+			return nil, nil
 		}
 
 		return nil, fmt.Errorf("VPCEndpoint has no DNS entries")
 	}
 
-	return &route53Types.ResourceRecord{
-		Value: vpceResp.VpcEndpoints[0].DnsEntries[0].DnsName,
-	}, nil
+	var records []avov1alpha2.Route53HostedZoneRecord
+	for _, record := range route53Records(resource) {
+		records = append(records, dualStackRoute53Records(record, route53RecordIpType(resource))...)
+	}
+
+	// The private IPs of the VPC Endpoint's network interfaces and its Availability Zones are only looked up if
+	// a record needs them
+	var (
+		ipv4s, ipv6s map[string][]string
+		zones        []vpceZone
+	)
+	for _, record := range records {
+		if (record.Type == avov1alpha2.Route53RecordTypeA && ipv4s == nil) || (record.Type == avov1alpha2.Route53RecordTypeAAAA && ipv6s == nil) {
+			if len(vpce.NetworkInterfaceIds) == 0 {
+				return nil, fmt.Errorf("VPCEndpoint has no network interfaces")
+			}
+		}
+
+		if record.Type == avov1alpha2.Route53RecordTypeA && ipv4s == nil {
+			if ipv4s, err = r.awsClient.GetNetworkInterfacePrivateIps(ctx, vpce.NetworkInterfaceIds); err != nil {
+				return nil, err
+			}
+		}
+
+		if record.Type == avov1alpha2.Route53RecordTypeAAAA && ipv6s == nil {
+			if ipv6s, err = r.awsClient.GetNetworkInterfaceIpv6Ips(ctx, vpce.NetworkInterfaceIds); err != nil {
+				return nil, err
+			}
+		}
+
+		if record.Zonal != nil && zones == nil {
+			if zones, err = r.getVpceZones(ctx, vpce); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	expected := make([]route53Record, 0, len(records))
+	for _, record := range records {
+		ips := ipv4s
+		if record.Type == avov1alpha2.Route53RecordTypeAAAA {
+			ips = ipv6s
+		}
+
+		var allIps []string
+		for _, zoneIps := range ips {
+			allIps = append(allIps, zoneIps...)
+		}
+
+		// The first DNS entry is the VPC Endpoint's regional DNS name
+		rrs, err := generateRoute53RecordSet(record, fmt.Sprintf("%s.%s", record.Hostname, strings.TrimRight(domainName, ".")), vpce.DnsEntries[0], allIps)
+		if err != nil {
+			return nil, err
+		}
+		expected = append(expected, route53Record{
+			rrs: rrs,
+			status: avov1alpha2.ResourceRecordStatus{
+				Hostname:            record.Hostname,
+				Name:                *rrs.Name,
+				Type:                string(rrs.Type),
+				ExternalNameService: record.ExternalNameService.Name,
+			},
+		})
+
+		if record.Zonal == nil {
+			continue
+		}
+
+		for _, zone := range zones {
+			label := zone.label(record.Zonal.Label)
+			hostname := fmt.Sprintf("%s.%s", label, record.Hostname)
+			rrs, err := generateRoute53RecordSet(record, fmt.Sprintf("%s.%s", hostname, strings.TrimRight(domainName, ".")), zone.dnsEntry, ips[zone.name])
+			if err != nil {
+				return nil, err
+			}
+
+			status := avov1alpha2.ResourceRecordStatus{
+				Hostname:         hostname,
+				AvailabilityZone: zone.name,
+				Name:             *rrs.Name,
+				Type:             string(rrs.Type),
+			}
+			if record.Zonal.ExternalNameServices {
+				status.ExternalNameService = fmt.Sprintf("%s-%s", record.ExternalNameService.Name, label)
+			}
+			expected = append(expected, route53Record{rrs: rrs, status: status})
+		}
+	}
+
+	return expected, nil
+}
+
+// route53RecordIpType returns the IP type of the Route53 Records AVO publishes for a VpcEndpoint CR, which follows
+// its DNS record IP type or, when service-defined, its IP address type
+func route53RecordIpType(resource *avov1alpha2.VpcEndpoint) avov1alpha2.DnsRecordIpType {
+	if ipType := dnsRecordIpType(resource); ipType != avov1alpha2.DnsRecordIpTypeServiceDefined {
+		return ipType
+	}
+
+	return avov1alpha2.DnsRecordIpType(ipAddressType(resource))
+}
+
+// dualStackRoute53Records returns the Route53 Records to publish for a configured record given the IP type of the
+// VpcEndpoint CR's records. A and AliasA records are accompanied by the matching AAAA and AliasAAAA records for
+// dualstack and replaced by them for ipv6. Accompanying records don't get ExternalName services, since the
+// ExternalName services of the configured record already resolve to them.
+func dualStackRoute53Records(record avov1alpha2.Route53HostedZoneRecord, ipType avov1alpha2.DnsRecordIpType) []avov1alpha2.Route53HostedZoneRecord {
+	var ipv6Type avov1alpha2.Route53RecordType
+	switch record.Type {
+	case avov1alpha2.Route53RecordTypeA:
+		ipv6Type = avov1alpha2.Route53RecordTypeAAAA
+	case avov1alpha2.Route53RecordTypeAliasA:
+		ipv6Type = avov1alpha2.Route53RecordTypeAliasAAAA
+	default:
+		return []avov1alpha2.Route53HostedZoneRecord{record}
+	}
+
+	switch ipType {
+	case avov1alpha2.DnsRecordIpTypeIpv6:
+		record.Type = ipv6Type
+		return []avov1alpha2.Route53HostedZoneRecord{record}
+	case avov1alpha2.DnsRecordIpTypeDualstack:
+		ipv6Record := record
+		ipv6Record.Type = ipv6Type
+		ipv6Record.ExternalNameService = avov1alpha2.ExternalNameService{}
+		if record.Zonal != nil {
+			ipv6Record.Zonal = &avov1alpha2.ZonalRecords{Label: record.Zonal.Label}
+		}
+		return []avov1alpha2.Route53HostedZoneRecord{record, ipv6Record}
+	default:
+		return []avov1alpha2.Route53HostedZoneRecord{record}
+	}
+}
+
+// generateRoute53RecordSet generates a Route53 Record with the provided name according to the type of the record,
+// pointing to the provided DNS entry of a VPC Endpoint or, for A and AAAA records, the provided IPs
+func generateRoute53RecordSet(record avov1alpha2.Route53HostedZoneRecord, name string, dnsEntry ec2Types.DnsEntry, ips []string) (route53Types.ResourceRecordSet, error) {
+	ttl := record.TTL
+	if ttl <= 0 {
+		ttl = defaultRoute53RecordTTL
+	}
+
+	rrs := route53Types.ResourceRecordSet{
+		Name: aws.String(name),
+	}
+
+	switch record.Type {
+	case avov1alpha2.Route53RecordTypeAliasA, avov1alpha2.Route53RecordTypeAliasAAAA:
+		if dnsEntry.HostedZoneId == nil {
+			return rrs, fmt.Errorf("VPCEndpoint DNS entry %s has no hosted zone id", aws.ToString(dnsEntry.DnsName))
+		}
+
+		rrs.Type = route53Types.RRTypeA
+		if record.Type == avov1alpha2.Route53RecordTypeAliasAAAA {
+			rrs.Type = route53Types.RRTypeAaaa
+		}
+		rrs.AliasTarget = &route53Types.AliasTarget{
+			DNSName:              dnsEntry.DnsName,
+			HostedZoneId:         dnsEntry.HostedZoneId,
+			EvaluateTargetHealth: false,
+		}
+	case avov1alpha2.Route53RecordTypeA, avov1alpha2.Route53RecordTypeAAAA:
+		if len(ips) == 0 {
+			if record.Type == avov1alpha2.Route53RecordTypeAAAA {
+				return rrs, fmt.Errorf("VPCEndpoint network interfaces have no IPv6 addresses for %s", name)
+			}
+			return rrs, fmt.Errorf("VPCEndpoint network interfaces have no private IPs for %s", name)
+		}
+		// Sort the IPs so that the record is stable regardless of the order AWS returns the network interfaces in
+		sorted := append([]string(nil), ips...)
+		sort.Strings(sorted)
+
+		rrs.Type = route53Types.RRTypeA
+		if record.Type == avov1alpha2.Route53RecordTypeAAAA {
+			rrs.Type = route53Types.RRTypeAaaa
+		}
+		rrs.TTL = aws.Int64(ttl)
+		for _, ip := range sorted {
+			rrs.ResourceRecords = append(rrs.ResourceRecords, route53Types.ResourceRecord{Value: aws.String(ip)})
+		}
+	default:
+		rrs.Type = route53Types.RRTypeCname
+		rrs.TTL = aws.Int64(ttl)
+		rrs.ResourceRecords = []route53Types.ResourceRecord{
+			{
+				Value: dnsEntry.DnsName,
+			},
+		}
+	}
+
+	return rrs, nil
+}
+
+// getVpceZones returns the Availability Zones of a VPC Endpoint's subnets, sorted by name, along with its zonal DNS
+// entries. Zonal DNS names are of the form "<vpce id>-<suffix>-<availability zone name>.<service domain>".
+func (r *reconcileScope) getVpceZones(ctx context.Context, vpce ec2Types.VpcEndpoint) ([]vpceZone, error) {
+	if len(vpce.SubnetIds) == 0 {
+		return nil, fmt.Errorf("VPCEndpoint has no subnets")
+	}
+
+	subnets, err := r.awsClient.DescribeSubnetsById(ctx, vpce.SubnetIds)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make([]vpceZone, 0, len(subnets))
+	for _, subnet := range subnets {
+		zone := vpceZone{
+			name: aws.ToString(subnet.AvailabilityZone),
+			id:   aws.ToString(subnet.AvailabilityZoneId),
+		}
+
+		found := false
+		for _, dnsEntry := range vpce.DnsEntries {
+			if strings.HasSuffix(strings.SplitN(aws.ToString(dnsEntry.DnsName), ".", 2)[0], "-"+zone.name) {
+				zone.dnsEntry = dnsEntry
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("VPCEndpoint has no DNS entry for Availability Zone %s", zone.name)
+		}
+
+		zones = append(zones, zone)
+	}
+
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].name < zones[j].name
+	})
+
+	return zones, nil
+}
+
+// findReplacedRoute53Records returns the records in the hosted zone that must be deleted when upserting the provided
+// records: records with the same name as one of them, but a different type, and owned records that are no longer
+// expected. Only the records with the names of the expected and owned records are listed, and only when the owned
+// records differ from the expected ones.
+func (r *reconcileScope) findReplacedRoute53Records(ctx context.Context, hostedZoneId string, expected []route53Record, owned []avov1alpha2.ResourceRecordStatus) ([]route53Types.ResourceRecordSet, error) {
+	if route53RecordsOwned(expected, owned) {
+		return nil, nil
+	}
+
+	var names []string
+	addName := func(name string) {
+		if !slices.ContainsFunc(names, func(n string) bool { return aws_client.Route53RecordNameEqual(n, name) }) {
+			names = append(names, name)
+		}
+	}
+	for _, record := range expected {
+		addName(aws.ToString(record.rrs.Name))
+	}
+	for _, o := range owned {
+		addName(o.Name)
+	}
+
+	var replaced []route53Types.ResourceRecordSet
+	for _, name := range names {
+		rrsets, err := r.awsClient.ListResourceRecordSetsByName(ctx, hostedZoneId, name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, existing := range rrsets {
+			if existing.Name == nil {
+				continue
+			}
+
+			sameName, sameNameAndType := false, false
+			for _, record := range expected {
+				if aws_client.Route53RecordNameEqual(*existing.Name, *record.rrs.Name) {
+					sameName = true
+					sameNameAndType = sameNameAndType || existing.Type == record.rrs.Type
+				}
+			}
+
+			switch {
+			case sameNameAndType:
+				continue
+			case sameName, isOwnedRoute53Record(existing, owned):
+				replaced = append(replaced, existing)
+			}
+		}
+	}
+
+	return replaced, nil
+}
+
+// route53RecordsOwned returns true if the owned records are exactly the expected records, in which case there are no
+// records to replace
+func route53RecordsOwned(expected []route53Record, owned []avov1alpha2.ResourceRecordStatus) bool {
+	if len(expected) != len(owned) {
+		return false
+	}
+
+	for _, o := range owned {
+		if !slices.ContainsFunc(expected, func(record route53Record) bool {
+			return aws_client.Route53RecordNameEqual(aws.ToString(record.rrs.Name), o.Name) && string(record.rrs.Type) == o.Type
+		}) {
+			return false
+		}
+	}
+
+	return true
 }
 
-// generateExternalNameService generates the expected ExternalName service for a VpcEndpoint CustomResource
-func (r *VpcEndpointReconciler) generateExternalNameService(resource *avov1alpha2.VpcEndpoint) (*corev1.Service, error) {
-	if resource.Status.ResourceRecordSet == "" {
+// generateExternalNameService generates the expected ExternalName service pointing to a Route53 Record created for a
+// VpcEndpoint CustomResource
+func (r *reconcileScope) generateExternalNameService(resource *avov1alpha2.VpcEndpoint, record avov1alpha2.ResourceRecordStatus) (*corev1.Service, error) {
+	if record.Name == "" {
 		// Should only happen when a Route53 Hosted Zone Record has not been created yet
-		return nil, fmt.Errorf("cannot generate ExternalName service for %s/%s: .status.resourceRecordSet is empty", resource.Namespace, resource.Name)
+		return nil, fmt.Errorf("cannot generate ExternalName service for %s/%s: the Route53 Hosted Zone Record for %s has not been created", resource.Namespace, resource.Name, record.Hostname)
 	}
 
 	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      resource.Spec.CustomDns.Route53PrivateHostedZone.Record.ExternalNameService.Name,
+			Name:      record.ExternalNameService,
 			Namespace: resource.Namespace,
 		},
 		Spec: corev1.ServiceSpec{
 			Type: corev1.ServiceTypeExternalName,
-			// resource.Status.ResourceRecordSet is generated in validateR53HostedZoneRecord() and in the format of
-			// ${.spec.customDns.route53PrivateHostedZone.Record.Hostname}.${domain name}
-			ExternalName: resource.Status.ResourceRecordSet,
+			// record.Name is generated in validateR53HostedZoneRecord() and in the format of
+			// ${hostname}.${domain name}
+			ExternalName: record.Name,
 		},
 	}
 
@@ -1007,9 +2457,10 @@ func tagsContains(tags []ec2Types.Tag, tagsToCheck map[string]string) bool {
 	return true
 }
 
-// createMissingPrivateZoneTags compares existing tags to the required set and applies if missing.
-// Sets AWSRoute53TagsCondition to True on success so subsequent reconciles skip the check.
-func (r *VpcEndpointReconciler) createMissingPrivateZoneTags(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+// ensurePrivateZoneTags compares existing tags to the required set, including user tags, and applies them if missing.
+// User tags previously applied by AVO that are no longer expected are removed.
+// Sets AWSRoute53TagsCondition to True on success so subsequent reconciles skip the check, see privateZoneTagsVerified.
+func (r *reconcileScope) ensurePrivateZoneTags(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
 	id := resource.Status.HostedZoneId
 
 	listTagsOut, err := r.awsClient.FetchPrivateZoneTags(ctx, id)
@@ -1017,30 +2468,47 @@ func (r *VpcEndpointReconciler) createMissingPrivateZoneTags(ctx context.Context
 		return fmt.Errorf("failed to list zone's tags %w", err)
 	}
 
-	generatedDefaultTagInput, err := r.awsClient.GenerateDefaultTagsForHostedZoneInput(id, r.clusterInfo.clusterTag)
+	defaultTags, err := util.GenerateR53Tags(r.clusterInfo.clusterTag)
 	if err != nil {
 		return fmt.Errorf("failed to generate hosted zone's default tags %w", err)
 	}
 
+	expectedTags := r.userTags(resource)
+	for _, tag := range defaultTags {
+		expectedTags[*tag.Key] = *tag.Value
+	}
+
 	actualTagsMap := map[string]string{}
 	for _, tag := range listTagsOut.ResourceTagSet.Tags {
 		actualTagsMap[*tag.Key] = *tag.Value
 	}
 
-	for _, tag := range generatedDefaultTagInput.AddTags {
-		v, ok := actualTagsMap[*tag.Key]
-		if !ok || v != *tag.Value {
-			if _, err := r.awsClient.ChangeTagsForResource(ctx, generatedDefaultTagInput); err != nil {
-				return fmt.Errorf("failed tag hosted zone with default tags %w", err)
-			}
+	toUpdate, toRemove := diffTags(actualTagsMap, expectedTags, resource.Status.Tags)
+	if len(toUpdate) > 0 || len(toRemove) > 0 {
+		keys := make([]string, 0, len(toUpdate))
+		for k := range toUpdate {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		addTags := make([]route53Types.Tag, len(keys))
+		for i, k := range keys {
+			addTags[i] = route53Types.Tag{Key: aws.String(k), Value: aws.String(toUpdate[k])}
 		}
+
+		if err := r.awsClient.ChangeHostedZoneTags(ctx, id, addTags, toRemove); err != nil {
+			return fmt.Errorf("failed to update hosted zone tags %w", err)
+		}
+		r.log.V(1).Info("Updated hosted zone tags", "id", id, "updated", keys, "removed", toRemove)
+		r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Updated", "Updated Private Hosted Zone tags: %s", id)
 	}
 
 	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-		Type:    avov1alpha2.AWSRoute53TagsCondition,
-		Status:  metav1.ConditionTrue,
-		Reason:  "TagsVerified",
-		Message: "Route53 hosted zone tags are correct",
+		Type:               avov1alpha2.AWSRoute53TagsCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "TagsVerified",
+		Message:            "Route53 hosted zone tags are correct",
+		ObservedGeneration: resource.Generation,
 	})
 	if err := r.Status().Update(ctx, resource); err != nil {
 		r.log.V(0).Error(err, "failed to update tags condition status")
@@ -1050,6 +2518,17 @@ func (r *VpcEndpointReconciler) createMissingPrivateZoneTags(ctx context.Context
 	return nil
 }
 
+// privateZoneTagsVerified returns true if the hosted zone's tags were verified for the current generation of the
+// VpcEndpoint CR and the user tags haven't changed since, e.g. because the operator's default tags changed
+func (r *reconcileScope) privateZoneTagsVerified(resource *avov1alpha2.VpcEndpoint) bool {
+	cond := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSRoute53TagsCondition)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.ObservedGeneration != resource.Generation {
+		return false
+	}
+
+	return maps.Equal(resource.Status.Tags, r.userTags(resource))
+}
+
 // hostedZoneCacheEntry holds a cached GetHostedZone response with a TTL.
 type hostedZoneCacheEntry struct {
 	response  *route53.GetHostedZoneOutput
@@ -1058,18 +2537,11 @@ type hostedZoneCacheEntry struct {
 
 // hostedZoneCacheTTL controls how long a cached GetHostedZone response is valid.
 // Within a single reconcile (which takes seconds), the cache will always be fresh.
-// Between reconciles, this TTL prevents stale data from persisting across the
-// 15-minute requeue interval.
 const hostedZoneCacheTTL = 2 * time.Minute
 
-var hostedZoneCacheMu sync.Mutex
-
 // getHostedZoneCached returns a cached GetHostedZone response if available and fresh,
 // otherwise fetches from AWS and caches the result.
-func (r *VpcEndpointReconciler) getHostedZoneCached(ctx context.Context, id string) (*route53.GetHostedZoneOutput, error) {
-	hostedZoneCacheMu.Lock()
-	defer hostedZoneCacheMu.Unlock()
-
+func (r *reconcileScope) getHostedZoneCached(ctx context.Context, id string) (*route53.GetHostedZoneOutput, error) {
 	if r.hostedZoneCache == nil {
 		r.hostedZoneCache = make(map[string]*hostedZoneCacheEntry)
 	}
@@ -1096,33 +2568,30 @@ func (r *VpcEndpointReconciler) getHostedZoneCached(ctx context.Context, id stri
 
 // invalidateHostedZoneCache removes a specific zone from the cache, useful after
 // mutations like AssociateVPCWithHostedZone.
-func (r *VpcEndpointReconciler) invalidateHostedZoneCache(id string) {
-	hostedZoneCacheMu.Lock()
-	defer hostedZoneCacheMu.Unlock()
-
-	if r.hostedZoneCache != nil {
-		delete(r.hostedZoneCache, id)
-	}
+func (r *reconcileScope) invalidateHostedZoneCache(id string) {
+	delete(r.hostedZoneCache, id)
 }
 
 // invalidateRoute53RecordCondition resets the AWSRoute53RecordReady condition so
 // that the next reconcile re-creates the DNS record. Called when the VPC endpoint
 // transitions to a non-available state (recreated, rejected, etc.).
-func (r *VpcEndpointReconciler) invalidateRoute53RecordCondition(resource *avov1alpha2.VpcEndpoint) {
+func (r *reconcileScope) invalidateRoute53RecordCondition(resource *avov1alpha2.VpcEndpoint) {
 	if meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSRoute53RecordCondition) {
 		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-			Type:    avov1alpha2.AWSRoute53RecordCondition,
-			Status:  metav1.ConditionFalse,
-			Reason:  "VpcEndpointChanged",
-			Message: "VPC endpoint is no longer available, Route53 record will be re-created",
+			Type:               avov1alpha2.AWSRoute53RecordCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "VpcEndpointChanged",
+			Message:            "VPC endpoint is no longer available, Route53 record will be re-created",
+			ObservedGeneration: resource.Generation,
 		})
 	}
 	if meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSRoute53TagsCondition) {
 		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-			Type:    avov1alpha2.AWSRoute53TagsCondition,
-			Status:  metav1.ConditionFalse,
-			Reason:  "VpcEndpointChanged",
-			Message: "VPC endpoint is no longer available, tags will be re-verified",
+			Type:               avov1alpha2.AWSRoute53TagsCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             "VpcEndpointChanged",
+			Message:            "VPC endpoint is no longer available, tags will be re-verified",
+			ObservedGeneration: resource.Generation,
 		})
 	}
 }
@@ -19,11 +19,17 @@ package vpcendpoint
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 	"github.com/go-logr/logr/testr"
+	configv1 "github.com/openshift/api/config/v1"
 	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
 	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
 	"github.com/openshift/aws-vpce-operator/pkg/testutil"
@@ -31,7 +37,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 )
 
@@ -69,10 +77,12 @@ func TestVpcEndpointReconciler_findOrCreateSecurityGroup(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			r := &VpcEndpointReconciler{
-				Client:    testutil.NewTestMock(t, test.resource).Client,
-				Scheme:    testutil.NewTestMock(t).Client.Scheme(),
-				Recorder:  record.NewFakeRecorder(1),
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:   testutil.NewTestMock(t, test.resource).Client,
+					Scheme:   testutil.NewTestMock(t).Client.Scheme(),
+					Recorder: record.NewFakeRecorder(1),
+				},
 				log:       testr.New(t),
 				awsClient: aws_client.NewMockedAwsClient(),
 			}
@@ -91,13 +101,55 @@ func TestVpcEndpointReconciler_findOrCreateSecurityGroup(t *testing.T) {
 	}
 }
 
-func TestVpcEndpointReconciler_createMissingSecurityGroupTags(t *testing.T) {
+func TestVpcEndpointReconciler_findOrCreateSecurityGroup_recordsCreationTags(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mock",
+			Namespace: "default",
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			Tags: map[string]string{"team": "mock"},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			InfraId: testutil.MockInfrastructureName,
+			VPCId:   aws_client.MockVpcId,
+			Tags:    map[string]string{"previous": "mock"},
+		},
+	}
+
+	client := testutil.NewTestMock(t, resource).Client
+	// A previous attempt created the security group but failed to record it in the status
+	ec2Client := &aws_client.MockedEC2{SecurityGroups: []ec2Types.SecurityGroup{}, SecurityGroupExists: true}
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:   client,
+			Scheme:   client.Scheme(),
+			Recorder: record.NewFakeRecorder(1),
+		},
+		log:         testr.New(t),
+		awsClient:   aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
+		clusterInfo: &clusterInfo{clusterTag: aws_client.MockLegacyClusterTag},
+	}
+
+	_, err := r.findOrCreateSecurityGroup(context.TODO(), resource)
+	assert.Error(t, err)
+
+	// The tags the security group was created with were recorded before creating it, so they can be removed later
+	actual := &avov1alpha2.VpcEndpoint{}
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: "mock", Namespace: "default"}, actual))
+	assert.Equal(t, map[string]string{"previous": "mock", "team": "mock"}, actual.Status.Tags)
+}
+
+func TestVpcEndpointReconciler_ensureSecurityGroupTags(t *testing.T) {
 	tests := []struct {
-		name        string
-		sg          *ec2Types.SecurityGroup
-		clusterInfo *clusterInfo
-		resource    *avov1alpha2.VpcEndpoint
-		expectErr   bool
+		name          string
+		sg            *ec2Types.SecurityGroup
+		clusterInfo   *clusterInfo
+		resource      *avov1alpha2.VpcEndpoint
+		defaultTags   map[string]string
+		expectCreated []string
+		expectDeleted []string
+		expectErr     bool
 	}{
 		{
 			name: "perfect match",
@@ -112,6 +164,10 @@ func TestVpcEndpointReconciler_createMissingSecurityGroupTags(t *testing.T) {
 						Key:   aws.String(aws_client.MockLegacyClusterTag),
 						Value: aws.String("owned"),
 					},
+					{
+						Key:   aws.String(util.RedHatManagedTagKey),
+						Value: aws.String(util.RedHatManagedTagValue),
+					},
 					{
 						Key:   aws.String("Name"),
 						Value: aws.String(fmt.Sprintf("%s-%s-sg", testutil.MockInfrastructureName, "mock1")),
@@ -152,26 +208,78 @@ func TestVpcEndpointReconciler_createMissingSecurityGroupTags(t *testing.T) {
 					InfraId: testutil.MockInfrastructureName,
 				},
 			},
+			expectCreated: []string{"Name", aws_client.MockLegacyClusterTag, util.RedHatManagedTagKey},
+		},
+		{
+			name: "user tags",
+			sg: &ec2Types.SecurityGroup{
+				GroupId: aws.String(aws_client.MockSecurityGroupId),
+				Tags: []ec2Types.Tag{
+					{Key: aws.String(util.OperatorTagKey), Value: aws.String(util.OperatorTagValue)},
+					{Key: aws.String(util.RedHatManagedTagKey), Value: aws.String(util.RedHatManagedTagValue)},
+					{Key: aws.String(aws_client.MockLegacyClusterTag), Value: aws.String("owned")},
+					{Key: aws.String("Name"), Value: aws.String(fmt.Sprintf("%s-%s-sg", testutil.MockInfrastructureName, "mock3"))},
+					{Key: aws.String("cost-center"), Value: aws.String("1234")},
+					{Key: aws.String("team"), Value: aws.String("sre")},
+					{Key: aws.String("unmanaged"), Value: aws.String("true")},
+				},
+			},
+			clusterInfo: &clusterInfo{
+				clusterTag: aws_client.MockLegacyClusterTag,
+			},
+			resource: &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mock3",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Tags: map[string]string{"cost-center": "5678", "Name": "override"},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					InfraId: testutil.MockInfrastructureName,
+					Tags:    map[string]string{"cost-center": "1234", "team": "sre"},
+				},
+			},
+			defaultTags:   map[string]string{"owner": "sre"},
+			expectCreated: []string{"cost-center", "owner"},
+			expectDeleted: []string{"team"},
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			r := &VpcEndpointReconciler{
-				Client:      testutil.NewTestMock(t, test.resource).Client,
-				Scheme:      testutil.NewTestMock(t).Client.Scheme(),
+			ec2Client := &aws_client.MockedEC2{}
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:      testutil.NewTestMock(t, test.resource).Client,
+					Scheme:      testutil.NewTestMock(t).Client.Scheme(),
+					Recorder:    record.NewFakeRecorder(1),
+					DefaultTags: test.defaultTags,
+				},
 				log:         testr.New(t),
-				awsClient:   aws_client.NewMockedAwsClient(),
+				awsClient:   aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
 				clusterInfo: test.clusterInfo,
-				Recorder:    record.NewFakeRecorder(1),
 			}
 
-			err := r.createMissingSecurityGroupTags(context.TODO(), test.sg, test.resource)
+			err := r.ensureSecurityGroupTags(context.TODO(), test.sg, test.resource)
 			if test.expectErr {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
 			}
+
+			var created, deleted []string
+			for _, input := range ec2Client.CreateTagsInputs {
+				for _, tag := range input.Tags {
+					created = append(created, *tag.Key)
+				}
+			}
+			for _, input := range ec2Client.DeleteTagsInputs {
+				for _, tag := range input.Tags {
+					deleted = append(deleted, *tag.Key)
+				}
+			}
+			assert.Equal(t, test.expectCreated, created)
+			assert.Equal(t, test.expectDeleted, deleted)
 		})
 	}
 }
@@ -345,9 +453,11 @@ func TestVpcEndpointReconciler_generateMissingSecurityGroupRules(t *testing.T) {
 			if test.resource != nil {
 				client = testutil.NewTestMock(t, test.resource).Client
 			}
-			r := &VpcEndpointReconciler{
-				Client:      client,
-				Scheme:      client.Scheme(),
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client: client,
+					Scheme: client.Scheme(),
+				},
 				log:         testr.New(t),
 				awsClient:   aws_client.NewMockedAwsClient(),
 				clusterInfo: &clusterInfo{},
@@ -365,6 +475,332 @@ func TestVpcEndpointReconciler_generateMissingSecurityGroupRules(t *testing.T) {
 	}
 }
 
+func TestVpcEndpointReconciler_generateExtraSecurityGroupRules(t *testing.T) {
+	managedTags := []ec2Types.Tag{
+		{
+			Key:   aws.String(util.OperatorTagKey),
+			Value: aws.String(util.OperatorTagValue),
+		},
+	}
+
+	existingRules := []ec2Types.SecurityGroupRule{
+		{
+			SecurityGroupRuleId: aws.String("sgr-expected-cidr"),
+			CidrIpv4:            aws.String("10.0.0.0/16"),
+			FromPort:            aws.Int32(443),
+			ToPort:              aws.Int32(443),
+			IpProtocol:          aws.String("tcp"),
+			IsEgress:            aws.Bool(false),
+			Tags:                managedTags,
+		},
+		{
+			SecurityGroupRuleId: aws.String("sgr-expected-sg"),
+			ReferencedGroupInfo: &ec2Types.ReferencedSecurityGroup{GroupId: aws.String(aws_client.MockSecurityGroupId)},
+			FromPort:            aws.Int32(443),
+			ToPort:              aws.Int32(443),
+			IpProtocol:          aws.String("tcp"),
+			IsEgress:            aws.Bool(false),
+			Tags:                managedTags,
+		},
+		{
+			SecurityGroupRuleId: aws.String("sgr-stale-managed"),
+			CidrIpv4:            aws.String("10.0.0.0/16"),
+			FromPort:            aws.Int32(8443),
+			ToPort:              aws.Int32(8443),
+			IpProtocol:          aws.String("tcp"),
+			IsEgress:            aws.Bool(false),
+			Tags:                managedTags,
+		},
+		{
+			SecurityGroupRuleId: aws.String("sgr-stale-managed-egress"),
+			CidrIpv4:            aws.String("0.0.0.0/0"),
+			FromPort:            aws.Int32(443),
+			ToPort:              aws.Int32(443),
+			IpProtocol:          aws.String("tcp"),
+			IsEgress:            aws.Bool(true),
+			Tags:                managedTags,
+		},
+		{
+			SecurityGroupRuleId: aws.String("sgr-hand-added"),
+			CidrIpv4:            aws.String("192.168.0.0/16"),
+			FromPort:            aws.Int32(22),
+			ToPort:              aws.Int32(22),
+			IpProtocol:          aws.String("tcp"),
+			IsEgress:            aws.Bool(false),
+		},
+	}
+
+	ingressRules := []avov1alpha2.SecurityGroupRule{
+		{
+			FromPort: 443,
+			ToPort:   443,
+			Protocol: "tcp",
+			CidrIp:   "10.0.0.0/16",
+		},
+		{
+			FromPort: 443,
+			ToPort:   443,
+			Protocol: "tcp",
+		},
+	}
+
+	tests := []struct {
+		name            string
+		resource        *avov1alpha2.VpcEndpoint
+		sg              *ec2Types.SecurityGroup
+		expectedIngress []string
+		expectedEgress  []string
+		expectErr       bool
+	}{
+		{
+			name:      "nil",
+			expectErr: true,
+		},
+		{
+			name: "revokes only stale operator-managed rules",
+			resource: &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mock1",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: avov1alpha2.SecurityGroup{
+						IngressRules: ingressRules,
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					InfraId: testutil.MockInfrastructureName,
+				},
+			},
+			sg: &ec2Types.SecurityGroup{
+				GroupId: aws.String(aws_client.MockSecurityGroupId),
+			},
+			expectedIngress: []string{"sgr-stale-managed"},
+			expectedEgress:  []string{"sgr-stale-managed-egress"},
+		},
+		{
+			name: "strict rule management also revokes hand-added rules",
+			resource: &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mock1",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: avov1alpha2.SecurityGroup{
+						IngressRules:         ingressRules,
+						StrictRuleManagement: true,
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					InfraId: testutil.MockInfrastructureName,
+				},
+			},
+			sg: &ec2Types.SecurityGroup{
+				GroupId: aws.String(aws_client.MockSecurityGroupId),
+			},
+			expectedIngress: []string{"sgr-stale-managed", "sgr-hand-added"},
+			expectedEgress:  []string{"sgr-stale-managed-egress"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := testutil.NewTestMock(t).Client
+			if test.resource != nil {
+				client = testutil.NewTestMock(t, test.resource).Client
+			}
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client: client,
+					Scheme: client.Scheme(),
+				},
+				log:         testr.New(t),
+				awsClient:   aws_client.NewAwsClientWithServiceClients(&aws_client.MockedEC2{SecurityGroupRules: existingRules}, &aws_client.MockedRoute53{}),
+				clusterInfo: &clusterInfo{},
+			}
+
+			ingress, egress, err := r.generateExtraSecurityGroupRules(context.TODO(), test.sg, test.resource)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedIngress, ingress)
+				assert.Equal(t, test.expectedEgress, egress)
+			}
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_generateMissingSecurityGroupRules_sources(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mock-sources",
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			SecurityGroup: avov1alpha2.SecurityGroup{
+				IngressRules: []avov1alpha2.SecurityGroupRule{
+					{FromPort: 443, ToPort: 443, Protocol: "tcp", PrefixListId: "pl-12345"},
+					{FromPort: 443, ToPort: 443, Protocol: "tcp", SourceSecurityGroupId: "123456789012/sg-12345"},
+					{FromPort: 443, ToPort: 443, Protocol: "tcp", CidrIpv6: "fd00::/8"},
+					{FromPort: 443, ToPort: 443, Protocol: "tcp", NetworkCidrs: avov1alpha2.NetworkCidrSourceClusterNetwork},
+				},
+			},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			InfraId: testutil.MockInfrastructureName,
+		},
+	}
+	network := &configv1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+		},
+		Status: configv1.NetworkStatus{
+			ClusterNetwork: []configv1.ClusterNetworkEntry{{CIDR: "10.128.0.0/14"}, {CIDR: "fd01::/48"}},
+		},
+	}
+
+	client := testutil.NewTestMock(t, resource, network).Client
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client: client,
+			Scheme: client.Scheme(),
+		},
+		log:         testr.New(t),
+		awsClient:   aws_client.NewMockedAwsClient(),
+		clusterInfo: &clusterInfo{},
+	}
+
+	ingress, egress, err := r.generateMissingSecurityGroupRules(context.TODO(), &ec2Types.SecurityGroup{GroupId: aws.String(aws_client.MockSecurityGroupId)}, resource)
+	assert.NoError(t, err)
+	assert.Empty(t, egress.IpPermissions)
+	if assert.Len(t, ingress.IpPermissions, 5) {
+		assert.Equal(t, "pl-12345", *ingress.IpPermissions[0].PrefixListIds[0].PrefixListId)
+		assert.Equal(t, "sg-12345", *ingress.IpPermissions[1].UserIdGroupPairs[0].GroupId)
+		assert.Equal(t, "123456789012", *ingress.IpPermissions[1].UserIdGroupPairs[0].UserId)
+		assert.Equal(t, "fd00::/8", *ingress.IpPermissions[2].Ipv6Ranges[0].CidrIpv6)
+		assert.Equal(t, "10.128.0.0/14", *ingress.IpPermissions[3].IpRanges[0].CidrIp)
+		assert.Equal(t, "fd01::/48", *ingress.IpPermissions[4].Ipv6Ranges[0].CidrIpv6)
+	}
+}
+
+func TestVpcEndpointReconciler_generateMissingSecurityGroupRules_noNetworkCidrs(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mock-sources",
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			SecurityGroup: avov1alpha2.SecurityGroup{
+				IngressRules: []avov1alpha2.SecurityGroupRule{
+					{FromPort: 443, ToPort: 443, Protocol: "tcp", NetworkCidrs: avov1alpha2.NetworkCidrSourceClusterNetwork},
+				},
+			},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			InfraId: testutil.MockInfrastructureName,
+		},
+	}
+
+	client := testutil.NewTestMock(t, resource, &configv1.Network{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}}).Client
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client: client,
+			Scheme: client.Scheme(),
+		},
+		log:         testr.New(t),
+		awsClient:   aws_client.NewMockedAwsClient(),
+		clusterInfo: &clusterInfo{},
+	}
+
+	// A network without any CIDRs is an error instead of silently producing no rules
+	_, _, err := r.generateMissingSecurityGroupRules(context.TODO(), &ec2Types.SecurityGroup{GroupId: aws.String(aws_client.MockSecurityGroupId)}, resource)
+	assert.Error(t, err)
+	condition := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSSecurityGroupCondition)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, networkCidrsNotFoundReason, condition.Reason)
+	}
+}
+
+func TestResolveSecurityGroupRules(t *testing.T) {
+	sources := &securityGroupRuleSources{
+		sourceSgIds: []*string{aws.String("sg-master"), aws.String("sg-worker")},
+		vpcCidr:     "10.0.0.0/16",
+		networkCidrs: map[avov1alpha2.NetworkCidrSource][]string{
+			avov1alpha2.NetworkCidrSourceMachineNetwork: {"10.0.0.0/17", "fd02::/64"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		avoRule    avov1alpha2.SecurityGroupRule
+		sources    *securityGroupRuleSources
+		useVpcCidr bool
+		expected   []avov1alpha2.SecurityGroupRule
+	}{
+		{
+			name:     "explicit source",
+			avoRule:  avov1alpha2.SecurityGroupRule{FromPort: 443, ToPort: 443, Protocol: "tcp", PrefixListId: "pl-12345"},
+			expected: []avov1alpha2.SecurityGroupRule{{FromPort: 443, ToPort: 443, Protocol: "tcp", PrefixListId: "pl-12345"}},
+		},
+		{
+			name:    "network CIDRs",
+			avoRule: avov1alpha2.SecurityGroupRule{FromPort: 443, ToPort: 443, Protocol: "tcp", NetworkCidrs: avov1alpha2.NetworkCidrSourceMachineNetwork},
+			expected: []avov1alpha2.SecurityGroupRule{
+				{FromPort: 443, ToPort: 443, Protocol: "tcp", CidrIp: "10.0.0.0/17"},
+				{FromPort: 443, ToPort: 443, Protocol: "tcp", CidrIpv6: "fd02::/64"},
+			},
+		},
+		{
+			name:     "unresolved network CIDRs",
+			avoRule:  avov1alpha2.SecurityGroupRule{FromPort: 443, ToPort: 443, Protocol: "tcp", NetworkCidrs: avov1alpha2.NetworkCidrSourceClusterNetwork},
+			expected: nil,
+		},
+		{
+			name:       "VPC CIDR",
+			avoRule:    avov1alpha2.SecurityGroupRule{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+			useVpcCidr: true,
+			expected:   []avov1alpha2.SecurityGroupRule{{FromPort: 443, ToPort: 443, Protocol: "tcp", CidrIp: "10.0.0.0/16"}},
+		},
+		{
+			name:    "VPC IPv6 CIDRs",
+			avoRule: avov1alpha2.SecurityGroupRule{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+			sources: &securityGroupRuleSources{
+				vpcCidr:      "10.0.0.0/16",
+				vpcIpv6Cidrs: []string{"2600:1f18:1234:5600::/56"},
+			},
+			useVpcCidr: true,
+			expected: []avov1alpha2.SecurityGroupRule{
+				{FromPort: 443, ToPort: 443, Protocol: "tcp", CidrIp: "10.0.0.0/16"},
+				{FromPort: 443, ToPort: 443, Protocol: "tcp", CidrIpv6: "2600:1f18:1234:5600::/56"},
+			},
+		},
+		{
+			name:       "VPC IPv6 CIDRs only",
+			avoRule:    avov1alpha2.SecurityGroupRule{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+			sources:    &securityGroupRuleSources{vpcIpv6Cidrs: []string{"2600:1f18:1234:5600::/56"}},
+			useVpcCidr: true,
+			expected:   []avov1alpha2.SecurityGroupRule{{FromPort: 443, ToPort: 443, Protocol: "tcp", CidrIpv6: "2600:1f18:1234:5600::/56"}},
+		},
+		{
+			name:    "cluster security groups",
+			avoRule: avov1alpha2.SecurityGroupRule{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+			expected: []avov1alpha2.SecurityGroupRule{
+				{FromPort: 443, ToPort: 443, Protocol: "tcp", SourceSecurityGroupId: "sg-master"},
+				{FromPort: 443, ToPort: 443, Protocol: "tcp", SourceSecurityGroupId: "sg-worker"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testSources := sources
+			if test.sources != nil {
+				testSources = test.sources
+			}
+			actual := resolveSecurityGroupRules([]avov1alpha2.SecurityGroupRule{test.avoRule}, test.useVpcCidr, testSources)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestAvoAndAwsSecurityGroupRuleCandidate(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -405,6 +841,91 @@ func TestAvoAndAwsSecurityGroupRuleCandidate(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "same IPv6 CIDR",
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort: 443,
+				ToPort:   443,
+				Protocol: "tcp",
+				CidrIpv6: "fd00::/8",
+			},
+			awsRule: ec2Types.SecurityGroupRule{
+				CidrIpv6:   aws.String("fd00::/8"),
+				FromPort:   aws.Int32(443),
+				IpProtocol: aws.String("tcp"),
+				IsEgress:   aws.Bool(false),
+				ToPort:     aws.Int32(443),
+			},
+			expected: true,
+		},
+		{
+			name: "IPv4 CIDR does not match IPv6 CIDR",
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort: 443,
+				ToPort:   443,
+				Protocol: "tcp",
+				CidrIp:   "10.0.0.0/16",
+			},
+			awsRule: ec2Types.SecurityGroupRule{
+				CidrIpv6:   aws.String("fd00::/8"),
+				FromPort:   aws.Int32(443),
+				IpProtocol: aws.String("tcp"),
+				IsEgress:   aws.Bool(false),
+				ToPort:     aws.Int32(443),
+			},
+			expected: false,
+		},
+		{
+			name: "different prefix list",
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort:     443,
+				ToPort:       443,
+				Protocol:     "tcp",
+				PrefixListId: "pl-1",
+			},
+			awsRule: ec2Types.SecurityGroupRule{
+				PrefixListId: aws.String("pl-2"),
+				FromPort:     aws.Int32(443),
+				IpProtocol:   aws.String("tcp"),
+				IsEgress:     aws.Bool(false),
+				ToPort:       aws.Int32(443),
+			},
+			expected: false,
+		},
+		{
+			name: "same source security group in the same account",
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort:              443,
+				ToPort:                443,
+				Protocol:              "tcp",
+				SourceSecurityGroupId: "sg-1",
+			},
+			awsRule: ec2Types.SecurityGroupRule{
+				ReferencedGroupInfo: &ec2Types.ReferencedSecurityGroup{GroupId: aws.String("sg-1"), UserId: aws.String("123456789012")},
+				FromPort:            aws.Int32(443),
+				IpProtocol:          aws.String("tcp"),
+				IsEgress:            aws.Bool(false),
+				ToPort:              aws.Int32(443),
+			},
+			expected: true,
+		},
+		{
+			name: "source security group in another account",
+			avoRule: avov1alpha2.SecurityGroupRule{
+				FromPort:              443,
+				ToPort:                443,
+				Protocol:              "tcp",
+				SourceSecurityGroupId: "210987654321/sg-1",
+			},
+			awsRule: ec2Types.SecurityGroupRule{
+				ReferencedGroupInfo: &ec2Types.ReferencedSecurityGroup{GroupId: aws.String("sg-1"), UserId: aws.String("123456789012")},
+				FromPort:            aws.Int32(443),
+				IpProtocol:          aws.String("tcp"),
+				IsEgress:            aws.Bool(false),
+				ToPort:              aws.Int32(443),
+			},
+			expected: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -453,9 +974,11 @@ func TestVpcEndpointReconciler_findOrCreateVpcEndpoint(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		r := &VpcEndpointReconciler{
-			Client:      testutil.NewTestMock(t, test.resource).Client,
-			Scheme:      testutil.NewTestMock(t).Client.Scheme(),
+		r := &reconcileScope{
+			VpcEndpointReconciler: &VpcEndpointReconciler{
+				Client: testutil.NewTestMock(t, test.resource).Client,
+				Scheme: testutil.NewTestMock(t).Client.Scheme(),
+			},
 			log:         testr.New(t),
 			awsClient:   aws_client.NewMockedAwsClient(),
 			clusterInfo: test.clusterInfo,
@@ -472,6 +995,56 @@ func TestVpcEndpointReconciler_findOrCreateVpcEndpoint(t *testing.T) {
 	}
 }
 
+// TestVpcEndpointReconciler_findOrCreateVpcEndpoint_recreate ensures that a VPC Endpoint rejected again after
+// being recreated and accepted, at the same generation, is recreated with a new client token
+func TestVpcEndpointReconciler_findOrCreateVpcEndpoint_recreate(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "mock", Namespace: "default", UID: "mock-uid", Generation: 1},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			RejectionPolicy: avov1alpha2.RejectionPolicy{Action: avov1alpha2.RejectionPolicyRecreate},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			VPCId:   aws_client.MockVpcId,
+			InfraId: testutil.MockInfrastructureName,
+		},
+	}
+
+	ec2Client := &aws_client.MockedEC2{VpcEndpoints: []ec2Types.VpcEndpoint{}}
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:   testutil.NewTestMock(t, resource).Client,
+			Recorder: record.NewFakeRecorder(10),
+		},
+		log:         testr.New(t),
+		awsClient:   aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
+		clusterInfo: &clusterInfo{clusterTag: aws_client.MockLegacyClusterTag},
+	}
+
+	var tokens []string
+	for i, rejectedAt := range []time.Time{time.Now().Add(-2 * time.Hour), time.Now().Add(-time.Hour)} {
+		// The VPC Endpoint was accepted, which resets the attempts, and then rejected and deleted again
+		resource.Status.RecreateAttempts = 0
+		resource.Status.VPCEndpointId = ""
+		resource.Status.LastRejectionTime = &metav1.Time{Time: rejectedAt}
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:   avov1alpha2.AWSVpcEndpointCondition,
+			Status: metav1.ConditionFalse,
+			Reason: vpcEndpointRejectedReason,
+		})
+
+		_, err := r.findOrCreateVpcEndpoint(context.TODO(), resource)
+		assert.NoError(t, err, i)
+		assert.Equal(t, int32(1), resource.Status.RecreateAttempts, i)
+		if assert.NotNil(t, ec2Client.LastCreateVpcEndpointInput, i) {
+			tokens = append(tokens, aws.ToString(ec2Client.LastCreateVpcEndpointInput.ClientToken))
+		}
+	}
+
+	if assert.Len(t, tokens, 2) {
+		assert.NotEqual(t, tokens[0], tokens[1])
+	}
+}
+
 func TestVpcEndpointReconciler_ensureVpcEndpointSubnets(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -498,8 +1071,9 @@ func TestVpcEndpointReconciler_ensureVpcEndpointSubnets(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			r := VpcEndpointReconciler{
-				log: testr.New(t),
+			r := reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{},
+				log:                   testr.New(t),
 			}
 
 			err := r.ensureVpcEndpointSubnets(context.TODO(), test.vpce, test.resource)
@@ -516,81 +1090,922 @@ func TestVpcEndpointReconciler_ensureVpcEndpointSubnets(t *testing.T) {
 	}
 }
 
-func TestVpcEndpointReconciler_diffVpcEndpointSecurityGroups(t *testing.T) {
-	tests := []struct {
-		name                string
-		resource            *avov1alpha2.VpcEndpoint
+func TestVpcEndpointReconciler_ensureVpcEndpointRouteTables(t *testing.T) {
+	routeTables := []ec2Types.RouteTable{
+		{
+			RouteTableId: aws.String(aws_client.MockRouteTableId),
+			VpcId:        aws.String(aws_client.MockVpcId),
+			Tags:         []ec2Types.Tag{{Key: aws.String(aws_client.MockLegacyClusterTag), Value: aws.String("owned")}},
+		},
+		{
+			RouteTableId: aws.String("rtb-public"),
+			VpcId:        aws.String(aws_client.MockVpcId),
+			Tags: []ec2Types.Tag{
+				{Key: aws.String(aws_client.MockLegacyClusterTag), Value: aws.String("owned")},
+				{Key: aws.String("public"), Value: aws.String("true")},
+			},
+		},
+		{
+			RouteTableId: aws.String("rtb-unmanaged"),
+			VpcId:        aws.String(aws_client.MockVpcId),
+		},
+	}
+
+	tests := []struct {
+		name             string
+		vpce             *ec2Types.VpcEndpoint
+		routeTables      avov1alpha2.RouteTables
+		expectedAdded    []string
+		expectedRemoved  []string
+		expectedStatus   []string
+		expectNoModified bool
+		expectErr        bool
+	}{
+		{
+			name:            "explicit route tables",
+			vpce:            &ec2Types.VpcEndpoint{VpcEndpointId: aws.String(testutil.MockVpcEndpointId), RouteTableIds: []string{"rtb-unmanaged"}},
+			routeTables:     avov1alpha2.RouteTables{Ids: []string{aws_client.MockRouteTableId}},
+			expectedAdded:   []string{aws_client.MockRouteTableId},
+			expectedRemoved: []string{"rtb-unmanaged"},
+			expectedStatus:  []string{aws_client.MockRouteTableId},
+		},
+		{
+			name:           "discovered by cluster tag",
+			vpce:           &ec2Types.VpcEndpoint{VpcEndpointId: aws.String(testutil.MockVpcEndpointId)},
+			expectedAdded:  []string{aws_client.MockRouteTableId, "rtb-public"},
+			expectedStatus: []string{aws_client.MockRouteTableId, "rtb-public"},
+		},
+		{
+			name:             "discovered by cluster tag and tags",
+			vpce:             &ec2Types.VpcEndpoint{VpcEndpointId: aws.String(testutil.MockVpcEndpointId), RouteTableIds: []string{"rtb-public"}},
+			routeTables:      avov1alpha2.RouteTables{Tags: []avov1alpha2.Tag{{Key: "public", Value: "true"}}},
+			expectedStatus:   []string{"rtb-public"},
+			expectNoModified: true,
+		},
+		{
+			name:        "no route tables discovered",
+			vpce:        &ec2Types.VpcEndpoint{VpcEndpointId: aws.String(testutil.MockVpcEndpointId)},
+			routeTables: avov1alpha2.RouteTables{Tags: []avov1alpha2.Tag{{Key: "missing"}}},
+			expectErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{Name: "mock1"},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Type:        avov1alpha2.VpcEndpointTypeGateway,
+					RouteTables: test.routeTables,
+				},
+				Status: avov1alpha2.VpcEndpointStatus{VPCId: aws_client.MockVpcId},
+			}
+			mockEC2 := &aws_client.MockedEC2{RouteTables: routeTables}
+			client := testutil.NewTestMock(t, resource).Client
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:   client,
+					Scheme:   client.Scheme(),
+					Recorder: record.NewFakeRecorder(1),
+				},
+				log:         testr.New(t),
+				awsClient:   aws_client.NewAwsClientWithServiceClients(mockEC2, &aws_client.MockedRoute53{}),
+				clusterInfo: &clusterInfo{clusterTag: aws_client.MockLegacyClusterTag},
+			}
+
+			err := r.ensureVpcEndpointRouteTables(context.TODO(), test.vpce, resource)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, resource.Status.RouteTableIds)
+			if test.expectNoModified {
+				assert.Empty(t, mockEC2.ModifyVpcEndpointInputs)
+				return
+			}
+			assert.Len(t, mockEC2.ModifyVpcEndpointInputs, 1)
+			assert.ElementsMatch(t, test.expectedAdded, mockEC2.ModifyVpcEndpointInputs[0].AddRouteTableIds)
+			assert.ElementsMatch(t, test.expectedRemoved, mockEC2.ModifyVpcEndpointInputs[0].RemoveRouteTableIds)
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_ensureVpcEndpointRoutes(t *testing.T) {
+	defaultRoute := avov1alpha2.GatewayLoadBalancerRoute{RouteTableId: aws_client.MockRouteTableId, DestinationCidrBlock: "0.0.0.0/0"}
+	ipv6Route := avov1alpha2.GatewayLoadBalancerRoute{RouteTableId: aws_client.MockRouteTableId, DestinationIpv6CidrBlock: "::/0"}
+	privateRoute := avov1alpha2.GatewayLoadBalancerRoute{RouteTableId: aws_client.MockRouteTableId, DestinationCidrBlock: "10.1.0.0/16"}
+
+	tests := []struct {
+		name             string
+		routes           []ec2Types.Route
+		specRoutes       []avov1alpha2.GatewayLoadBalancerRoute
+		statusRoutes     []avov1alpha2.GatewayLoadBalancerRoute
+		replacedRoutes   []avov1alpha2.ReplacedRoute
+		expectedCreated  int
+		expectedReplaced int
+		expectedDeleted  int
+		// expectedReplacedRoutes are the original targets expected in the status after reconciling
+		expectedReplacedRoutes []avov1alpha2.ReplacedRoute
+	}{
+		{
+			name:            "missing route is created",
+			specRoutes:      []avov1alpha2.GatewayLoadBalancerRoute{defaultRoute},
+			expectedCreated: 1,
+		},
+		{
+			name: "existing route is unchanged",
+			routes: []ec2Types.Route{
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String(testutil.MockVpcEndpointId)},
+			},
+			specRoutes:   []avov1alpha2.GatewayLoadBalancerRoute{defaultRoute},
+			statusRoutes: []avov1alpha2.GatewayLoadBalancerRoute{defaultRoute},
+		},
+		{
+			name: "route to another target is replaced",
+			routes: []ec2Types.Route{
+				{DestinationIpv6CidrBlock: aws.String("::/0"), GatewayId: aws.String("igw-12345")},
+			},
+			specRoutes:       []avov1alpha2.GatewayLoadBalancerRoute{ipv6Route},
+			expectedReplaced: 1,
+			expectedReplacedRoutes: []avov1alpha2.ReplacedRoute{
+				{Route: ipv6Route, TargetType: aws_client.RouteTargetGateway, TargetId: "igw-12345"},
+			},
+		},
+		{
+			name: "removed replaced route is restored to its original target",
+			routes: []ec2Types.Route{
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String(testutil.MockVpcEndpointId)},
+			},
+			statusRoutes: []avov1alpha2.GatewayLoadBalancerRoute{defaultRoute},
+			replacedRoutes: []avov1alpha2.ReplacedRoute{
+				{Route: defaultRoute, TargetType: aws_client.RouteTargetNatGateway, TargetId: "nat-12345"},
+			},
+			expectedReplaced: 1,
+		},
+		{
+			name: "removed route is deleted",
+			routes: []ec2Types.Route{
+				{DestinationCidrBlock: aws.String("10.1.0.0/16"), GatewayId: aws.String(testutil.MockVpcEndpointId)},
+			},
+			statusRoutes:    []avov1alpha2.GatewayLoadBalancerRoute{privateRoute},
+			expectedDeleted: 1,
+		},
+		{
+			name: "removed route sending traffic elsewhere is kept",
+			routes: []ec2Types.Route{
+				{DestinationCidrBlock: aws.String("10.1.0.0/16"), GatewayId: aws.String("tgw-12345")},
+			},
+			statusRoutes: []avov1alpha2.GatewayLoadBalancerRoute{privateRoute},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{Name: "mock1"},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Type:   avov1alpha2.VpcEndpointTypeGatewayLoadBalancer,
+					Routes: test.specRoutes,
+				},
+				Status: avov1alpha2.VpcEndpointStatus{Routes: test.statusRoutes, ReplacedRoutes: slices.Clone(test.replacedRoutes)},
+			}
+			mockEC2 := &aws_client.MockedEC2{
+				RouteTables: []ec2Types.RouteTable{
+					{RouteTableId: aws.String(aws_client.MockRouteTableId), Routes: test.routes},
+				},
+			}
+			client := testutil.NewTestMock(t, resource).Client
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:   client,
+					Scheme:   client.Scheme(),
+					Recorder: record.NewFakeRecorder(10),
+				},
+				log:       testr.New(t),
+				awsClient: aws_client.NewAwsClientWithServiceClients(mockEC2, &aws_client.MockedRoute53{}),
+			}
+
+			vpce := &ec2Types.VpcEndpoint{VpcEndpointId: aws.String(testutil.MockVpcEndpointId)}
+			assert.NoError(t, r.ensureVpcEndpointRoutes(context.TODO(), vpce, resource))
+			assert.Len(t, mockEC2.CreateRouteInputs, test.expectedCreated)
+			assert.Len(t, mockEC2.ReplaceRouteInputs, test.expectedReplaced)
+			assert.Len(t, mockEC2.DeleteRouteInputs, test.expectedDeleted)
+			assert.Equal(t, test.specRoutes, resource.Status.Routes)
+			assert.Equal(t, test.expectedReplacedRoutes, resource.Status.ReplacedRoutes)
+			if len(test.replacedRoutes) > 0 && assert.Len(t, mockEC2.ReplaceRouteInputs, 1) {
+				assert.Equal(t, test.replacedRoutes[0].TargetId, aws.ToString(mockEC2.ReplaceRouteInputs[0].NatGatewayId))
+				assert.Nil(t, mockEC2.ReplaceRouteInputs[0].VpcEndpointId)
+			}
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_ensureVpcEndpointPolicy(t *testing.T) {
+	const (
+		policy           = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`
+		normalizedPolicy = `{"Statement":[{"Action":"s3:GetObject","Effect":"Allow","Principal":"*","Resource":"*"}],"Version":"2012-10-17"}`
+	)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+		Data:       map[string]string{"policy.json": policy},
+	}
+
+	tests := []struct {
+		name              string
+		policy            *avov1alpha2.Policy
+		actualPolicy      *string
+		policyHash        string
+		expectedModify    *ec2.ModifyVpcEndpointInput
+		expectedHash      string
+		expectedCondition metav1.ConditionStatus
+		expectErr         bool
+	}{
+		{
+			name: "no policy",
+		},
+		{
+			name:       "removed policy is reset",
+			policyHash: util.PolicyDocumentHash(normalizedPolicy),
+			expectedModify: &ec2.ModifyVpcEndpointInput{
+				VpcEndpointId: aws.String(testutil.MockVpcEndpointId),
+				ResetPolicy:   aws.Bool(true),
+			},
+		},
+		{
+			name:         "inline policy is applied",
+			policy:       &avov1alpha2.Policy{Document: policy},
+			actualPolicy: aws.String(`{"Version":"2008-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`),
+			expectedModify: &ec2.ModifyVpcEndpointInput{
+				VpcEndpointId:  aws.String(testutil.MockVpcEndpointId),
+				PolicyDocument: aws.String(normalizedPolicy),
+			},
+			expectedHash:      util.PolicyDocumentHash(normalizedPolicy),
+			expectedCondition: metav1.ConditionTrue,
+		},
+		{
+			name:              "reformatted policy is unchanged",
+			policy:            &avov1alpha2.Policy{Document: policy},
+			actualPolicy:      aws.String(normalizedPolicy),
+			expectedHash:      util.PolicyDocumentHash(normalizedPolicy),
+			expectedCondition: metav1.ConditionTrue,
+		},
+		{
+			name: "ConfigMap policy is applied",
+			policy: &avov1alpha2.Policy{ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "policy"},
+				Key:                  "policy.json",
+			}},
+			expectedModify: &ec2.ModifyVpcEndpointInput{
+				VpcEndpointId:  aws.String(testutil.MockVpcEndpointId),
+				PolicyDocument: aws.String(normalizedPolicy),
+			},
+			expectedHash:      util.PolicyDocumentHash(normalizedPolicy),
+			expectedCondition: metav1.ConditionTrue,
+		},
+		{
+			name: "missing optional ConfigMap",
+			policy: &avov1alpha2.Policy{ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+				Key:                  "policy.json",
+				Optional:             aws.Bool(true),
+			}},
+		},
+		{
+			name: "applied policy is kept when the optional ConfigMap goes missing",
+			policy: &avov1alpha2.Policy{ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+				Key:                  "policy.json",
+				Optional:             aws.Bool(true),
+			}},
+			policyHash:        util.PolicyDocumentHash(normalizedPolicy),
+			expectedHash:      util.PolicyDocumentHash(normalizedPolicy),
+			expectedCondition: metav1.ConditionFalse,
+		},
+		{
+			name: "applied policy is kept when the optional key goes missing",
+			policy: &avov1alpha2.Policy{ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "policy"},
+				Key:                  "missing.json",
+				Optional:             aws.Bool(true),
+			}},
+			policyHash:        util.PolicyDocumentHash(normalizedPolicy),
+			expectedHash:      util.PolicyDocumentHash(normalizedPolicy),
+			expectedCondition: metav1.ConditionFalse,
+		},
+		{
+			name: "missing ConfigMap key",
+			policy: &avov1alpha2.Policy{ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "policy"},
+				Key:                  "missing.json",
+			}},
+			expectErr: true,
+		},
+		{
+			name:      "invalid policy",
+			policy:    &avov1alpha2.Policy{Document: `{"Version":`},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{Name: "mock1", Namespace: "default"},
+				Spec:       avov1alpha2.VpcEndpointSpec{Policy: test.policy},
+				Status:     avov1alpha2.VpcEndpointStatus{PolicyHash: test.policyHash},
+			}
+			mockEC2 := &aws_client.MockedEC2{}
+			client := testutil.NewTestMock(t, resource, configMap).Client
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:    client,
+					APIReader: client,
+					Scheme:    client.Scheme(),
+					Recorder:  record.NewFakeRecorder(1),
+				},
+				log:       testr.New(t),
+				awsClient: aws_client.NewAwsClientWithServiceClients(mockEC2, &aws_client.MockedRoute53{}),
+			}
+
+			vpce := &ec2Types.VpcEndpoint{VpcEndpointId: aws.String(testutil.MockVpcEndpointId), PolicyDocument: test.actualPolicy}
+			err := r.ensureVpcEndpointPolicy(context.TODO(), vpce, resource)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			if test.expectedModify == nil {
+				assert.Empty(t, mockEC2.ModifyVpcEndpointInputs)
+			} else {
+				assert.Equal(t, []*ec2.ModifyVpcEndpointInput{test.expectedModify}, mockEC2.ModifyVpcEndpointInputs)
+			}
+			assert.Equal(t, test.expectedHash, resource.Status.PolicyHash)
+			condition := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSVpcEndpointPolicyCondition)
+			if test.expectedCondition == "" {
+				assert.Nil(t, condition)
+			} else if assert.NotNil(t, condition) {
+				assert.Equal(t, test.expectedCondition, condition.Status)
+			}
+		})
+	}
+}
+
+func TestSubnetSupportsIpAddressType(t *testing.T) {
+	ipv4Subnet := ec2Types.Subnet{CidrBlock: aws.String("10.0.0.0/24")}
+	dualStackSubnet := ec2Types.Subnet{
+		CidrBlock: aws.String("10.0.0.0/24"),
+		Ipv6CidrBlockAssociationSet: []ec2Types.SubnetIpv6CidrBlockAssociation{
+			{
+				Ipv6CidrBlock:      aws.String("2600:1f18:1234:5601::/64"),
+				Ipv6CidrBlockState: &ec2Types.SubnetCidrBlockState{State: ec2Types.SubnetCidrBlockStateCodeAssociated},
+			},
+		},
+	}
+	disassociatedSubnet := ec2Types.Subnet{
+		CidrBlock: aws.String("10.0.0.0/24"),
+		Ipv6CidrBlockAssociationSet: []ec2Types.SubnetIpv6CidrBlockAssociation{
+			{
+				Ipv6CidrBlock:      aws.String("2600:1f18:1234:5601::/64"),
+				Ipv6CidrBlockState: &ec2Types.SubnetCidrBlockState{State: ec2Types.SubnetCidrBlockStateCodeDisassociated},
+			},
+		},
+	}
+	ipv6Subnet := dualStackSubnet
+	ipv6Subnet.CidrBlock = nil
+	ipv6Subnet.Ipv6Native = aws.Bool(true)
+
+	tests := []struct {
+		name          string
+		subnet        ec2Types.Subnet
+		ipAddressType avov1alpha2.IpAddressType
+		expected      bool
+	}{
+		{name: "ipv4 subnet for ipv4", subnet: ipv4Subnet, ipAddressType: avov1alpha2.IpAddressTypeIpv4, expected: true},
+		{name: "ipv4 subnet for dualstack", subnet: ipv4Subnet, ipAddressType: avov1alpha2.IpAddressTypeDualstack, expected: false},
+		{name: "ipv4 subnet for ipv6", subnet: ipv4Subnet, ipAddressType: avov1alpha2.IpAddressTypeIpv6, expected: false},
+		{name: "dualstack subnet for ipv4", subnet: dualStackSubnet, ipAddressType: avov1alpha2.IpAddressTypeIpv4, expected: true},
+		{name: "dualstack subnet for dualstack", subnet: dualStackSubnet, ipAddressType: avov1alpha2.IpAddressTypeDualstack, expected: true},
+		{name: "dualstack subnet for ipv6", subnet: dualStackSubnet, ipAddressType: avov1alpha2.IpAddressTypeIpv6, expected: false},
+		{name: "disassociated IPv6 CIDR for dualstack", subnet: disassociatedSubnet, ipAddressType: avov1alpha2.IpAddressTypeDualstack, expected: false},
+		{name: "ipv6 subnet for ipv4", subnet: ipv6Subnet, ipAddressType: avov1alpha2.IpAddressTypeIpv4, expected: false},
+		{name: "ipv6 subnet for dualstack", subnet: ipv6Subnet, ipAddressType: avov1alpha2.IpAddressTypeDualstack, expected: false},
+		{name: "ipv6 subnet for ipv6", subnet: ipv6Subnet, ipAddressType: avov1alpha2.IpAddressTypeIpv6, expected: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, subnetSupportsIpAddressType(test.subnet, test.ipAddressType))
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_ensureVpcEndpointIpAddressType(t *testing.T) {
+	tests := []struct {
+		name     string
+		vpce     *ec2Types.VpcEndpoint
+		spec     avov1alpha2.VpcEndpointSpec
+		expected []*ec2.ModifyVpcEndpointInput
+	}{
+		{
+			name: "ipv4 by default",
+			vpce: &ec2Types.VpcEndpoint{VpcEndpointId: aws.String(testutil.MockVpcEndpointId)},
+		},
+		{
+			name: "unchanged",
+			vpce: &ec2Types.VpcEndpoint{
+				VpcEndpointId: aws.String(testutil.MockVpcEndpointId),
+				IpAddressType: ec2Types.IpAddressTypeDualstack,
+				DnsOptions:    &ec2Types.DnsOptions{DnsRecordIpType: ec2Types.DnsRecordIpTypeIpv4},
+			},
+			spec: avov1alpha2.VpcEndpointSpec{IpAddressType: avov1alpha2.IpAddressTypeDualstack, DnsRecordIpType: avov1alpha2.DnsRecordIpTypeIpv4},
+		},
+		{
+			name: "ipv4 to dualstack",
+			vpce: &ec2Types.VpcEndpoint{
+				VpcEndpointId: aws.String(testutil.MockVpcEndpointId),
+				IpAddressType: ec2Types.IpAddressTypeIpv4,
+				DnsOptions:    &ec2Types.DnsOptions{DnsRecordIpType: ec2Types.DnsRecordIpTypeIpv4},
+			},
+			spec: avov1alpha2.VpcEndpointSpec{IpAddressType: avov1alpha2.IpAddressTypeDualstack},
+			expected: []*ec2.ModifyVpcEndpointInput{
+				{
+					VpcEndpointId: aws.String(testutil.MockVpcEndpointId),
+					IpAddressType: ec2Types.IpAddressTypeDualstack,
+					DnsOptions:    &ec2Types.DnsOptionsSpecification{DnsRecordIpType: ec2Types.DnsRecordIpTypeDualstack},
+				},
+			},
+		},
+		{
+			name: "DNS record IP type only",
+			vpce: &ec2Types.VpcEndpoint{
+				VpcEndpointId: aws.String(testutil.MockVpcEndpointId),
+				IpAddressType: ec2Types.IpAddressTypeDualstack,
+				DnsOptions:    &ec2Types.DnsOptions{DnsRecordIpType: ec2Types.DnsRecordIpTypeDualstack},
+			},
+			spec: avov1alpha2.VpcEndpointSpec{IpAddressType: avov1alpha2.IpAddressTypeDualstack, DnsRecordIpType: avov1alpha2.DnsRecordIpTypeServiceDefined},
+			expected: []*ec2.ModifyVpcEndpointInput{
+				{
+					VpcEndpointId: aws.String(testutil.MockVpcEndpointId),
+					IpAddressType: ec2Types.IpAddressTypeDualstack,
+					DnsOptions:    &ec2Types.DnsOptionsSpecification{DnsRecordIpType: ec2Types.DnsRecordIpTypeServiceDefined},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockEC2 := &aws_client.MockedEC2{}
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Recorder: record.NewFakeRecorder(1),
+				},
+				log:       testr.New(t),
+				awsClient: aws_client.NewAwsClientWithServiceClients(mockEC2, &aws_client.MockedRoute53{}),
+			}
+
+			err := r.ensureVpcEndpointIpAddressType(context.TODO(), test.vpce, &avov1alpha2.VpcEndpoint{Spec: test.spec})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, mockEC2.ModifyVpcEndpointInputs)
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_diffVpcEndpointSecurityGroups(t *testing.T) {
+	tests := []struct {
+		name                string
+		resource            *avov1alpha2.VpcEndpoint
 		vpce                *ec2Types.VpcEndpoint
 		expectedNumToAdd    int
 		expectedNumToRemove int
 	}{
 		{
-			name: "exact match",
+			name: "exact match",
+			resource: &avov1alpha2.VpcEndpoint{
+				Status: avov1alpha2.VpcEndpointStatus{
+					SecurityGroupId: aws_client.MockSecurityGroupId,
+				},
+			},
+			vpce: &ec2Types.VpcEndpoint{
+				Groups: []ec2Types.SecurityGroupIdentifier{
+					{
+						GroupId: aws.String(aws_client.MockSecurityGroupId),
+					},
+				},
+			},
+			expectedNumToAdd:    0,
+			expectedNumToRemove: 0,
+		},
+		{
+			name: "need to add and remove",
+			resource: &avov1alpha2.VpcEndpoint{
+				Status: avov1alpha2.VpcEndpointStatus{
+					SecurityGroupId: aws_client.MockSecurityGroupId,
+				},
+			},
+			vpce: &ec2Types.VpcEndpoint{
+				Groups: []ec2Types.SecurityGroupIdentifier{
+					{
+						GroupId: aws.String("sg-extra-to-remove"),
+					},
+				},
+			},
+			expectedNumToAdd:    1,
+			expectedNumToRemove: 1,
+		},
+		{
+			name: "user security groups",
+			resource: &avov1alpha2.VpcEndpoint{
+				Status: avov1alpha2.VpcEndpointStatus{
+					SecurityGroupId:      aws_client.MockSecurityGroupId,
+					UserSecurityGroupIds: []string{"sg-user1", "sg-user2"},
+				},
+			},
+			vpce: &ec2Types.VpcEndpoint{
+				Groups: []ec2Types.SecurityGroupIdentifier{
+					{
+						GroupId: aws.String(aws_client.MockSecurityGroupId),
+					},
+					{
+						GroupId: aws.String("sg-user1"),
+					},
+				},
+			},
+			expectedNumToAdd:    1,
+			expectedNumToRemove: 0,
+		},
+		{
+			name: "managed security group disabled",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: avov1alpha2.SecurityGroup{
+						ManagedSecurityGroup: avov1alpha2.ManagedSecurityGroupDisabled,
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					SecurityGroupId:      aws_client.MockSecurityGroupId,
+					UserSecurityGroupIds: []string{"sg-user1"},
+				},
+			},
+			vpce: &ec2Types.VpcEndpoint{
+				Groups: []ec2Types.SecurityGroupIdentifier{
+					{
+						GroupId: aws.String(aws_client.MockSecurityGroupId),
+					},
+				},
+			},
+			expectedNumToAdd:    1,
+			expectedNumToRemove: 1,
+		},
+		{
+			name: "no expected security groups yet",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: avov1alpha2.SecurityGroup{
+						ManagedSecurityGroup: avov1alpha2.ManagedSecurityGroupDisabled,
+					},
+				},
+			},
+			vpce: &ec2Types.VpcEndpoint{
+				Groups: []ec2Types.SecurityGroupIdentifier{
+					{
+						GroupId: aws.String("sg-default"),
+					},
+				},
+			},
+			expectedNumToAdd:    0,
+			expectedNumToRemove: 0,
+		},
+	}
+
+	for _, test := range tests {
+		r := &reconcileScope{
+			VpcEndpointReconciler: &VpcEndpointReconciler{
+				Client: nil,
+				Scheme: nil,
+			},
+			log:         testr.New(t),
+			awsClient:   nil,
+			clusterInfo: nil,
+		}
+		t.Run(test.name, func(t *testing.T) {
+			actualToAdd, actualToRemove := r.diffVpcEndpointSecurityGroups(test.vpce, test.resource)
+
+			assert.Equalf(t, test.expectedNumToAdd, len(actualToAdd), "expected to add %d, got %d", test.expectedNumToAdd, len(actualToAdd))
+			assert.Equalf(t, test.expectedNumToRemove, len(actualToRemove), "expected to remove %d, got %d", test.expectedNumToRemove, len(actualToRemove))
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_findUserSecurityGroups(t *testing.T) {
+	ec2Client := &aws_client.MockedEC2{
+		SecurityGroups: []ec2Types.SecurityGroup{
+			{
+				GroupId: aws.String("sg-tagged"),
+				VpcId:   aws.String(aws_client.MockVpcId),
+				Tags:    []ec2Types.Tag{{Key: aws.String("team"), Value: aws.String("network")}},
+			},
+			{
+				GroupId: aws.String(aws_client.MockSecurityGroupId),
+				VpcId:   aws.String(aws_client.MockVpcId),
+				Tags:    []ec2Types.Tag{{Key: aws.String("team"), Value: aws.String("network")}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		resource  *avov1alpha2.VpcEndpoint
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "none",
+			resource: &avov1alpha2.VpcEndpoint{},
+			expected: nil,
+		},
+		{
+			name: "ids and tags",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: avov1alpha2.SecurityGroup{
+						Ids:  []string{"sg-tagged", "sg-id"},
+						Tags: []avov1alpha2.Tag{{Key: "team", Value: "network"}},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					SecurityGroupId: aws_client.MockSecurityGroupId,
+					VPCId:           aws_client.MockVpcId,
+				},
+			},
+			expected: []string{"sg-id", "sg-tagged"},
+		},
+		{
+			name: "security group in another VPC",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: avov1alpha2.SecurityGroup{
+						Ids: []string{"sg-id"},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCId: "vpc-other",
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "no security groups with tags",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					SecurityGroup: avov1alpha2.SecurityGroup{
+						Tags: []avov1alpha2.Tag{{Key: "team", Value: "other"}},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCId: aws_client.MockVpcId,
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{},
+				log:                   testr.New(t),
+				awsClient:             aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
+			}
+
+			actual, err := r.findUserSecurityGroups(context.TODO(), test.resource)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_generateRoute53Records(t *testing.T) {
+	tests := []struct {
+		name      string
+		resource  *avov1alpha2.VpcEndpoint
+		expected  []route53Types.ResourceRecordSet
+		expectErr bool
+	}{
+		{
+			name: "CNAME by default",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					CustomDns: avov1alpha2.CustomDns{
+						Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+							Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "test"},
+						},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCEndpointId: testutil.MockVpcEndpointId,
+				},
+			},
+			expected: []route53Types.ResourceRecordSet{
+				{
+					Name:            aws.String("test.example.com"),
+					Type:            route53Types.RRTypeCname,
+					TTL:             aws.Int64(300),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(testutil.MockVpcEndpointDnsName)}},
+				},
+			},
+		},
+		{
+			name: "multiple records of each type",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					CustomDns: avov1alpha2.CustomDns{
+						Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+							Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "api", Type: avov1alpha2.Route53RecordTypeCNAME, TTL: 60},
+							Records: []avov1alpha2.Route53HostedZoneRecord{
+								{Hostname: "*.apps", Type: avov1alpha2.Route53RecordTypeAliasAAAA, TTL: 60},
+								{Hostname: "ips", Type: avov1alpha2.Route53RecordTypeA},
+							},
+						},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCEndpointId: testutil.MockVpcEndpointId,
+				},
+			},
+			expected: []route53Types.ResourceRecordSet{
+				{
+					Name:            aws.String("api.example.com"),
+					Type:            route53Types.RRTypeCname,
+					TTL:             aws.Int64(60),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(testutil.MockVpcEndpointDnsName)}},
+				},
+				{
+					Name: aws.String("*.apps.example.com"),
+					Type: route53Types.RRTypeAaaa,
+					AliasTarget: &route53Types.AliasTarget{
+						DNSName:      aws.String(testutil.MockVpcEndpointDnsName),
+						HostedZoneId: aws.String(aws_client.MockVpcEndpointHostedZone),
+					},
+				},
+				{
+					Name:            aws.String("ips.example.com"),
+					Type:            route53Types.RRTypeA,
+					TTL:             aws.Int64(300),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(aws_client.MockNetworkInterfaceIp)}},
+				},
+			},
+		},
+		{
+			name: "zonal records",
+			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					CustomDns: avov1alpha2.CustomDns{
+						Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+							Record: avov1alpha2.Route53HostedZoneRecord{
+								Hostname: "api",
+								Zonal:    &avov1alpha2.ZonalRecords{},
+							},
+							Records: []avov1alpha2.Route53HostedZoneRecord{
+								{
+									Hostname: "ips",
+									Type:     avov1alpha2.Route53RecordTypeA,
+									Zonal:    &avov1alpha2.ZonalRecords{Label: avov1alpha2.ZonalRecordLabelAvailabilityZoneId},
+								},
+							},
+						},
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCEndpointId: testutil.MockVpcEndpointId,
+				},
+			},
+			expected: []route53Types.ResourceRecordSet{
+				{
+					Name:            aws.String("api.example.com"),
+					Type:            route53Types.RRTypeCname,
+					TTL:             aws.Int64(300),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(testutil.MockVpcEndpointDnsName)}},
+				},
+				{
+					Name:            aws.String(fmt.Sprintf("%s.api.example.com", aws_client.MockAvailabilityZone)),
+					Type:            route53Types.RRTypeCname,
+					TTL:             aws.Int64(300),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(aws_client.MockVpcEndpointZonalDns)}},
+				},
+				{
+					Name:            aws.String("ips.example.com"),
+					Type:            route53Types.RRTypeA,
+					TTL:             aws.Int64(300),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(aws_client.MockNetworkInterfaceIp)}},
+				},
+				{
+					Name:            aws.String(fmt.Sprintf("%s.ips.example.com", aws_client.MockAvailabilityZoneId)),
+					Type:            route53Types.RRTypeA,
+					TTL:             aws.Int64(300),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(aws_client.MockNetworkInterfaceIp)}},
+				},
+			},
+		},
+		{
+			name: "dualstack records",
 			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					IpAddressType: avov1alpha2.IpAddressTypeDualstack,
+					CustomDns: avov1alpha2.CustomDns{
+						Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+							Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "ips", Type: avov1alpha2.Route53RecordTypeA},
+							Records: []avov1alpha2.Route53HostedZoneRecord{
+								{Hostname: "alias", Type: avov1alpha2.Route53RecordTypeAliasA},
+								{Hostname: "cname", Type: avov1alpha2.Route53RecordTypeCNAME},
+							},
+						},
+					},
+				},
 				Status: avov1alpha2.VpcEndpointStatus{
-					SecurityGroupId: aws_client.MockSecurityGroupId,
+					VPCEndpointId: testutil.MockVpcEndpointId,
 				},
 			},
-			vpce: &ec2Types.VpcEndpoint{
-				Groups: []ec2Types.SecurityGroupIdentifier{
-					{
-						GroupId: aws.String(aws_client.MockSecurityGroupId),
+			expected: []route53Types.ResourceRecordSet{
+				{
+					Name:            aws.String("ips.example.com"),
+					Type:            route53Types.RRTypeA,
+					TTL:             aws.Int64(300),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(aws_client.MockNetworkInterfaceIp)}},
+				},
+				{
+					Name:            aws.String("ips.example.com"),
+					Type:            route53Types.RRTypeAaaa,
+					TTL:             aws.Int64(300),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(aws_client.MockNetworkInterfaceIpv6)}},
+				},
+				{
+					Name: aws.String("alias.example.com"),
+					Type: route53Types.RRTypeA,
+					AliasTarget: &route53Types.AliasTarget{
+						DNSName:      aws.String(testutil.MockVpcEndpointDnsName),
+						HostedZoneId: aws.String(aws_client.MockVpcEndpointHostedZone),
+					},
+				},
+				{
+					Name: aws.String("alias.example.com"),
+					Type: route53Types.RRTypeAaaa,
+					AliasTarget: &route53Types.AliasTarget{
+						DNSName:      aws.String(testutil.MockVpcEndpointDnsName),
+						HostedZoneId: aws.String(aws_client.MockVpcEndpointHostedZone),
 					},
 				},
+				{
+					Name:            aws.String("cname.example.com"),
+					Type:            route53Types.RRTypeCname,
+					TTL:             aws.Int64(300),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(testutil.MockVpcEndpointDnsName)}},
+				},
 			},
-			expectedNumToAdd:    0,
-			expectedNumToRemove: 0,
 		},
 		{
-			name: "need to add and remove",
+			name: "ipv6 records",
 			resource: &avov1alpha2.VpcEndpoint{
+				Spec: avov1alpha2.VpcEndpointSpec{
+					IpAddressType: avov1alpha2.IpAddressTypeIpv6,
+					CustomDns: avov1alpha2.CustomDns{
+						Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+							Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "ips", Type: avov1alpha2.Route53RecordTypeA},
+						},
+					},
+				},
 				Status: avov1alpha2.VpcEndpointStatus{
-					SecurityGroupId: aws_client.MockSecurityGroupId,
+					VPCEndpointId: testutil.MockVpcEndpointId,
 				},
 			},
-			vpce: &ec2Types.VpcEndpoint{
-				Groups: []ec2Types.SecurityGroupIdentifier{
-					{
-						GroupId: aws.String("sg-extra-to-remove"),
-					},
+			expected: []route53Types.ResourceRecordSet{
+				{
+					Name:            aws.String("ips.example.com"),
+					Type:            route53Types.RRTypeAaaa,
+					TTL:             aws.Int64(300),
+					ResourceRecords: []route53Types.ResourceRecord{{Value: aws.String(aws_client.MockNetworkInterfaceIpv6)}},
 				},
 			},
-			expectedNumToAdd:    1,
-			expectedNumToRemove: 1,
 		},
-	}
-
-	for _, test := range tests {
-		r := &VpcEndpointReconciler{
-			Client:      nil,
-			Scheme:      nil,
-			log:         testr.New(t),
-			awsClient:   nil,
-			clusterInfo: nil,
-		}
-		t.Run(test.name, func(t *testing.T) {
-			actualToAdd, actualToRemove := r.diffVpcEndpointSecurityGroups(test.vpce, test.resource)
-
-			assert.Equalf(t, test.expectedNumToAdd, len(actualToAdd), "expected to add %d, got %d", test.expectedNumToAdd, len(actualToAdd))
-			assert.Equalf(t, test.expectedNumToRemove, len(actualToRemove), "expected to remove %d, got %d", test.expectedNumToRemove, len(actualToRemove))
-		})
-	}
-}
-
-func TestVpcEndpointReconciler_generateRoute53Record(t *testing.T) {
-	tests := []struct {
-		resource  *avov1alpha2.VpcEndpoint
-		expectErr bool
-	}{
 		{
+			name: "no records",
 			resource: &avov1alpha2.VpcEndpoint{
 				Status: avov1alpha2.VpcEndpointStatus{
 					VPCEndpointId: testutil.MockVpcEndpointId,
 				},
 			},
-			expectErr: false,
+			expected: []route53Types.ResourceRecordSet{},
 		},
 		{
+			name:      "missing VPC Endpoint ID",
 			resource:  &avov1alpha2.VpcEndpoint{},
 			expectErr: true,
 		},
@@ -601,30 +2016,226 @@ func TestVpcEndpointReconciler_generateRoute53Record(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	r := &VpcEndpointReconciler{
-		Client:      mock.Client,
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client: mock.Client,
+			Scheme: mock.Client.Scheme(),
+		},
 		log:         testr.New(t),
-		Scheme:      mock.Client.Scheme(),
 		awsClient:   aws_client.NewMockedAwsClientWithSubnets(),
 		clusterInfo: nil,
 	}
 
 	for _, test := range tests {
-		_, err = r.generateRoute53Record(context.TODO(), test.resource)
-		if test.expectErr {
-			assert.Error(t, err)
-		} else {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := r.generateRoute53Records(context.TODO(), test.resource, "example.com.")
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				rrsets := make([]route53Types.ResourceRecordSet, len(actual))
+				for i, record := range actual {
+					rrsets[i] = record.rrs
+				}
+				assert.Equal(t, test.expected, rrsets)
+			}
+		})
+	}
+}
+
+func TestDualStackRoute53Records(t *testing.T) {
+	record := avov1alpha2.Route53HostedZoneRecord{
+		Hostname:            "test",
+		Type:                avov1alpha2.Route53RecordTypeA,
+		ExternalNameService: avov1alpha2.ExternalNameService{Name: "test"},
+		Zonal:               &avov1alpha2.ZonalRecords{Label: avov1alpha2.ZonalRecordLabelAvailabilityZoneId, ExternalNameServices: true},
+	}
+
+	assert.Equal(t, []avov1alpha2.Route53HostedZoneRecord{record}, dualStackRoute53Records(record, avov1alpha2.DnsRecordIpTypeIpv4))
+
+	ipv6Record := record
+	ipv6Record.Type = avov1alpha2.Route53RecordTypeAAAA
+	assert.Equal(t, []avov1alpha2.Route53HostedZoneRecord{ipv6Record}, dualStackRoute53Records(record, avov1alpha2.DnsRecordIpTypeIpv6))
+
+	// Only the configured record gets ExternalName services
+	assert.Equal(t, []avov1alpha2.Route53HostedZoneRecord{
+		record,
+		{
+			Hostname: "test",
+			Type:     avov1alpha2.Route53RecordTypeAAAA,
+			Zonal:    &avov1alpha2.ZonalRecords{Label: avov1alpha2.ZonalRecordLabelAvailabilityZoneId},
+		},
+	}, dualStackRoute53Records(record, avov1alpha2.DnsRecordIpTypeDualstack))
+	assert.True(t, record.Zonal.ExternalNameServices)
+
+	cname := avov1alpha2.Route53HostedZoneRecord{Hostname: "test", Type: avov1alpha2.Route53RecordTypeCNAME}
+	assert.Equal(t, []avov1alpha2.Route53HostedZoneRecord{cname}, dualStackRoute53Records(cname, avov1alpha2.DnsRecordIpTypeDualstack))
+}
+
+func TestRoute53RecordIpType(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     avov1alpha2.VpcEndpointSpec
+		expected avov1alpha2.DnsRecordIpType
+	}{
+		{
+			name:     "default",
+			expected: avov1alpha2.DnsRecordIpTypeIpv4,
+		},
+		{
+			name:     "follows the IP address type",
+			spec:     avov1alpha2.VpcEndpointSpec{IpAddressType: avov1alpha2.IpAddressTypeDualstack},
+			expected: avov1alpha2.DnsRecordIpTypeDualstack,
+		},
+		{
+			name:     "DNS record IP type",
+			spec:     avov1alpha2.VpcEndpointSpec{IpAddressType: avov1alpha2.IpAddressTypeDualstack, DnsRecordIpType: avov1alpha2.DnsRecordIpTypeIpv4},
+			expected: avov1alpha2.DnsRecordIpTypeIpv4,
+		},
+		{
+			name:     "service-defined",
+			spec:     avov1alpha2.VpcEndpointSpec{IpAddressType: avov1alpha2.IpAddressTypeIpv6, DnsRecordIpType: avov1alpha2.DnsRecordIpTypeServiceDefined},
+			expected: avov1alpha2.DnsRecordIpTypeIpv6,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, route53RecordIpType(&avov1alpha2.VpcEndpoint{Spec: test.spec}))
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_generateRoute53Records_ZonalStatus(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		Spec: avov1alpha2.VpcEndpointSpec{
+			CustomDns: avov1alpha2.CustomDns{
+				Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+					Record: avov1alpha2.Route53HostedZoneRecord{
+						Hostname:            "api",
+						ExternalNameService: avov1alpha2.ExternalNameService{Name: "api"},
+						Zonal:               &avov1alpha2.ZonalRecords{ExternalNameServices: true},
+					},
+				},
+			},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			VPCEndpointId: testutil.MockVpcEndpointId,
+		},
+	}
+
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{},
+		log:                   testr.New(t),
+		awsClient:             aws_client.NewMockedAwsClientWithSubnets(),
+	}
+
+	actual, err := r.generateRoute53Records(context.TODO(), resource, "example.com")
+	assert.NoError(t, err)
+	statuses := make([]avov1alpha2.ResourceRecordStatus, len(actual))
+	for i, record := range actual {
+		statuses[i] = record.status
+	}
+	assert.Equal(t, []avov1alpha2.ResourceRecordStatus{
+		{
+			Hostname:            "api",
+			Name:                "api.example.com",
+			Type:                "CNAME",
+			ExternalNameService: "api",
+		},
+		{
+			Hostname:            "us-east-1a.api",
+			AvailabilityZone:    aws_client.MockAvailabilityZone,
+			Name:                "us-east-1a.api.example.com",
+			Type:                "CNAME",
+			ExternalNameService: "api-us-east-1a",
+		},
+	}, statuses)
+}
+
+func TestVpcEndpointReconciler_findReplacedRoute53Records(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []route53Record
+		owned    []avov1alpha2.ResourceRecordStatus
+		replaced int
+		noLookup bool
+	}{
+		{
+			name:     "same name and type",
+			expected: []route53Record{{rrs: route53Types.ResourceRecordSet{Name: aws.String("mock"), Type: route53Types.RRTypeCname}}},
+			replaced: 0,
+		},
+		{
+			name:     "same name and different type",
+			expected: []route53Record{{rrs: route53Types.ResourceRecordSet{Name: aws.String("mock"), Type: route53Types.RRTypeA}}},
+			replaced: 1,
+		},
+		{
+			name:     "owned record no longer expected",
+			expected: []route53Record{},
+			owned:    []avov1alpha2.ResourceRecordStatus{{Hostname: "mock", Name: "mock", Type: "CNAME"}},
+			replaced: 1,
+		},
+		{
+			name:     "unowned record",
+			expected: []route53Record{},
+			owned:    []avov1alpha2.ResourceRecordStatus{{Hostname: "mock", Name: "mock", Type: "A"}},
+			replaced: 0,
+		},
+		{
+			name:     "owned records are expected",
+			expected: []route53Record{{rrs: route53Types.ResourceRecordSet{Name: aws.String("mock"), Type: route53Types.RRTypeA}}},
+			owned:    []avov1alpha2.ResourceRecordStatus{{Hostname: "mock", Name: "mock.", Type: "A"}},
+			replaced: 0,
+			noLookup: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The mocked hosted zone contains a "mock" CNAME record
+			route53Client := &aws_client.MockedRoute53{}
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{},
+				log:                   testr.New(t),
+				awsClient:             aws_client.NewAwsClientWithServiceClients(&aws_client.MockedEC2{}, route53Client),
+			}
+
+			replaced, err := r.findReplacedRoute53Records(context.TODO(), aws_client.MockHostedZoneId, test.expected, test.owned)
 			assert.NoError(t, err)
-		}
+			assert.Len(t, replaced, test.replaced)
+			if test.noLookup {
+				assert.Empty(t, route53Client.ListResourceRecordSetsInputs)
+			} else {
+				for _, input := range route53Client.ListResourceRecordSetsInputs {
+					// Only the records with the expected and owned names are listed
+					assert.Equal(t, "mock", aws.ToString(input.StartRecordName))
+				}
+			}
+		})
 	}
 }
 
+func TestOwnedRoute53Records(t *testing.T) {
+	assert.Empty(t, ownedRoute53Records(&avov1alpha2.VpcEndpoint{}))
+	assert.Equal(t, []avov1alpha2.ResourceRecordStatus{{Name: "legacy.example.com"}}, ownedRoute53Records(&avov1alpha2.VpcEndpoint{
+		Status: avov1alpha2.VpcEndpointStatus{ResourceRecordSet: "legacy.example.com"},
+	}))
+
+	records := []avov1alpha2.ResourceRecordStatus{{Hostname: "api", Name: "api.example.com", Type: "CNAME"}}
+	assert.Equal(t, records, ownedRoute53Records(&avov1alpha2.VpcEndpoint{
+		Status: avov1alpha2.VpcEndpointStatus{ResourceRecordSet: "api.example.com", ResourceRecords: records},
+	}))
+}
+
 func TestVpcEndpointReconciler_generateExternalNameService(t *testing.T) {
 	var trueBool = true
 
 	tests := []struct {
 		name       string
 		resource   *avov1alpha2.VpcEndpoint
+		record     avov1alpha2.ResourceRecordStatus
 		domainName string
 		expected   *corev1.Service
 		expectErr  bool
@@ -649,9 +2260,12 @@ func TestVpcEndpointReconciler_generateExternalNameService(t *testing.T) {
 						},
 					},
 				},
-				Status: avov1alpha2.VpcEndpointStatus{
-					ResourceRecordSet: "hostname.my.cluster.com",
-				},
+			},
+			record: avov1alpha2.ResourceRecordStatus{
+				Hostname:            "hostname",
+				Name:                "hostname.my.cluster.com",
+				Type:                "CNAME",
+				ExternalNameService: "demo",
 			},
 			domainName: "my.cluster.com",
 			expected: &corev1.Service{
@@ -675,6 +2289,20 @@ func TestVpcEndpointReconciler_generateExternalNameService(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "record not created yet",
+			resource: &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "demo-vpce",
+					Namespace: "demo-ns",
+				},
+			},
+			record: avov1alpha2.ResourceRecordStatus{
+				Hostname:            "hostname",
+				ExternalNameService: "demo",
+			},
+			expectErr: true,
+		},
 	}
 
 	mock, err := testutil.NewDefaultMock()
@@ -684,13 +2312,15 @@ func TestVpcEndpointReconciler_generateExternalNameService(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			r := &VpcEndpointReconciler{
-				Client: mock.Client,
-				log:    testr.New(t),
-				Scheme: mock.Client.Scheme(),
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client: mock.Client,
+					Scheme: mock.Client.Scheme(),
+				},
+				log: testr.New(t),
 			}
 
-			actual, err := r.generateExternalNameService(test.resource)
+			actual, err := r.generateExternalNameService(test.resource, test.record)
 			if test.expectErr {
 				assert.Error(t, err)
 			} else {
@@ -757,3 +2387,272 @@ func TestTagsContains(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateAssumeRoleSessionName(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource *avov1alpha2.VpcEndpoint
+		expected string
+	}{
+		{
+			name: "session name specified",
+			resource: &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mock",
+					Namespace: "mock-ns",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					AssumeRoleSessionName: "custom-session",
+				},
+			},
+			expected: "custom-session",
+		},
+		{
+			name: "derived from namespace and name",
+			resource: &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mock",
+					Namespace: "mock-ns",
+				},
+			},
+			expected: "avo-mock-ns-mock",
+		},
+		{
+			name: "truncated to 64 characters",
+			resource: &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      strings.Repeat("n", 63),
+					Namespace: "mock-ns",
+				},
+			},
+			expected: ("avo-mock-ns-" + strings.Repeat("n", 63))[:64],
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, generateAssumeRoleSessionName(test.resource))
+		})
+	}
+}
+
+func TestDiffTags(t *testing.T) {
+	tests := []struct {
+		name           string
+		actual         map[string]string
+		expected       map[string]string
+		applied        map[string]string
+		expectedUpdate map[string]string
+		expectedRemove []string
+	}{
+		{
+			name:           "in sync",
+			actual:         map[string]string{"a": "1", "unmanaged": "true"},
+			expected:       map[string]string{"a": "1"},
+			applied:        map[string]string{"a": "1"},
+			expectedUpdate: map[string]string{},
+		},
+		{
+			name:           "missing and changed tags",
+			actual:         map[string]string{"a": "1"},
+			expected:       map[string]string{"a": "2", "b": "1"},
+			expectedUpdate: map[string]string{"a": "2", "b": "1"},
+		},
+		{
+			name:           "only previously applied tags are removed",
+			actual:         map[string]string{"a": "1", "b": "1", "c": "1"},
+			expected:       map[string]string{"a": "1"},
+			applied:        map[string]string{"a": "1", "b": "1", "d": "1"},
+			expectedUpdate: map[string]string{},
+			expectedRemove: []string{"b"},
+		},
+		{
+			name:           "orphaned resource picked up again",
+			actual:         map[string]string{"a": "1", util.OrphanedTagKey: "default/mock"},
+			expected:       map[string]string{"a": "1"},
+			expectedUpdate: map[string]string{},
+			expectedRemove: []string{util.OrphanedTagKey},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			toUpdate, toRemove := diffTags(test.actual, test.expected, test.applied)
+			assert.Equal(t, test.expectedUpdate, toUpdate)
+			assert.Equal(t, test.expectedRemove, toRemove)
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_ensureVpcEndpointTags(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mock-tags",
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			Tags: map[string]string{"cost-center": "1234"},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			Tags: map[string]string{"team": "sre"},
+		},
+	}
+	vpce := &ec2Types.VpcEndpoint{
+		VpcEndpointId: aws.String(testutil.MockVpcEndpointId),
+		Tags: []ec2Types.Tag{
+			{Key: aws.String("Name"), Value: aws.String("mock-vpce")},
+			{Key: aws.String("team"), Value: aws.String("sre")},
+		},
+		NetworkInterfaceIds: []string{aws_client.MockNetworkInterfaceId},
+	}
+
+	ec2Client := &aws_client.MockedEC2{}
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Recorder: record.NewFakeRecorder(2),
+		},
+		log:         testr.New(t),
+		awsClient:   aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
+		clusterInfo: &clusterInfo{},
+	}
+
+	assert.NoError(t, r.ensureVpcEndpointTags(context.TODO(), vpce, resource))
+
+	// The VPC Endpoint's stale user tag is removed and the user tags are added to both the VPC Endpoint and its ENI
+	assert.Len(t, ec2Client.CreateTagsInputs, 2)
+	assert.Equal(t, []string{testutil.MockVpcEndpointId}, ec2Client.CreateTagsInputs[0].Resources)
+	assert.Equal(t, []string{aws_client.MockNetworkInterfaceId}, ec2Client.CreateTagsInputs[1].Resources)
+	for _, input := range ec2Client.CreateTagsInputs {
+		assert.Equal(t, []ec2Types.Tag{{Key: aws.String("cost-center"), Value: aws.String("1234")}}, input.Tags)
+	}
+	assert.Len(t, ec2Client.DeleteTagsInputs, 1)
+	assert.Equal(t, []string{testutil.MockVpcEndpointId}, ec2Client.DeleteTagsInputs[0].Resources)
+	assert.Equal(t, []ec2Types.Tag{{Key: aws.String("team")}}, ec2Client.DeleteTagsInputs[0].Tags)
+}
+
+func TestVpcEndpointReconciler_updateVpcEndpointObservedState(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		Status: avov1alpha2.VpcEndpointStatus{
+			DnsEntries: []avov1alpha2.VpcEndpointDnsEntry{{DnsName: "stale.amazonaws.com"}},
+		},
+	}
+	vpce := &ec2Types.VpcEndpoint{
+		VpcEndpointId:       aws.String(testutil.MockVpcEndpointId),
+		OwnerId:             aws.String("123456789012"),
+		PrivateDnsEnabled:   aws.Bool(true),
+		PolicyDocument:      aws.String(`{"Version": "2008-10-17", "Statement": []}`),
+		SubnetIds:           []string{aws_client.MockPrivateSubnetId},
+		NetworkInterfaceIds: []string{aws_client.MockNetworkInterfaceId},
+		DnsEntries: []ec2Types.DnsEntry{
+			{DnsName: aws.String(testutil.MockVpcEndpointDnsName), HostedZoneId: aws.String(aws_client.MockVpcEndpointHostedZone)},
+			{DnsName: aws.String(aws_client.MockVpcEndpointZonalDns), HostedZoneId: aws.String(aws_client.MockVpcEndpointHostedZone)},
+		},
+	}
+
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{},
+		log:                   testr.New(t),
+		awsClient:             aws_client.NewMockedAwsClientWithSubnets(),
+	}
+
+	assert.NoError(t, r.updateVpcEndpointObservedState(context.TODO(), vpce, resource))
+
+	assert.Equal(t, "123456789012", resource.Status.OwnerId)
+	assert.True(t, resource.Status.PrivateDnsEnabled)
+	assert.Equal(t, util.PolicyDocumentHash(`{"Statement":[],"Version":"2008-10-17"}`), resource.Status.ObservedPolicyHash)
+	assert.Equal(t, []avov1alpha2.VpcEndpointSubnet{
+		{Id: aws_client.MockPrivateSubnetId, AvailabilityZone: aws_client.MockAvailabilityZone, AvailabilityZoneId: aws_client.MockAvailabilityZoneId},
+	}, resource.Status.Subnets)
+	assert.Equal(t, []avov1alpha2.VpcEndpointNetworkInterface{
+		{
+			Id:               aws_client.MockNetworkInterfaceId,
+			AvailabilityZone: aws_client.MockAvailabilityZone,
+			PrivateIpAddress: aws_client.MockNetworkInterfaceIp,
+			Ipv6Addresses:    []string{aws_client.MockNetworkInterfaceIpv6},
+		},
+	}, resource.Status.NetworkInterfaces)
+	assert.Equal(t, []avov1alpha2.VpcEndpointDnsEntry{
+		{DnsName: testutil.MockVpcEndpointDnsName, HostedZoneId: aws_client.MockVpcEndpointHostedZone},
+		{DnsName: aws_client.MockVpcEndpointZonalDns, HostedZoneId: aws_client.MockVpcEndpointHostedZone},
+	}, resource.Status.DnsEntries)
+
+	// The subnets and network interfaces aren't described again while their IDs match the status
+	observed := resource.Status.DeepCopy()
+	ec2Client := &aws_client.MockedEC2{}
+	r.awsClient = aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{})
+	vpce.PrivateDnsEnabled = aws.Bool(false)
+	assert.NoError(t, r.updateVpcEndpointObservedState(context.TODO(), vpce, resource))
+	assert.Empty(t, ec2Client.DescribeNetworkInterfacesInputs)
+	assert.Equal(t, observed.Subnets, resource.Status.Subnets)
+	assert.Equal(t, observed.NetworkInterfaces, resource.Status.NetworkInterfaces)
+	assert.False(t, resource.Status.PrivateDnsEnabled)
+
+	// They are refreshed once AWS reports different ones
+	vpce.SubnetIds = nil
+	vpce.NetworkInterfaceIds = []string{aws_client.MockNetworkInterfaceId, "eni-new"}
+	assert.NoError(t, r.updateVpcEndpointObservedState(context.TODO(), vpce, resource))
+	assert.Len(t, ec2Client.DescribeNetworkInterfacesInputs, 1)
+	assert.Empty(t, resource.Status.Subnets)
+	assert.Len(t, resource.Status.NetworkInterfaces, 2)
+
+	clearVpcEndpointObservedState(resource)
+	assert.Empty(t, resource.Status.OwnerId)
+	assert.Empty(t, resource.Status.Subnets)
+	assert.Empty(t, resource.Status.NetworkInterfaces)
+	assert.Empty(t, resource.Status.DnsEntries)
+}
+
+func TestVpcEndpointReconciler_ensurePrivateZoneTags(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "mock-zone-tags",
+			Generation: 2,
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			Tags: map[string]string{"cost-center": "1234"},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			HostedZoneId: aws_client.MockHostedZoneId,
+			Tags:         map[string]string{"team": "sre"},
+		},
+	}
+
+	route53Client := &aws_client.MockedRoute53{
+		HostedZoneTags: []route53Types.Tag{
+			{Key: aws.String(util.OperatorTagKey), Value: aws.String(util.OperatorTagValue)},
+			{Key: aws.String(util.RedHatManagedTagKey), Value: aws.String(util.RedHatManagedTagValue)},
+			{Key: aws.String(aws_client.MockLegacyClusterTag), Value: aws.String("owned")},
+			{Key: aws.String("team"), Value: aws.String("sre")},
+			{Key: aws.String("unmanaged"), Value: aws.String("true")},
+		},
+	}
+	client := testutil.NewTestMock(t, resource).Client
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:   client,
+			Scheme:   client.Scheme(),
+			Recorder: record.NewFakeRecorder(1),
+		},
+		log:       testr.New(t),
+		awsClient: aws_client.NewAwsClientWithServiceClients(&aws_client.MockedEC2{}, route53Client),
+		clusterInfo: &clusterInfo{
+			clusterTag: aws_client.MockLegacyClusterTag,
+		},
+	}
+
+	assert.NoError(t, r.ensurePrivateZoneTags(context.TODO(), resource))
+	assert.Len(t, route53Client.ChangeTagsInputs, 1)
+	assert.Equal(t, []route53Types.Tag{{Key: aws.String("cost-center"), Value: aws.String("1234")}}, route53Client.ChangeTagsInputs[0].AddTags)
+	assert.Equal(t, []string{"team"}, route53Client.ChangeTagsInputs[0].RemoveTagKeys)
+
+	cond := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSRoute53TagsCondition)
+	assert.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, int64(2), cond.ObservedGeneration)
+
+	// The tags aren't verified until the status records the new user tags
+	assert.False(t, r.privateZoneTagsVerified(resource))
+	resource.Status.Tags = map[string]string{"cost-center": "1234"}
+	assert.True(t, r.privateZoneTagsVerified(resource))
+	r.DefaultTags = map[string]string{"owner": "sre"}
+	assert.False(t, r.privateZoneTagsVerified(resource))
+}
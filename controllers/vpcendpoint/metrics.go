@@ -92,8 +92,39 @@ var (
 		},
 		[]string{"name", "namespace"},
 	)
+
+	orphanedResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "aws_vpce_operator",
+			Name:      "orphaned_resources",
+			Help:      "Count of AWS resources managed by AVO for this cluster without a VpcEndpoint CR, labeled by resource type",
+		},
+		[]string{
+			"resource_type",
+		},
+	)
+
+	orphanedResourcesDeleted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "aws_vpce_operator",
+			Name:      "orphaned_resources_deleted_total",
+			Help:      "Count of orphaned AWS resources deleted by the garbage collector, labeled by resource type",
+		},
+		[]string{
+			"resource_type",
+		},
+	)
+
+	garbageCollectionFailure = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "aws_vpce_operator",
+			Name:      "garbage_collection_failure_total",
+			Help:      "Count of garbage collection runs that failed to find or delete orphaned AWS resources",
+		},
+	)
 )
 
 func init() {
-	metrics.Registry.MustRegister(vpcePendingAcceptance, awsUnauthorizedOperation, vpceCleanupFailure, vpceSecurityGroupReadyDuration, vpceEndpointReadyDuration, vpceRoute53ReadyDuration, vpceNotReadySeconds)
+	metrics.Registry.MustRegister(vpcePendingAcceptance, awsUnauthorizedOperation, vpceCleanupFailure, vpceSecurityGroupReadyDuration, vpceEndpointReadyDuration, vpceRoute53ReadyDuration, vpceNotReadySeconds,
+		orphanedResources, orphanedResourcesDeleted, garbageCollectionFailure)
 }
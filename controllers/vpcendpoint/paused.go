@@ -0,0 +1,91 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+)
+
+// isPaused returns true if the reconciliation of the VpcEndpoint CR is paused by the avo.openshift.io/paused annotation
+func isPaused(vpce *avov1alpha2.VpcEndpoint) bool {
+	return vpce.Annotations[avov1alpha2.PausedAnnotation] == "true"
+}
+
+// reconcilePaused reports the state of a paused VpcEndpoint CR's VPC Endpoint without changing anything in AWS or
+// Kubernetes other than the CR's status. Cleanup is skipped as well, so a paused VpcEndpoint being deleted keeps its
+// finalizer until it's unpaused.
+func (r *reconcileScope) reconcilePaused(ctx context.Context, vpce *avov1alpha2.VpcEndpoint) (ctrl.Result, error) {
+	message := fmt.Sprintf("Reconciliation is paused by the %s annotation", avov1alpha2.PausedAnnotation)
+	if !vpce.DeletionTimestamp.IsZero() {
+		message = fmt.Sprintf("Deletion is blocked until the %s annotation is removed", avov1alpha2.PausedAnnotation)
+	}
+	r.log.V(0).Info("Skipping reconciliation of paused VpcEndpoint", "vpcEndpoint", vpce.Name, "namespace", vpce.Namespace)
+
+	// Looking up the cluster and the VPC Endpoint only reads from AWS, so failures are logged and the status is
+	// reported as of the last successful lookup
+	if err := r.parseClusterInfo(ctx, vpce, true); err != nil {
+		r.log.V(0).Info("Unable to refresh the status of paused VpcEndpoint", "error", err.Error())
+	} else if vpce.Status.VPCEndpointId != "" {
+		resp, err := r.awsClient.DescribeSingleVPCEndpointById(ctx, vpce.Status.VPCEndpointId)
+		switch {
+		case err != nil:
+			r.log.V(0).Info("Unable to refresh the status of paused VpcEndpoint", "error", err.Error())
+		case resp != nil && len(resp.VpcEndpoints) > 0:
+			vpce.Status.Status = string(resp.VpcEndpoints[0].State)
+		}
+	}
+
+	if cond := meta.FindStatusCondition(vpce.Status.Conditions, avov1alpha2.PausedCondition); cond == nil || cond.Message != message {
+		r.Recorder.Event(vpce, corev1.EventTypeNormal, "Paused", message)
+	}
+	meta.SetStatusCondition(&vpce.Status.Conditions, metav1.Condition{
+		Type:               avov1alpha2.PausedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: vpce.Generation,
+		Reason:             "Paused",
+		Message:            message,
+	})
+	if err := r.Status().Update(ctx, vpce); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return ctrl.Result{RequeueAfter: time.Minute * 15}, nil
+}
+
+// clearPaused removes the Paused condition once a VpcEndpoint CR is unpaused
+func (r *reconcileScope) clearPaused(ctx context.Context, vpce *avov1alpha2.VpcEndpoint) error {
+	if !meta.RemoveStatusCondition(&vpce.Status.Conditions, avov1alpha2.PausedCondition) {
+		return nil
+	}
+
+	r.log.V(0).Info("Resuming reconciliation of VpcEndpoint", "vpcEndpoint", vpce.Name, "namespace", vpce.Namespace)
+	r.Recorder.Event(vpce, corev1.EventTypeNormal, "Resumed", "Reconciliation resumed")
+	if err := r.Status().Update(ctx, vpce); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+)
+
+func TestVpcEndpointReconciler_Reconcile_paused(t *testing.T) {
+	tests := []struct {
+		name            string
+		deleting        bool
+		expectedMessage string
+	}{
+		{
+			name:            "paused",
+			expectedMessage: "Reconciliation is paused by the avo.openshift.io/paused annotation",
+		},
+		{
+			name:            "paused while deleting",
+			deleting:        true,
+			expectedMessage: "Deletion is blocked until the avo.openshift.io/paused annotation is removed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vpce := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "mock",
+					Namespace:   "default",
+					Annotations: map[string]string{avov1alpha2.PausedAnnotation: "true"},
+					Finalizers:  []string{avoFinalizer},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCEndpointId:   testutil.MockVpcEndpointId,
+					SecurityGroupId: "sg-12345",
+				},
+			}
+			if test.deleting {
+				vpce.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			}
+
+			client := testutil.NewTestMock(t, vpce).Client
+			recorder := record.NewFakeRecorder(2)
+			r := &VpcEndpointReconciler{
+				Client:   client,
+				Scheme:   client.Scheme(),
+				Recorder: recorder,
+			}
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "mock", Namespace: "default"}}
+			for i := 0; i < 2; i++ {
+				result, err := r.Reconcile(context.TODO(), req)
+				assert.NoError(t, err)
+				assert.Equal(t, 15*time.Minute, result.RequeueAfter)
+			}
+
+			actual := &avov1alpha2.VpcEndpoint{}
+			assert.NoError(t, client.Get(context.TODO(), req.NamespacedName, actual))
+			assert.Equal(t, []string{avoFinalizer}, actual.Finalizers)
+			assert.Equal(t, testutil.MockVpcEndpointId, actual.Status.VPCEndpointId)
+			assert.Equal(t, "sg-12345", actual.Status.SecurityGroupId)
+
+			cond := meta.FindStatusCondition(actual.Status.Conditions, avov1alpha2.PausedCondition)
+			if assert.NotNil(t, cond) {
+				assert.Equal(t, metav1.ConditionTrue, cond.Status)
+				assert.Equal(t, test.expectedMessage, cond.Message)
+			}
+
+			// The Paused event is only emitted once
+			assert.Len(t, recorder.Events, 1)
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_clearPaused(t *testing.T) {
+	vpce := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "mock", Namespace: "default"},
+		Status: avov1alpha2.VpcEndpointStatus{
+			Conditions: []metav1.Condition{
+				{Type: avov1alpha2.PausedCondition, Status: metav1.ConditionTrue, Reason: "Paused"},
+			},
+		},
+	}
+
+	client := testutil.NewTestMock(t, vpce).Client
+	recorder := record.NewFakeRecorder(2)
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:   client,
+			Recorder: recorder,
+		},
+	}
+
+	assert.NoError(t, r.clearPaused(context.TODO(), vpce))
+	assert.Nil(t, meta.FindStatusCondition(vpce.Status.Conditions, avov1alpha2.PausedCondition))
+	assert.Len(t, recorder.Events, 1)
+
+	assert.NoError(t, r.clearPaused(context.TODO(), vpce))
+	assert.Len(t, recorder.Events, 1)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+)
+
+// unauthorizedErrorCodes are the AWS API error codes reported with the Unauthorized reason
+var unauthorizedErrorCodes = map[string]struct{}{
+	"UnauthorizedOperation":       {},
+	"AccessDenied":                {},
+	"AccessDeniedException":       {},
+	"AuthFailure":                 {},
+	"ExpiredToken":                {},
+	"InvalidClientTokenId":        {},
+	"UnrecognizedClientException": {},
+}
+
+// quotaExceededErrorCodes are the AWS API error codes reported with the QuotaExceeded reason, in addition to the
+// EC2 *LimitExceeded codes
+var quotaExceededErrorCodes = map[string]struct{}{
+	"LimitsExceeded":                      {},
+	"TooManyHostedZones":                  {},
+	"TooManyVPCAssociationAuthorizations": {},
+}
+
+// updateReadyCondition records the outcome of a reconcile in the VpcEndpoint CR's status: the Ready condition,
+// .status.observedGeneration, .status.lastReconcileTime and .status.lastReconcileError
+func (r *reconcileScope) updateReadyCondition(ctx context.Context, vpce *avov1alpha2.VpcEndpoint, reconcileErr error) error {
+	var requeueErr *requeueAfterError
+	if errors.As(reconcileErr, &requeueErr) {
+		// Waiting, e.g. before recreating a rejected VPC Endpoint, isn't a failure
+		reconcileErr = nil
+	}
+
+	// Only the Ready condition is set here. The other conditions keep the generation the validations last computed
+	// them for, since the validations skip the work already done for the current generation.
+	if reconcileErr == nil {
+		vpce.Status.LastReconcileError = ""
+	} else {
+		vpce.Status.LastReconcileError = reconcileErr.Error()
+	}
+
+	meta.SetStatusCondition(&vpce.Status.Conditions, readyCondition(vpce, reconcileErr))
+	now := metav1.Now()
+	vpce.Status.LastReconcileTime = &now
+	vpce.Status.ObservedGeneration = vpce.Generation
+
+	if err := r.Status().Update(ctx, vpce); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	return nil
+}
+
+// readyCondition returns the Ready condition of a VpcEndpoint CR given the error of its last reconcile, if any
+func readyCondition(vpce *avov1alpha2.VpcEndpoint, reconcileErr error) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               avov1alpha2.ReadyCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: vpce.Generation,
+	}
+
+	vpceCondition := meta.FindStatusCondition(vpce.Status.Conditions, avov1alpha2.AWSVpcEndpointCondition)
+	rejected := vpceCondition != nil && vpceCondition.Reason == vpcEndpointRejectedReason
+
+	if reconcileErr != nil {
+		condition.Reason = reconcileErrorReason(reconcileErr)
+		condition.Message = reconcileErr.Error()
+		// A rejection is reported as an error, but it's more specific than a generic reconcile error
+		if rejected && condition.Reason == avov1alpha2.ReadyReasonReconcileError {
+			condition.Reason = avov1alpha2.ReadyReasonRejected
+			condition.Message = vpceCondition.Message
+		}
+		return condition
+	}
+
+	if isVpcEndpointReady(vpce) {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = avov1alpha2.ReadyReasonAvailable
+		condition.Message = "The VPC Endpoint and its resources are ready"
+		return condition
+	}
+
+	// When this bug is fixed we can compare against the ec2Types.State enums
+	// https://github.com/aws/aws-sdk/issues/116
+	switch {
+	case rejected:
+		condition.Reason = avov1alpha2.ReadyReasonRejected
+		condition.Message = vpceCondition.Message
+		return condition
+	case vpceCondition != nil && vpceCondition.Reason == "pendingAcceptance":
+		condition.Reason = avov1alpha2.ReadyReasonPendingAcceptance
+		condition.Message = "The VPC Endpoint is waiting to be accepted by the VPC Endpoint Service"
+		return condition
+	}
+
+	var pending []string
+	for _, c := range vpce.Status.Conditions {
+		if c.Type != avov1alpha2.ReadyCondition && c.Status != metav1.ConditionTrue {
+			pending = append(pending, c.Type)
+		}
+	}
+	condition.Reason = avov1alpha2.ReadyReasonProgressing
+	condition.Message = "Waiting for the VPC Endpoint's resources to be created"
+	if len(pending) > 0 {
+		condition.Message = fmt.Sprintf("Waiting for %s", strings.Join(pending, ", "))
+	}
+
+	return condition
+}
+
+// reconcileErrorReason returns the Ready condition reason for a reconcile error
+func reconcileErrorReason(err error) string {
+	var ae smithy.APIError
+	if errors.As(err, &ae) {
+		code := ae.ErrorCode()
+		if _, ok := unauthorizedErrorCodes[code]; ok {
+			return avov1alpha2.ReadyReasonUnauthorized
+		}
+		if _, ok := retry.DefaultThrottleErrorCodes[code]; ok {
+			return avov1alpha2.ReadyReasonThrottled
+		}
+		if _, ok := quotaExceededErrorCodes[code]; ok || strings.HasSuffix(code, "LimitExceeded") {
+			return avov1alpha2.ReadyReasonQuotaExceeded
+		}
+	}
+
+	if errors.Is(err, aws_client.ErrSubnetsNotFound) {
+		return avov1alpha2.ReadyReasonSubnetDiscoveryFailed
+	}
+
+	return avov1alpha2.ReadyReasonReconcileError
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+)
+
+func Test_reconcileErrorReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "unauthorized",
+			err:      fmt.Errorf("failed to create VPC Endpoint: %w", &smithy.GenericAPIError{Code: "UnauthorizedOperation"}),
+			expected: avov1alpha2.ReadyReasonUnauthorized,
+		},
+		{
+			name:     "access denied",
+			err:      &smithy.GenericAPIError{Code: "AccessDenied"},
+			expected: avov1alpha2.ReadyReasonUnauthorized,
+		},
+		{
+			name:     "throttled",
+			err:      &smithy.GenericAPIError{Code: "RequestLimitExceeded"},
+			expected: avov1alpha2.ReadyReasonThrottled,
+		},
+		{
+			name:     "vpc endpoint quota",
+			err:      &smithy.GenericAPIError{Code: "VpcEndpointLimitExceeded"},
+			expected: avov1alpha2.ReadyReasonQuotaExceeded,
+		},
+		{
+			name:     "hosted zone quota",
+			err:      &smithy.GenericAPIError{Code: "TooManyHostedZones"},
+			expected: avov1alpha2.ReadyReasonQuotaExceeded,
+		},
+		{
+			name:     "subnet discovery",
+			err:      fmt.Errorf("unable to autodiscover subnets: %w", fmt.Errorf("%w with tag key: mock", aws_client.ErrSubnetsNotFound)),
+			expected: avov1alpha2.ReadyReasonSubnetDiscoveryFailed,
+		},
+		{
+			name:     "other API error",
+			err:      &smithy.GenericAPIError{Code: "InvalidParameter"},
+			expected: avov1alpha2.ReadyReasonReconcileError,
+		},
+		{
+			name:     "other error",
+			err:      errors.New("mock"),
+			expected: avov1alpha2.ReadyReasonReconcileError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, reconcileErrorReason(test.err))
+		})
+	}
+}
+
+func Test_readyCondition(t *testing.T) {
+	tests := []struct {
+		name           string
+		conditions     []metav1.Condition
+		err            error
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name: "available",
+			conditions: []metav1.Condition{
+				{Type: avov1alpha2.AWSSecurityGroupCondition, Status: metav1.ConditionTrue},
+				{Type: avov1alpha2.AWSVpcEndpointCondition, Status: metav1.ConditionTrue},
+			},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: avov1alpha2.ReadyReasonAvailable,
+		},
+		{
+			name: "pending acceptance",
+			conditions: []metav1.Condition{
+				{Type: avov1alpha2.AWSSecurityGroupCondition, Status: metav1.ConditionTrue},
+				{Type: avov1alpha2.AWSVpcEndpointCondition, Status: metav1.ConditionFalse, Reason: "pendingAcceptance"},
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: avov1alpha2.ReadyReasonPendingAcceptance,
+		},
+		{
+			name: "rejected",
+			conditions: []metav1.Condition{
+				{Type: avov1alpha2.AWSVpcEndpointCondition, Status: metav1.ConditionFalse, Reason: vpcEndpointRejectedReason},
+			},
+			err:            errors.New("VPC Endpoint unexpectedly needed to be deleted"),
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: avov1alpha2.ReadyReasonRejected,
+		},
+		{
+			name: "progressing",
+			conditions: []metav1.Condition{
+				{Type: avov1alpha2.AWSVpcEndpointCondition, Status: metav1.ConditionFalse, Reason: "pending"},
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: avov1alpha2.ReadyReasonProgressing,
+		},
+		{
+			name: "error",
+			conditions: []metav1.Condition{
+				{Type: avov1alpha2.AWSSecurityGroupCondition, Status: metav1.ConditionTrue},
+				{Type: avov1alpha2.AWSVpcEndpointCondition, Status: metav1.ConditionTrue},
+			},
+			err:            &smithy.GenericAPIError{Code: "Throttling"},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: avov1alpha2.ReadyReasonThrottled,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			vpce := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status:     avov1alpha2.VpcEndpointStatus{Conditions: test.conditions},
+			}
+
+			actual := readyCondition(vpce, test.err)
+			assert.Equal(t, avov1alpha2.ReadyCondition, actual.Type)
+			assert.Equal(t, test.expectedStatus, actual.Status)
+			assert.Equal(t, test.expectedReason, actual.Reason)
+			assert.Equal(t, int64(2), actual.ObservedGeneration)
+		})
+	}
+}
+
+func TestVpcEndpointReconciler_updateReadyCondition(t *testing.T) {
+	vpce := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "mock", Namespace: "default", Generation: 2},
+		Status: avov1alpha2.VpcEndpointStatus{
+			Conditions: []metav1.Condition{
+				{Type: avov1alpha2.AWSVpcEndpointCondition, Status: metav1.ConditionTrue, Reason: "available", ObservedGeneration: 1},
+			},
+		},
+	}
+
+	client := testutil.NewTestMock(t, vpce).Client
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client: client,
+		},
+		log: testr.New(t),
+	}
+	key := types.NamespacedName{Name: "mock", Namespace: "default"}
+
+	// A failed reconcile records its error
+	assert.NoError(t, r.updateReadyCondition(context.TODO(), vpce, &smithy.GenericAPIError{Code: "UnauthorizedOperation", Message: "mock"}))
+	actual := &avov1alpha2.VpcEndpoint{}
+	assert.NoError(t, client.Get(context.TODO(), key, actual))
+	assert.NotEmpty(t, actual.Status.LastReconcileError)
+	assert.NotNil(t, actual.Status.LastReconcileTime)
+	assert.Equal(t, int64(2), actual.Status.ObservedGeneration)
+	if cond := meta.FindStatusCondition(actual.Status.Conditions, avov1alpha2.ReadyCondition); assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		assert.Equal(t, avov1alpha2.ReadyReasonUnauthorized, cond.Reason)
+	}
+
+	// A successful reconcile clears the error, waiting before requeueing isn't an error. Conditions that weren't
+	// computed again by this reconcile keep their generation.
+	assert.NoError(t, r.updateReadyCondition(context.TODO(), actual, &requeueAfterError{after: time.Minute, reason: "mock"}))
+	assert.NoError(t, client.Get(context.TODO(), key, actual))
+	assert.Empty(t, actual.Status.LastReconcileError)
+	if cond := meta.FindStatusCondition(actual.Status.Conditions, avov1alpha2.AWSVpcEndpointCondition); assert.NotNil(t, cond) {
+		assert.Equal(t, int64(1), cond.ObservedGeneration)
+	}
+	if cond := meta.FindStatusCondition(actual.Status.Conditions, avov1alpha2.ReadyCondition); assert.NotNil(t, cond) {
+		assert.Equal(t, int64(2), cond.ObservedGeneration)
+	}
+	assert.True(t, meta.IsStatusConditionTrue(actual.Status.Conditions, avov1alpha2.ReadyCondition))
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -37,24 +39,41 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 type Validation func(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error
 
-// isVpcEndpointReady returns true if all status conditions on the VpcEndpoint CR are True.
+// requeueAfterError is returned by a Validation that cannot make progress until some time has passed,
+// so that the reconcile is requeued after that time instead of with exponential backoff.
+type requeueAfterError struct {
+	after  time.Duration
+	reason string
+}
+
+func (e *requeueAfterError) Error() string {
+	return fmt.Sprintf("%s, requeueing after %s", e.reason, e.after)
+}
+
+// isVpcEndpointReady returns true if all status conditions on the VpcEndpoint CR, other than the Ready condition
+// that summarizes them, are True.
 func isVpcEndpointReady(resource *avov1alpha2.VpcEndpoint) bool {
-	if len(resource.Status.Conditions) == 0 {
-		return false
-	}
+	ready := false
 	for _, c := range resource.Status.Conditions {
+		if c.Type == avov1alpha2.ReadyCondition {
+			continue
+		}
 		if c.Status != metav1.ConditionTrue {
 			return false
 		}
+		ready = true
 	}
-	return true
+	return ready
 }
 
-func (r *VpcEndpointReconciler) validateResources(ctx context.Context, resource *avov1alpha2.VpcEndpoint, validations []Validation) error {
+func (r *reconcileScope) validateResources(ctx context.Context, resource *avov1alpha2.VpcEndpoint, validations []Validation) error {
 	for _, validation := range validations {
 		if err := validation(ctx, resource); err != nil {
 			return err
@@ -64,22 +83,73 @@ func (r *VpcEndpointReconciler) validateResources(ctx context.Context, resource
 	return nil
 }
 
-// validateSecurityGroup checks a security group against what's expected, returning an error if there are differences.
-// Security groups can't be updated-in-place, so a new one will need to be created before deleting this existing one.
-func (r *VpcEndpointReconciler) validateSecurityGroup(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+// validateSecurityGroup finds the user's security groups selected by the VpcEndpoint CR and, unless disabled, checks
+// the managed security group against what's expected, returning an error if there are differences.
+func (r *reconcileScope) validateSecurityGroup(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
 	if resource == nil {
 		// Should never happen
 		return fmt.Errorf("resource must be specified")
 	}
 
+	// Only interface VPC Endpoints have security groups
+	if vpcEndpointType(resource) != avov1alpha2.VpcEndpointTypeInterface {
+		return nil
+	}
+
+	userSgIds, err := r.findUserSecurityGroups(ctx, resource)
+	if err != nil {
+		r.log.V(0).Error(err, "failed to find user security groups")
+		return err
+	}
+
+	if !slices.Equal(resource.Status.UserSecurityGroupIds, userSgIds) {
+		r.log.V(0).Info("Found user security groups", "ids", userSgIds)
+		resource.Status.UserSecurityGroupIds = userSgIds
+		if err := r.Status().Update(ctx, resource); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+			return err
+		}
+	}
+
+	if managedSecurityGroupEnabled(resource) {
+		if err := r.validateManagedSecurityGroup(ctx, resource); err != nil {
+			return err
+		}
+	}
+
+	if !meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSSecurityGroupCondition) {
+		duration := time.Since(resource.CreationTimestamp.Time).Seconds()
+		vpceSecurityGroupReadyDuration.Observe(duration)
+		r.log.V(0).Info("Security group ready", "durationSeconds", duration)
+	}
+
+	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+		Type:               avov1alpha2.AWSSecurityGroupCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Validated",
+		Message:            "Validated",
+		ObservedGeneration: resource.Generation,
+	})
+	if err := r.Status().Update(ctx, resource); err != nil {
+		r.log.V(0).Error(err, "failed to update status")
+		return err
+	}
+
+	return nil
+}
+
+// validateManagedSecurityGroup checks the managed security group against what's expected, returning an error if
+// there are differences. Security groups can't be updated-in-place, so a new one will need to be created before
+// deleting this existing one.
+func (r *reconcileScope) validateManagedSecurityGroup(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
 	sg, err := r.findOrCreateSecurityGroup(ctx, resource)
 	if err != nil {
 		r.log.V(0).Error(err, "failed to find or create security groups")
 		return err
 	}
 
-	if err := r.createMissingSecurityGroupTags(ctx, sg, resource); err != nil {
-		r.log.V(0).Error(err, "failed to create missing security group tags")
+	if err := r.ensureSecurityGroupTags(ctx, sg, resource); err != nil {
+		r.log.V(0).Error(err, "failed to reconcile security group tags")
 		return err
 	}
 
@@ -95,21 +165,20 @@ func (r *VpcEndpointReconciler) validateSecurityGroup(ctx context.Context, resou
 		return err
 	}
 
-	if !meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSSecurityGroupCondition) {
-		duration := time.Since(resource.CreationTimestamp.Time).Seconds()
-		vpceSecurityGroupReadyDuration.Observe(duration)
-		r.log.V(0).Info("Security group ready", "durationSeconds", duration)
+	ingressRuleIds, egressRuleIds, err := r.generateExtraSecurityGroupRules(ctx, sg, resource)
+	if err != nil {
+		r.log.V(0).Error(err, "failed to generate extra security group rules")
+		return err
 	}
 
-	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-		Type:    avov1alpha2.AWSSecurityGroupCondition,
-		Status:  metav1.ConditionTrue,
-		Reason:  "Validated",
-		Message: "Validated",
-	})
-	if err := r.Status().Update(ctx, resource); err != nil {
-		r.log.V(0).Error(err, "failed to update status")
-		return err
+	if len(ingressRuleIds) > 0 || len(egressRuleIds) > 0 {
+		if err := r.awsClient.RevokeSecurityGroupRules(ctx, *sg.GroupId, ingressRuleIds, egressRuleIds); err != nil {
+			r.log.V(0).Error(err, "failed to revoke security group rules")
+			return err
+		}
+		r.log.V(0).Info("Revoked security group rules", "ingressRuleIds", ingressRuleIds, "egressRuleIds", egressRuleIds)
+		r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Updated", "Revoked security group rules: %v",
+			append(ingressRuleIds, egressRuleIds...))
 	}
 
 	return nil
@@ -117,7 +186,7 @@ func (r *VpcEndpointReconciler) validateSecurityGroup(ctx context.Context, resou
 
 // validateVPCEndpoint checks a VPC endpoint with what's expected and reconciles their state
 // returning an error if it cannot do so.
-func (r *VpcEndpointReconciler) validateVPCEndpoint(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+func (r *reconcileScope) validateVPCEndpoint(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
 	if resource == nil {
 		// Should never happen
 		return fmt.Errorf("resource must be specified")
@@ -139,6 +208,11 @@ func (r *VpcEndpointReconciler) validateVPCEndpoint(ctx context.Context, resourc
 		}
 	}
 
+	// The observed state is persisted along with the VPC Endpoint's state below
+	if err := r.updateVpcEndpointObservedState(ctx, vpce, resource); err != nil {
+		return err
+	}
+
 	// When this bug is fixed we can switch/case off of enums
 	// https://github.com/aws/aws-sdk/issues/116
 	switch vpce.State { //nolint:exhaustive
@@ -147,9 +221,10 @@ func (r *VpcEndpointReconciler) validateVPCEndpoint(ctx context.Context, resourc
 		// Nothing we can do at the moment, the VPC Endpoint needs to be accepted
 		r.log.V(0).Info("Waiting for VPC Endpoint connection acceptance", "status", string(vpce.State))
 		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-			Type:   avov1alpha2.AWSVpcEndpointCondition,
-			Status: metav1.ConditionFalse,
-			Reason: string(vpce.State),
+			Type:               avov1alpha2.AWSVpcEndpointCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             string(vpce.State),
+			ObservedGeneration: resource.Generation,
 		})
 		r.invalidateRoute53RecordCondition(resource)
 		if err := r.Status().Update(ctx, resource); err != nil {
@@ -163,9 +238,10 @@ func (r *VpcEndpointReconciler) validateVPCEndpoint(ctx context.Context, resourc
 		vpcePendingAcceptance.WithLabelValues(resource.Name, resource.Namespace, resource.Status.VPCEndpointId).Set(0)
 		r.log.V(0).Info("VPC Endpoint is transitioning state", "status", string(vpce.State))
 		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-			Type:   avov1alpha2.AWSVpcEndpointCondition,
-			Status: metav1.ConditionFalse,
-			Reason: string(vpce.State),
+			Type:               avov1alpha2.AWSVpcEndpointCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             string(vpce.State),
+			ObservedGeneration: resource.Generation,
 		})
 		r.invalidateRoute53RecordCondition(resource)
 		if err := r.Status().Update(ctx, resource); err != nil {
@@ -177,10 +253,12 @@ func (r *VpcEndpointReconciler) validateVPCEndpoint(ctx context.Context, resourc
 	case "available":
 		vpcePendingAcceptance.WithLabelValues(resource.Name, resource.Namespace, resource.Status.VPCEndpointId).Set(0)
 		r.log.V(0).Info("VPC Endpoint ready", "id", resource.Status.VPCEndpointId)
+		// The VPC Endpoint was accepted, so start counting recreation attempts from scratch on future rejections
+		resource.Status.RecreateAttempts = 0
 
 		// Enable private DNS after the VPCE connection has been accepted. AWS does not allow
 		// PrivateDnsEnabled at creation time for services that require acceptance.
-		if r.EnablePrivateDns && resource.Spec.EnablePrivateDns && (vpce.PrivateDnsEnabled == nil || !*vpce.PrivateDnsEnabled) {
+		if r.EnablePrivateDns && resource.Spec.EnablePrivateDns && vpcEndpointType(resource) == avov1alpha2.VpcEndpointTypeInterface && (vpce.PrivateDnsEnabled == nil || !*vpce.PrivateDnsEnabled) {
 			r.log.V(0).Info("Enabling private DNS on VPC Endpoint", "id", resource.Status.VPCEndpointId)
 			enablePrivateDns := true
 			if _, err := r.awsClient.ModifyVpcEndpoint(ctx, &ec2.ModifyVpcEndpointInput{
@@ -192,33 +270,49 @@ func (r *VpcEndpointReconciler) validateVPCEndpoint(ctx context.Context, resourc
 			r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Updated", "Enabled private DNS on VPC Endpoint: %s", resource.Status.VPCEndpointId)
 		}
 	case "rejected":
+		vpcePendingAcceptance.WithLabelValues(resource.Name, resource.Namespace, resource.Status.VPCEndpointId).Set(0)
 		r.log.V(0).Info("VPC Endpoint rejected, starting deletion", "id", resource.Status.VPCEndpointId)
 		r.invalidateRoute53RecordCondition(resource)
 		if _, err := r.awsClient.DeleteVPCEndpoint(ctx, resource.Status.VPCEndpointId); err != nil {
 			var ae smithy.APIError
-			if errors.As(err, &ae) {
-				if ae.ErrorCode() == "InvalidVpcEndpoint.NotFound" {
-					resource.Status.VPCEndpointId = ""
-				} else {
-					return err
-				}
-			} else {
+			if !errors.As(err, &ae) || ae.ErrorCode() != "InvalidVpcEndpoint.NotFound" {
 				return err
 			}
 		}
+		r.Recorder.Eventf(resource, corev1.EventTypeWarning, "Rejected", "Deleted rejected VPC endpoint: %s", resource.Status.VPCEndpointId)
+
+		now := metav1.Now()
+		resource.Status.LastRejectionTime = &now
+		resource.Status.VPCEndpointId = ""
+		clearVpcEndpointObservedState(resource)
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:               avov1alpha2.AWSVpcEndpointCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             vpcEndpointRejectedReason,
+			Message:            "VPC Endpoint was rejected by the VPC Endpoint Service and deleted",
+			ObservedGeneration: resource.Generation,
+		})
+		if err := r.Status().Update(ctx, resource); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+			return err
+		}
+
+		if err := r.applyRejectionPolicy(ctx, resource); err != nil {
+			return err
+		}
 
 		return fmt.Errorf("VPC Endpoint unexpectedly needed to be deleted")
 	case ec2Types.StateFailed, ec2Types.StateDeleted:
 		// No other known states, but just in case catch with a default
 		fallthrough
 	default:
-		// TODO: If rejected, we may want an option to recreate the VPC Endpoint and try again
 		vpcePendingAcceptance.WithLabelValues(resource.Name, resource.Namespace, resource.Status.VPCEndpointId).Set(0)
 		r.log.V(0).Info("VPC Endpoint in a bad state", "status", string(vpce.State))
 		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-			Type:   avov1alpha2.AWSVpcEndpointCondition,
-			Status: metav1.ConditionFalse,
-			Reason: string(vpce.State),
+			Type:               avov1alpha2.AWSVpcEndpointCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             string(vpce.State),
+			ObservedGeneration: resource.Generation,
 		})
 		r.invalidateRoute53RecordCondition(resource)
 		if err := r.Status().Update(ctx, resource); err != nil {
@@ -229,14 +323,56 @@ func (r *VpcEndpointReconciler) validateVPCEndpoint(ctx context.Context, resourc
 		return fmt.Errorf("vpc endpoint in a bad state: %s", vpce.State)
 	}
 
-	err = r.ensureVpcEndpointSubnets(ctx, vpce, resource)
-	if err != nil {
-		return fmt.Errorf("failed to reconcile VPC Endpoint subnets: %w", err)
+	switch vpcEndpointType(resource) {
+	case avov1alpha2.VpcEndpointTypeGateway:
+		// Gateway VPC Endpoints are associated with route tables instead of subnets and have no security groups
+		if err := r.ensureVpcEndpointRouteTables(ctx, vpce, resource); err != nil {
+			return fmt.Errorf("failed to reconcile VPC Endpoint route tables: %w", err)
+		}
+	case avov1alpha2.VpcEndpointTypeGatewayLoadBalancer:
+		// The subnet of a GatewayLoadBalancer VPC Endpoint is set when it's created and can't be modified. It has no
+		// security groups.
+		if !slices.Equal(vpce.SubnetIds, resource.Spec.Vpc.SubnetIds) {
+			return fmt.Errorf("the subnet of a GatewayLoadBalancer VPC Endpoint can't be changed from %v to %v", vpce.SubnetIds, resource.Spec.Vpc.SubnetIds)
+		}
+
+		if err := r.ensureVpcEndpointRoutes(ctx, vpce, resource); err != nil {
+			return fmt.Errorf("failed to reconcile VPC Endpoint routes: %w", err)
+		}
+	default:
+		err = r.ensureVpcEndpointSubnets(ctx, vpce, resource)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile VPC Endpoint subnets: %w", err)
+		}
+
+		// The IP address type is set at creation, so it only needs to be modified when it's been changed afterward,
+		// which AWS only allows once the VPC Endpoint is available
+		if vpce.State == ec2Types.StateAvailable {
+			if err := r.ensureVpcEndpointIpAddressType(ctx, vpce, resource); err != nil {
+				return fmt.Errorf("failed to reconcile VPC Endpoint IP address type: %w", err)
+			}
+		}
+
+		err = r.ensureVpcEndpointSecurityGroups(ctx, vpce, resource)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile VPC Endpoint security groups: %w", err)
+		}
+
+		// The managed security group was detached above, so it can be deleted once it's no longer wanted
+		if !managedSecurityGroupEnabled(resource) && resource.Status.SecurityGroupId != "" {
+			if err := r.deleteManagedSecurityGroup(ctx, resource); err != nil {
+				return err
+			}
+		}
 	}
 
-	err = r.ensureVpcEndpointSecurityGroups(ctx, vpce, resource)
+	if err := r.ensureVpcEndpointPolicy(ctx, vpce, resource); err != nil {
+		return fmt.Errorf("failed to reconcile VPC Endpoint policy: %w", err)
+	}
+
+	err = r.ensureVpcEndpointTags(ctx, vpce, resource)
 	if err != nil {
-		return fmt.Errorf("failed to reconcile VPC Endpoint security groups: %w", err)
+		return fmt.Errorf("failed to reconcile VPC Endpoint tags: %w", err)
 	}
 
 	if !meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSVpcEndpointCondition) {
@@ -246,10 +382,11 @@ func (r *VpcEndpointReconciler) validateVPCEndpoint(ctx context.Context, resourc
 	}
 
 	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-		Type:    avov1alpha2.AWSVpcEndpointCondition,
-		Status:  metav1.ConditionTrue,
-		Reason:  string(vpce.State),
-		Message: fmt.Sprintf("VPC Endpoint status is: %s", string(vpce.State)),
+		Type:               avov1alpha2.AWSVpcEndpointCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             string(vpce.State),
+		Message:            fmt.Sprintf("VPC Endpoint status is: %s", string(vpce.State)),
+		ObservedGeneration: resource.Generation,
 	})
 	if err := r.Status().Update(ctx, resource); err != nil {
 		r.log.V(0).Error(err, "failed to update status")
@@ -259,7 +396,7 @@ func (r *VpcEndpointReconciler) validateVPCEndpoint(ctx context.Context, resourc
 	return nil
 }
 
-func (r *VpcEndpointReconciler) validateCustomDns(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+func (r *reconcileScope) validateCustomDns(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
 	// When enablePrivateDns is active, DNS resolution is handled at the VPC Endpoint Service level
 	// via Domain Ownership Verification, so skip all Route53 and ExternalName service management.
 	if r.EnablePrivateDns && resource.Spec.EnablePrivateDns {
@@ -267,6 +404,11 @@ func (r *VpcEndpointReconciler) validateCustomDns(ctx context.Context, resource
 		return nil
 	}
 
+	// Only interface VPC Endpoints have DNS names to point records at
+	if vpcEndpointType(resource) != avov1alpha2.VpcEndpointTypeInterface {
+		return nil
+	}
+
 	if err := r.validateResources(ctx, resource,
 		[]Validation{
 			r.validateR53PrivateHostedZone,
@@ -281,7 +423,7 @@ func (r *VpcEndpointReconciler) validateCustomDns(ctx context.Context, resource
 }
 
 // validateR53PrivateHostedZone ensures the configured CustomDns Private Hosted Zone exists
-func (r *VpcEndpointReconciler) validateR53PrivateHostedZone(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+func (r *reconcileScope) validateR53PrivateHostedZone(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
 	if resource == nil {
 		// Should never happen
 		return errors.New("resource must be specified")
@@ -334,8 +476,8 @@ func (r *VpcEndpointReconciler) validateR53PrivateHostedZone(ctx context.Context
 			return err
 		}
 
-		if !meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSRoute53TagsCondition) {
-			if err := r.createMissingPrivateZoneTags(ctx, resource); err != nil {
+		if !r.privateZoneTagsVerified(resource) {
+			if err := r.ensurePrivateZoneTags(ctx, resource); err != nil {
 				return err
 			}
 		}
@@ -345,7 +487,37 @@ func (r *VpcEndpointReconciler) validateR53PrivateHostedZone(ctx context.Context
 	return nil
 }
 
-func (r *VpcEndpointReconciler) validateR53HostedZoneAuthorization(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+// validateTags records the user tags applied to the AWS resources in the status once they have all been tagged, so
+// that tags dropped from spec.tags or the operator's default tags can be removed from them later
+func (r *reconcileScope) validateTags(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+	if resource == nil {
+		// Should never happen
+		return errors.New("resource must be specified")
+	}
+
+	// The VPC Endpoint and its network interfaces are only tagged once the VPC Endpoint is available
+	if !meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSVpcEndpointCondition) {
+		return nil
+	}
+
+	userTags := r.userTags(resource)
+	if maps.Equal(resource.Status.Tags, userTags) {
+		return nil
+	}
+
+	if len(userTags) == 0 {
+		userTags = nil
+	}
+	resource.Status.Tags = userTags
+	if err := r.Status().Update(ctx, resource); err != nil {
+		r.log.V(0).Error(err, "failed to update status")
+		return err
+	}
+
+	return nil
+}
+
+func (r *reconcileScope) validateR53HostedZoneAuthorization(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
 	if resource == nil {
 		// Should never happen
 		return errors.New("resource must be specified")
@@ -381,12 +553,25 @@ func (r *VpcEndpointReconciler) validateR53HostedZoneAuthorization(ctx context.C
 			}
 
 			// Use the provided override credentials for this specific vpcendpoint
-			cfg, err := secrets.ParseAWSCredentialOverride(ctx, r.APIReader, v.Region, v.CredentialsSecretRef)
+			secret, err := secrets.GetAWSCredentialOverride(ctx, r.APIReader, v.CredentialsSecretRef)
+			if err != nil {
+				return err
+			}
+			clients, err := r.AWSClientPool.Get(ctx, aws_client.ClientPoolKey{
+				Region:                v.Region,
+				CredentialSource:      secrets.CredentialSource(secret),
+				SecretResourceVersion: secret.ResourceVersion,
+			}, func(ctx context.Context) (aws.Config, error) {
+				return secrets.AWSConfigFromSecret(ctx, v.Region, secret)
+			})
 			if err != nil {
 				return err
 			}
 
-			r.awsAssociatedVpcClient = aws_client.NewVpcAssociationClient(cfg)
+			r.awsAssociatedVpcClient = clients.VpcAssociationClient()
+			if r.dryRunRecorder != nil {
+				r.awsAssociatedVpcClient = aws_client.NewDryRunVpcAssociationClient(r.awsAssociatedVpcClient, r.dryRunRecorder)
+			}
 			if _, err := r.awsAssociatedVpcClient.AssociateVPCWithHostedZone(ctx, resource.Status.HostedZoneId, v.VpcId, v.Region); err != nil {
 				return err
 			}
@@ -400,48 +585,106 @@ func (r *VpcEndpointReconciler) validateR53HostedZoneAuthorization(ctx context.C
 }
 
 // validateR53HostedZoneRecord ensures a DNS record exists for the given VPC Endpoint
-func (r *VpcEndpointReconciler) validateR53HostedZoneRecord(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+func (r *reconcileScope) validateR53HostedZoneRecord(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
 	if resource == nil {
 		// Should never happen
 		return errors.New("resource must be specified")
 	}
 
-	// Skip the Route53 UPSERT when the record was already created and the VPC endpoint
+	// Skip the Route53 UPSERT when the record was already created for the current spec and the VPC endpoint
 	// hasn't changed. This avoids a ChangeResourceRecordSets API call on every reconcile,
 	// which is the primary contributor to Route 53 throttling on MCs with many private HCPs.
-	if meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSRoute53RecordCondition) &&
+	if recordCondition := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSRoute53RecordCondition); recordCondition != nil &&
+		recordCondition.Status == metav1.ConditionTrue &&
+		recordCondition.ObservedGeneration == resource.Generation &&
 		meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSVpcEndpointCondition) {
 		r.log.V(1).Info("Route53 record already created and VPC endpoint unchanged, skipping UPSERT",
 			"vpcEndpoint", resource.Name, "namespace", resource.Namespace)
 		return nil
 	}
 
+	if len(route53Records(resource)) == 0 && len(ownedRoute53Records(resource)) == 0 {
+		// No records are configured or need to be deleted
+		return nil
+	}
+
 	resp, err := r.getHostedZoneCached(ctx, resource.Status.HostedZoneId)
 	if err != nil {
 		return err
 	}
 
-	resourceRecord, err := r.generateRoute53Record(ctx, resource)
+	expected, err := r.generateRoute53Records(ctx, resource, *resp.HostedZone.Name)
 	if err != nil {
+		// The records can't be generated, e.g. before the VPC Endpoint is available or when an AWS call is
+		// throttled, so they must not be skipped as created for the current generation by the next reconcile
 		r.log.V(0).Info("Skipping Route53 Record", "error", err.Error())
+		if meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:               avov1alpha2.AWSRoute53RecordCondition,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: resource.Generation,
+			Reason:             route53RecordsNotGeneratedReason,
+			Message:            err.Error(),
+		}) {
+			if err := r.Status().Update(ctx, resource); err != nil {
+				r.log.V(0).Error(err, "failed to update status")
+				return err
+			}
+		}
 		return nil
 	}
-	if resourceRecord == nil {
+	if expected == nil {
 		r.log.V(0).Info("Skipping Route53 Record: no resource record available")
 		return nil
 	}
 
-	input := &route53Types.ResourceRecordSet{
-		Name:            aws.String(fmt.Sprintf("%s.%s", resource.Spec.CustomDns.Route53PrivateHostedZone.Record.Hostname, strings.TrimRight(*resp.HostedZone.Name, "."))),
-		ResourceRecords: []route53Types.ResourceRecord{*resourceRecord},
-		TTL:             aws.Int64(300),
-		Type:            route53Types.RRTypeCname,
+	if err := r.adoptRoute53Records(ctx, resource, *resp.HostedZone.Id, expected); err != nil {
+		return err
 	}
 
-	if _, err := r.awsClient.UpsertResourceRecordSet(ctx, input, *resp.HostedZone.Id); err != nil {
+	// Records of a different type with the same name as an expected record, e.g. from before its type was changed,
+	// must be replaced instead of upserted, because Route 53 does not allow a CNAME to coexist with other records of
+	// the same name. Owned records that are no longer expected are deleted.
+	owned := ownedRoute53Records(resource)
+	replaced, err := r.findReplacedRoute53Records(ctx, *resp.HostedZone.Id, expected, owned)
+	if err != nil {
 		return err
 	}
-	r.log.V(0).Info("Route53 Hosted Zone Record exists", "domainName", *input.Name)
+	rrsets := make([]route53Types.ResourceRecordSet, len(expected))
+	statuses := make([]avov1alpha2.ResourceRecordStatus, len(expected))
+	names := make([]string, len(expected))
+	for i, record := range expected {
+		rrsets[i] = record.rrs
+		statuses[i] = record.status
+		names[i] = record.status.Name
+	}
+
+	if len(rrsets) > 0 || len(replaced) > 0 {
+		for _, rrs := range replaced {
+			r.log.V(0).Info("Replacing Route53 Hosted Zone Record", "name", *rrs.Name, "type", rrs.Type)
+		}
+		if _, err := r.awsClient.ReplaceResourceRecordSets(ctx, rrsets, replaced, *resp.HostedZone.Id); err != nil {
+			return err
+		}
+	}
+	for _, rrs := range rrsets {
+		r.log.V(0).Info("Route53 Hosted Zone Record exists", "domainName", *rrs.Name, "type", rrs.Type)
+	}
+
+	if len(expected) == 0 {
+		if len(owned) > 0 {
+			r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Deleted", "Deleted Route53 record(s) in hosted zone %s", resource.Status.HostedZoneId)
+		}
+
+		resource.Status.ResourceRecordSet = ""
+		resource.Status.ResourceRecords = nil
+		meta.RemoveStatusCondition(&resource.Status.Conditions, avov1alpha2.AWSRoute53RecordCondition)
+		if err := r.Status().Update(ctx, resource); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+			return err
+		}
+
+		return nil
+	}
 
 	// Record time-to-ready metric on first successful Route53 record creation
 	if !meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSRoute53RecordCondition) {
@@ -450,12 +693,14 @@ func (r *VpcEndpointReconciler) validateR53HostedZoneRecord(ctx context.Context,
 		r.log.V(0).Info("Route53 record ready", "durationSeconds", duration)
 	}
 
-	resource.Status.ResourceRecordSet = *input.Name
+	resource.Status.ResourceRecordSet = names[0]
+	resource.Status.ResourceRecords = statuses
 	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-		Type:    avov1alpha2.AWSRoute53RecordCondition,
-		Status:  metav1.ConditionTrue,
-		Reason:  "Created",
-		Message: fmt.Sprintf("Created: %s", *input.Name),
+		Type:               avov1alpha2.AWSRoute53RecordCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: resource.Generation,
+		Reason:             "Created",
+		Message:            fmt.Sprintf("Created: %s", strings.Join(names, ", ")),
 	})
 	if err := r.Status().Update(ctx, resource); err != nil {
 		r.log.V(0).Error(err, "failed to update status")
@@ -465,95 +710,217 @@ func (r *VpcEndpointReconciler) validateR53HostedZoneRecord(ctx context.Context,
 	return nil
 }
 
-// validateExternalNameService checks if the expected ExternalName service exists, creating or updating it as needed
-func (r *VpcEndpointReconciler) validateExternalNameService(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+// validateExternalNameService checks if the expected ExternalName services exist for the created Route53 Hosted Zone
+// Records, creating or updating them as needed, and deletes the ones that are no longer expected
+func (r *reconcileScope) validateExternalNameService(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
 	if resource == nil {
 		// Should never happen
 		return errors.New("cannot generate ExternalName service: custom resource is nil")
 	}
 
-	if resource.Spec.CustomDns.Route53PrivateHostedZone.Record.Hostname == "" ||
-		resource.Spec.CustomDns.Route53PrivateHostedZone.Record.ExternalNameService.Name == "" {
-		// Fields for generating an externalName service are not set
-		return nil
-	}
-
-	found := &corev1.Service{}
-	expected, err := r.generateExternalNameService(resource)
-	if err != nil {
-		return err
-	}
-
-	err = r.Get(ctx, types.NamespacedName{
-		Name:      resource.Spec.CustomDns.Route53PrivateHostedZone.Record.ExternalNameService.Name,
-		Namespace: resource.Namespace,
-	}, found)
-	if err != nil {
-		if kerr.IsNotFound(err) {
-			// Create the ExternalName service since it's missing
-			r.log.V(0).Info("Creating ExternalName service", "service", expected)
-			if err := r.Create(ctx, expected); err != nil {
-				r.log.V(0).Error(err, "failed to create ExternalName service")
-				return err
-			}
+	expectedServices := map[string]bool{}
+	reason := ""
+	for _, record := range resource.Status.ResourceRecords {
+		if record.ExternalNameService == "" {
+			// Fields for generating an externalName service are not set
+			continue
+		}
+		expectedServices[record.ExternalNameService] = true
 
+		result, err := r.reconcileExternalNameService(ctx, resource, record)
+		if err != nil {
 			meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-				Type:   avov1alpha2.ExternalNameServiceCondition,
-				Status: metav1.ConditionTrue,
-				Reason: "Created",
+				Type:               avov1alpha2.ExternalNameServiceCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "UnknownError",
+				Message:            fmt.Sprintf("Unknown error: %v", err),
+				ObservedGeneration: resource.Generation,
 			})
 			if err := r.Status().Update(ctx, resource); err != nil {
 				r.log.V(0).Error(err, "failed to update status")
 				return err
 			}
 
-			// Requeue, but no error
-			return fmt.Errorf("requeue to validate service")
-		} else {
-			meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-				Type:    avov1alpha2.ExternalNameServiceCondition,
-				Status:  metav1.ConditionFalse,
-				Reason:  "UnknownError",
-				Message: fmt.Sprintf("Unknown error: %v", err),
-			})
-			if err := r.Status().Update(ctx, resource); err != nil {
-				r.log.V(0).Error(err, "failed to update status")
-				return err
+			return err
+		}
+
+		switch result {
+		case controllerutil.OperationResultCreated:
+			reason = "Created"
+		case controllerutil.OperationResultUpdated:
+			if reason == "" {
+				reason = "Reconciled"
 			}
+		}
+	}
 
+	// Only delete ExternalName services once .status.resourceRecords reflects the current spec, so that services
+	// aren't deleted while their records are still being created
+	if recordCondition := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSRoute53RecordCondition); (recordCondition != nil &&
+		recordCondition.Status == metav1.ConditionTrue && recordCondition.ObservedGeneration == resource.Generation) ||
+		len(route53Records(resource)) == 0 {
+		if err := r.deleteUnexpectedExternalNameServices(ctx, resource, expectedServices); err != nil {
 			return err
 		}
 	}
 
+	if reason == "" {
+		return nil
+	}
+
+	meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+		Type:               avov1alpha2.ExternalNameServiceCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		ObservedGeneration: resource.Generation,
+	})
+	if err := r.Status().Update(ctx, resource); err != nil {
+		r.log.V(0).Error(err, "failed to update status")
+		return err
+	}
+
+	if reason == "Created" {
+		// Requeue, but no error
+		return fmt.Errorf("requeue to validate service")
+	}
+
+	return nil
+}
+
+// reconcileExternalNameService creates or updates the ExternalName service pointing to a created Route53 Hosted Zone
+// Record
+func (r *reconcileScope) reconcileExternalNameService(ctx context.Context, resource *avov1alpha2.VpcEndpoint, record avov1alpha2.ResourceRecordStatus) (controllerutil.OperationResult, error) {
+	expected, err := r.generateExternalNameService(resource, record)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	found := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{
+		Name:      record.ExternalNameService,
+		Namespace: resource.Namespace,
+	}, found); err != nil {
+		if !kerr.IsNotFound(err) {
+			return controllerutil.OperationResultNone, err
+		}
+
+		// Create the ExternalName service since it's missing
+		r.log.V(0).Info("Creating ExternalName service", "service", expected)
+		if err := r.Create(ctx, expected); err != nil {
+			r.log.V(0).Error(err, "failed to create ExternalName service")
+			return controllerutil.OperationResultNone, err
+		}
+
+		return controllerutil.OperationResultCreated, nil
+	}
+
 	// The only mutable field we care about is .spec.ExternalName, fix it if it got messed up
-	if found.Spec.ExternalName != expected.Spec.ExternalName {
-		found.Spec.ExternalName = expected.Spec.ExternalName
-		r.log.V(0).Info("Updating ExternalName service", "service", found)
-		if err := r.Update(ctx, found); err != nil {
-			meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-				Type:    avov1alpha2.ExternalNameServiceCondition,
-				Status:  metav1.ConditionFalse,
-				Reason:  "UnknownError",
-				Message: fmt.Sprintf("Unknown error: %v", err),
-			})
-			if err := r.Status().Update(ctx, resource); err != nil {
-				r.log.V(0).Error(err, "failed to update status")
-				return err
-			}
+	if found.Spec.ExternalName == expected.Spec.ExternalName {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	found.Spec.ExternalName = expected.Spec.ExternalName
+	r.log.V(0).Info("Updating ExternalName service", "service", found)
+	if err := r.Update(ctx, found); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	return controllerutil.OperationResultUpdated, nil
+}
+
+// deleteUnexpectedExternalNameServices deletes the ExternalName services controlled by the VpcEndpoint that are not
+// expected, e.g. because their record was removed from the spec
+func (r *reconcileScope) deleteUnexpectedExternalNameServices(ctx context.Context, resource *avov1alpha2.VpcEndpoint, expectedServices map[string]bool) error {
+	services := &corev1.ServiceList{}
+	if err := r.List(ctx, services, client.InNamespace(resource.Namespace)); err != nil {
+		return err
+	}
+
+	for i := range services.Items {
+		svc := &services.Items[i]
+		if svc.Spec.Type != corev1.ServiceTypeExternalName || expectedServices[svc.Name] || !metav1.IsControlledBy(svc, resource) {
+			continue
+		}
 
+		r.log.V(0).Info("Deleting ExternalName service", "service", svc.Name)
+		if err := r.Delete(ctx, svc); err != nil && !kerr.IsNotFound(err) {
 			return err
 		}
+		r.Recorder.Eventf(resource, corev1.EventTypeNormal, "Deleted", "Deleted ExternalName service %s", svc.Name)
+	}
+
+	return nil
+}
+
+// applyRejectionPolicy determines whether a rejected and deleted VPC Endpoint may be recreated according to its
+// RejectionPolicy. It returns a terminal error if it must stay deleted, a requeueAfterError if the backoff
+// hasn't elapsed yet, or nil if it may be recreated now.
+func (r *reconcileScope) applyRejectionPolicy(ctx context.Context, resource *avov1alpha2.VpcEndpoint) error {
+	policy := resource.Spec.RejectionPolicy
+
+	var message string
+	switch {
+	case policy.Action != avov1alpha2.RejectionPolicyRecreate:
+		message = "VPC Endpoint was rejected by the VPC Endpoint Service and will not be recreated"
+	case policy.MaxAttempts > 0 && resource.Status.RecreateAttempts >= policy.MaxAttempts:
+		message = fmt.Sprintf("VPC Endpoint was rejected by the VPC Endpoint Service and will not be recreated after %d attempts", resource.Status.RecreateAttempts)
+	default:
+		var wait time.Duration
+		if resource.Status.LastRejectionTime != nil {
+			wait = time.Until(resource.Status.LastRejectionTime.Add(rejectionBackoff(policy, resource.Status.RecreateAttempts)))
+		}
+		if wait <= 0 {
+			return nil
+		}
 
 		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
-			Type:   avov1alpha2.ExternalNameServiceCondition,
-			Status: metav1.ConditionTrue,
-			Reason: "Reconciled",
+			Type:               avov1alpha2.AWSVpcEndpointCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             vpcEndpointRejectedReason,
+			Message:            fmt.Sprintf("VPC Endpoint was rejected by the VPC Endpoint Service and will be recreated in %s", wait.Round(time.Second)),
+			ObservedGeneration: resource.Generation,
 		})
 		if err := r.Status().Update(ctx, resource); err != nil {
 			r.log.V(0).Error(err, "failed to update status")
 			return err
 		}
+
+		return &requeueAfterError{after: wait, reason: "waiting to recreate rejected VPC Endpoint"}
 	}
 
-	return nil
+	if cond := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSVpcEndpointCondition); cond == nil || cond.Message != message {
+		r.Recorder.Event(resource, corev1.EventTypeWarning, "Rejected", message)
+		meta.SetStatusCondition(&resource.Status.Conditions, metav1.Condition{
+			Type:               avov1alpha2.AWSVpcEndpointCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             vpcEndpointRejectedReason,
+			Message:            message,
+			ObservedGeneration: resource.Generation,
+		})
+		if err := r.Status().Update(ctx, resource); err != nil {
+			r.log.V(0).Error(err, "failed to update status")
+			return err
+		}
+	}
+
+	return reconcile.TerminalError(errors.New(message))
+}
+
+// rejectionBackoff returns the delay after a rejection before a VPC Endpoint is recreated for the given attempt,
+// doubling from the policy's initial backoff with each attempt up to maxRejectionBackoff.
+func rejectionBackoff(policy avov1alpha2.RejectionPolicy, attempts int32) time.Duration {
+	backoff := defaultRejectionInitialBackoff
+	if policy.InitialBackoffSeconds > 0 {
+		backoff = time.Duration(policy.InitialBackoffSeconds) * time.Second
+	}
+
+	for i := int32(0); i < attempts && backoff < maxRejectionBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > maxRejectionBackoff {
+		return maxRejectionBackoff
+	}
+
+	return backoff
 }
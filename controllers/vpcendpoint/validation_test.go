@@ -18,7 +18,9 @@ package vpcendpoint
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr/testr"
 	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
@@ -26,10 +28,12 @@ import (
 	"github.com/openshift/aws-vpce-operator/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func TestVPCEndpointReconciler_validateSecurityGroup(t *testing.T) {
@@ -81,15 +85,17 @@ func TestVPCEndpointReconciler_validateSecurityGroup(t *testing.T) {
 		if test.resource != nil {
 			client = testutil.NewTestMock(t, test.resource).Client
 		}
-		r := &VpcEndpointReconciler{
-			Client:    client,
-			Scheme:    client.Scheme(),
+		r := &reconcileScope{
+			VpcEndpointReconciler: &VpcEndpointReconciler{
+				Client:   client,
+				Scheme:   client.Scheme(),
+				Recorder: record.NewFakeRecorder(1),
+			},
 			awsClient: aws_client.NewMockedAwsClientWithSubnets(),
 			log:       testr.New(t),
 			clusterInfo: &clusterInfo{
 				clusterTag: aws_client.MockLegacyClusterTag,
 			},
-			Recorder: record.NewFakeRecorder(1),
 		}
 
 		t.Run(test.name, func(t *testing.T) {
@@ -107,6 +113,44 @@ func TestVPCEndpointReconciler_validateSecurityGroup(t *testing.T) {
 	}
 }
 
+func TestVPCEndpointReconciler_validateSecurityGroup_managedSecurityGroupDisabled(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mock1",
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			SecurityGroup: avov1alpha2.SecurityGroup{
+				ManagedSecurityGroup: avov1alpha2.ManagedSecurityGroupDisabled,
+				Ids:                  []string{"sg-2", "sg-1"},
+			},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			VPCId:   aws_client.MockVpcId,
+			InfraId: testutil.MockInfrastructureName,
+		},
+	}
+
+	client := testutil.NewTestMock(t, resource).Client
+	ec2Client := &aws_client.MockedEC2{}
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:   client,
+			Scheme:   client.Scheme(),
+			Recorder: record.NewFakeRecorder(1),
+		},
+		awsClient: aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
+		log:       testr.New(t),
+		clusterInfo: &clusterInfo{
+			clusterTag: aws_client.MockLegacyClusterTag,
+		},
+	}
+
+	assert.NoError(t, r.validateSecurityGroup(context.TODO(), resource))
+	assert.Equal(t, []string{"sg-1", "sg-2"}, resource.Status.UserSecurityGroupIds)
+	assert.Empty(t, resource.Status.SecurityGroupId, "no managed security group should be created")
+	assert.True(t, meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSSecurityGroupCondition))
+}
+
 func TestVPCEndpointReconciler_validateVPCEndpoint(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -137,9 +181,11 @@ func TestVPCEndpointReconciler_validateVPCEndpoint(t *testing.T) {
 		if test.resource != nil {
 			client = testutil.NewTestMock(t, test.resource).Client
 		}
-		r := &VpcEndpointReconciler{
-			Client:    client,
-			Scheme:    client.Scheme(),
+		r := &reconcileScope{
+			VpcEndpointReconciler: &VpcEndpointReconciler{
+				Client: client,
+				Scheme: client.Scheme(),
+			},
 			awsClient: aws_client.NewMockedAwsClientWithSubnets(),
 			log:       testr.New(t),
 			clusterInfo: &clusterInfo{
@@ -162,6 +208,171 @@ func TestVPCEndpointReconciler_validateVPCEndpoint(t *testing.T) {
 	}
 }
 
+func TestVPCEndpointReconciler_validateVPCEndpoint_deletesDisabledManagedSecurityGroup(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mock1",
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			SecurityGroup: avov1alpha2.SecurityGroup{
+				ManagedSecurityGroup: avov1alpha2.ManagedSecurityGroupDisabled,
+				Ids:                  []string{"sg-user"},
+			},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			VPCEndpointId:        testutil.MockVpcEndpointId,
+			SecurityGroupId:      aws_client.MockSecurityGroupId,
+			UserSecurityGroupIds: []string{"sg-user"},
+		},
+	}
+
+	client := testutil.NewTestMock(t, resource).Client
+	ec2Client := &aws_client.MockedEC2{}
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:   client,
+			Scheme:   client.Scheme(),
+			Recorder: record.NewFakeRecorder(1),
+		},
+		awsClient: aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
+		log:       testr.New(t),
+		clusterInfo: &clusterInfo{
+			clusterTag: aws_client.MockLegacyClusterTag,
+		},
+	}
+
+	assert.NoError(t, r.validateVPCEndpoint(context.TODO(), resource))
+	assert.Equal(t, []string{aws_client.MockSecurityGroupId}, ec2Client.DeletedSecurityGroupIds)
+	assert.Empty(t, resource.Status.SecurityGroupId)
+}
+
+func TestVPCEndpointReconciler_validateVPCEndpoint_gateway(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mock1",
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			Type: avov1alpha2.VpcEndpointTypeGateway,
+			RouteTables: avov1alpha2.RouteTables{
+				Ids: []string{aws_client.MockRouteTableId},
+			},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			VPCEndpointId: testutil.MockVpcEndpointId,
+		},
+	}
+
+	client := testutil.NewTestMock(t, resource).Client
+	ec2Client := &aws_client.MockedEC2{}
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:   client,
+			Scheme:   client.Scheme(),
+			Recorder: record.NewFakeRecorder(1),
+		},
+		awsClient: aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
+		log:       testr.New(t),
+		clusterInfo: &clusterInfo{
+			clusterTag: aws_client.MockLegacyClusterTag,
+		},
+	}
+
+	assert.NoError(t, r.validateVPCEndpoint(context.TODO(), resource))
+	assert.Len(t, ec2Client.ModifyVpcEndpointInputs, 1)
+	assert.Equal(t, []string{aws_client.MockRouteTableId}, ec2Client.ModifyVpcEndpointInputs[0].AddRouteTableIds)
+	assert.Empty(t, ec2Client.ModifyVpcEndpointInputs[0].AddSubnetIds)
+	assert.Empty(t, ec2Client.ModifyVpcEndpointInputs[0].AddSecurityGroupIds)
+	assert.Equal(t, []string{aws_client.MockRouteTableId}, resource.Status.RouteTableIds)
+	assert.True(t, meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSVpcEndpointCondition))
+}
+
+func TestVPCEndpointReconciler_validateVPCEndpoint_gatewayLoadBalancer(t *testing.T) {
+	tests := []struct {
+		name      string
+		subnetIds []string
+		expectErr bool
+	}{
+		{
+			name:      "subnet unchanged",
+			subnetIds: []string{aws_client.MockPrivateSubnetId},
+		},
+		{
+			name:      "subnet changed",
+			subnetIds: []string{"subnet-changed"},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mock1",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Type: avov1alpha2.VpcEndpointTypeGatewayLoadBalancer,
+					Vpc: avov1alpha2.Vpc{
+						SubnetIds: test.subnetIds,
+					},
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					VPCEndpointId: testutil.MockVpcEndpointId,
+				},
+			}
+
+			client := testutil.NewTestMock(t, resource).Client
+			ec2Client := &aws_client.MockedEC2{}
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:   client,
+					Scheme:   client.Scheme(),
+					Recorder: record.NewFakeRecorder(1),
+				},
+				awsClient: aws_client.NewAwsClientWithServiceClients(ec2Client, &aws_client.MockedRoute53{}),
+				log:       testr.New(t),
+				clusterInfo: &clusterInfo{
+					clusterTag: aws_client.MockLegacyClusterTag,
+				},
+			}
+
+			err := r.validateVPCEndpoint(context.TODO(), resource)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Empty(t, ec2Client.ModifyVpcEndpointInputs)
+			assert.True(t, meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.AWSVpcEndpointCondition))
+		})
+	}
+}
+
+func TestVPCEndpointReconciler_validateSecurityGroup_gateway(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mock1",
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			Type: avov1alpha2.VpcEndpointTypeGateway,
+		},
+	}
+
+	client := testutil.NewTestMock(t, resource).Client
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client: client,
+			Scheme: client.Scheme(),
+		},
+		awsClient: aws_client.NewAwsClientWithServiceClients(&aws_client.MockedEC2{}, &aws_client.MockedRoute53{}),
+		log:       testr.New(t),
+	}
+
+	assert.NoError(t, r.validateSecurityGroup(context.TODO(), resource))
+	assert.Empty(t, resource.Status.SecurityGroupId)
+	assert.Nil(t, meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSSecurityGroupCondition))
+}
+
 func TestVPCEndpointReconciler_validateVPCEndpoint_enablesPrivateDns(t *testing.T) {
 	resource := &avov1alpha2.VpcEndpoint{
 		ObjectMeta: metav1.ObjectMeta{
@@ -176,13 +387,15 @@ func TestVPCEndpointReconciler_validateVPCEndpoint_enablesPrivateDns(t *testing.
 	}
 
 	client := testutil.NewTestMock(t, resource).Client
-	r := &VpcEndpointReconciler{
-		Client:           client,
-		Scheme:           client.Scheme(),
-		awsClient:        aws_client.NewMockedAwsClientWithSubnets(),
-		log:              testr.New(t),
-		EnablePrivateDns: true,
-		Recorder:         record.NewFakeRecorder(1),
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:           client,
+			Scheme:           client.Scheme(),
+			EnablePrivateDns: true,
+			Recorder:         record.NewFakeRecorder(1),
+		},
+		awsClient: aws_client.NewMockedAwsClientWithSubnets(),
+		log:       testr.New(t),
 		clusterInfo: &clusterInfo{
 			clusterTag: aws_client.MockLegacyClusterTag,
 		},
@@ -210,15 +423,17 @@ func TestVPCEndpointReconciler_validateVPCEndpoint_skipsPrivateDnsWhenFlagDisabl
 	}
 
 	client := testutil.NewTestMock(t, resource).Client
-	r := &VpcEndpointReconciler{
-		Client:           client,
-		Scheme:           client.Scheme(),
-		awsClient:        aws_client.NewMockedAwsClientWithSubnets(),
-		log:              testr.New(t),
-		EnablePrivateDns: false, // operator flag off
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:           client,
+			Scheme:           client.Scheme(),
+			EnablePrivateDns: false, // operator flag off
+		},
 		clusterInfo: &clusterInfo{
 			clusterTag: aws_client.MockLegacyClusterTag,
 		},
+		awsClient: aws_client.NewMockedAwsClientWithSubnets(),
+		log:       testr.New(t),
 	}
 
 	err := r.validateVPCEndpoint(context.TODO(), resource)
@@ -274,12 +489,14 @@ func TestVPCEndpointReconciler_validateCustomDns_privateDns(t *testing.T) {
 			}
 
 			client := testutil.NewTestMock(t, resource).Client
-			r := &VpcEndpointReconciler{
-				Client:           client,
-				Scheme:           client.Scheme(),
-				awsClient:        aws_client.NewMockedAwsClient(),
-				log:              testr.New(t),
-				EnablePrivateDns: test.operatorEnablePrivateDns,
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:           client,
+					Scheme:           client.Scheme(),
+					EnablePrivateDns: test.operatorEnablePrivateDns,
+				},
+				awsClient: aws_client.NewMockedAwsClient(),
+				log:       testr.New(t),
 				clusterInfo: &clusterInfo{
 					clusterTag: aws_client.MockLegacyClusterTag,
 				},
@@ -390,6 +607,38 @@ func Test_isVpcEndpointReady(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "ready condition from a previous reconcile is ignored",
+			resource: &avov1alpha2.VpcEndpoint{
+				Status: avov1alpha2.VpcEndpointStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   avov1alpha2.AWSVpcEndpointCondition,
+							Status: metav1.ConditionTrue,
+						},
+						{
+							Type:   avov1alpha2.ReadyCondition,
+							Status: metav1.ConditionFalse,
+						},
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "only a ready condition",
+			resource: &avov1alpha2.VpcEndpoint{
+				Status: avov1alpha2.VpcEndpointStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   avov1alpha2.ReadyCondition,
+							Status: metav1.ConditionTrue,
+						},
+					},
+				},
+			},
+			expected: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -419,9 +668,10 @@ func TestValidateR53HostedZoneRecord_SkipsUpsertWhenConditionsTrue(t *testing.T)
 		},
 	}
 
-	r := &VpcEndpointReconciler{
-		awsClient: aws_client.NewMockedThrottlingAwsClient(),
-		log:       testr.New(t),
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{},
+		awsClient:             aws_client.NewMockedThrottlingAwsClient(),
+		log:                   testr.New(t),
 	}
 
 	// Using the throttling mock: if the guard is removed, this would error with "Throttling".
@@ -431,6 +681,13 @@ func TestValidateR53HostedZoneRecord_SkipsUpsertWhenConditionsTrue(t *testing.T)
 
 func TestValidateR53HostedZoneRecord_DoesNotSkipWhenRecordConditionFalse(t *testing.T) {
 	resource := &avov1alpha2.VpcEndpoint{
+		Spec: avov1alpha2.VpcEndpointSpec{
+			CustomDns: avov1alpha2.CustomDns{
+				Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+					Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "test"},
+				},
+			},
+		},
 		Status: avov1alpha2.VpcEndpointStatus{
 			VPCEndpointId: testutil.MockVpcEndpointId,
 			HostedZoneId:  "Z12345",
@@ -449,9 +706,10 @@ func TestValidateR53HostedZoneRecord_DoesNotSkipWhenRecordConditionFalse(t *test
 		},
 	}
 
-	r := &VpcEndpointReconciler{
-		awsClient: aws_client.NewMockedThrottlingAwsClient(),
-		log:       testr.New(t),
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{},
+		awsClient:             aws_client.NewMockedThrottlingAwsClient(),
+		log:                   testr.New(t),
 	}
 
 	// When the record condition is False, the function should NOT skip.
@@ -463,6 +721,13 @@ func TestValidateR53HostedZoneRecord_DoesNotSkipWhenRecordConditionFalse(t *test
 
 func TestValidateR53HostedZoneRecord_DoesNotSkipWhenVpceConditionFalse(t *testing.T) {
 	resource := &avov1alpha2.VpcEndpoint{
+		Spec: avov1alpha2.VpcEndpointSpec{
+			CustomDns: avov1alpha2.CustomDns{
+				Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+					Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "test"},
+				},
+			},
+		},
 		Status: avov1alpha2.VpcEndpointStatus{
 			VPCEndpointId: testutil.MockVpcEndpointId,
 			HostedZoneId:  "Z12345",
@@ -481,16 +746,203 @@ func TestValidateR53HostedZoneRecord_DoesNotSkipWhenVpceConditionFalse(t *testin
 		},
 	}
 
-	r := &VpcEndpointReconciler{
-		awsClient: aws_client.NewMockedThrottlingAwsClient(),
-		log:       testr.New(t),
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{},
+		awsClient:             aws_client.NewMockedThrottlingAwsClient(),
+		log:                   testr.New(t),
+	}
+
+	err := r.validateR53HostedZoneRecord(context.TODO(), resource)
+	assert.Error(t, err, "expected throttling error, proving skip path was not taken")
+	assert.Contains(t, err.Error(), "Throttling")
+}
+
+func TestValidateR53HostedZoneRecord_DoesNotSkipWhenGenerationChanged(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: 2,
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			CustomDns: avov1alpha2.CustomDns{
+				Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+					Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "test"},
+				},
+			},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			VPCEndpointId: testutil.MockVpcEndpointId,
+			HostedZoneId:  "Z12345",
+			Conditions: []metav1.Condition{
+				{
+					Type:               avov1alpha2.AWSRoute53RecordCondition,
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 1,
+					Reason:             "Created",
+				},
+				{
+					Type:   avov1alpha2.AWSVpcEndpointCondition,
+					Status: metav1.ConditionTrue,
+					Reason: "available",
+				},
+			},
+		},
 	}
 
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{},
+		awsClient:             aws_client.NewMockedThrottlingAwsClient(),
+		log:                   testr.New(t),
+	}
+
+	// The record type or TTL may have changed, so the record must be upserted again
 	err := r.validateR53HostedZoneRecord(context.TODO(), resource)
 	assert.Error(t, err, "expected throttling error, proving skip path was not taken")
 	assert.Contains(t, err.Error(), "Throttling")
 }
 
+func TestValidateR53HostedZoneRecord_RecordsNotGenerated(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "mock",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			CustomDns: avov1alpha2.CustomDns{
+				Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+					Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "test"},
+				},
+			},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			// Without the VPC Endpoint's ID, the expected records can't be generated
+			HostedZoneId: "Z12345",
+			Conditions: []metav1.Condition{
+				{
+					Type:               avov1alpha2.AWSRoute53RecordCondition,
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 1,
+					Reason:             "Created",
+				},
+				{
+					Type:   avov1alpha2.AWSVpcEndpointCondition,
+					Status: metav1.ConditionTrue,
+					Reason: "available",
+				},
+			},
+		},
+	}
+
+	client := testutil.NewTestMock(t, resource).Client
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client: client,
+		},
+		awsClient:       aws_client.NewMockedAwsClient(),
+		log:             testr.New(t),
+		hostedZoneCache: map[string]*hostedZoneCacheEntry{},
+	}
+
+	// The record condition isn't left True for a generation whose records weren't upserted, so that the next
+	// reconcile doesn't skip them
+	assert.NoError(t, r.validateR53HostedZoneRecord(context.TODO(), resource))
+	cond := meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSRoute53RecordCondition)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		assert.Equal(t, route53RecordsNotGeneratedReason, cond.Reason)
+		assert.Equal(t, int64(2), cond.ObservedGeneration)
+	}
+	assert.False(t, isVpcEndpointReady(resource))
+}
+
+func TestVpcEndpointReconciler_validateExternalNameService(t *testing.T) {
+	resource := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "demo-vpce",
+			Namespace:  "demo-ns",
+			UID:        "demo-uid",
+			Generation: 1,
+		},
+		Spec: avov1alpha2.VpcEndpointSpec{
+			CustomDns: avov1alpha2.CustomDns{
+				Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+					Record: avov1alpha2.Route53HostedZoneRecord{
+						Hostname:            "api",
+						ExternalNameService: avov1alpha2.ExternalNameService{Name: "api"},
+					},
+					Records: []avov1alpha2.Route53HostedZoneRecord{
+						{Hostname: "*.apps"},
+						{Hostname: "oauth", ExternalNameService: avov1alpha2.ExternalNameService{Name: "oauth"}},
+					},
+				},
+			},
+		},
+		Status: avov1alpha2.VpcEndpointStatus{
+			ResourceRecords: []avov1alpha2.ResourceRecordStatus{
+				{Hostname: "api", Name: "api.example.com", Type: "CNAME", ExternalNameService: "api"},
+				{Hostname: "*.apps", Name: "*.apps.example.com", Type: "CNAME"},
+				{Hostname: "oauth", Name: "oauth.example.com", Type: "CNAME", ExternalNameService: "oauth"},
+			},
+			Conditions: []metav1.Condition{
+				{
+					Type:               avov1alpha2.AWSRoute53RecordCondition,
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: 1,
+					Reason:             "Created",
+				},
+			},
+		},
+	}
+
+	trueBool := true
+	ownerReferences := []metav1.OwnerReference{
+		{
+			APIVersion:         "avo.openshift.io/v1alpha2",
+			Kind:               "VpcEndpoint",
+			Name:               resource.Name,
+			UID:                resource.UID,
+			Controller:         &trueBool,
+			BlockOwnerDeletion: &trueBool,
+		},
+	}
+	// A service for a record that was removed from the spec, and a service not owned by the VpcEndpoint
+	removed := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "removed", Namespace: resource.Namespace, OwnerReferences: ownerReferences},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "removed.example.com"},
+	}
+	unowned := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "unowned", Namespace: resource.Namespace},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "unowned.example.com"},
+	}
+
+	mock := testutil.NewTestMock(t, resource, removed, unowned)
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:   mock.Client,
+			Scheme:   mock.Client.Scheme(),
+			Recorder: record.NewFakeRecorder(10),
+		},
+		log: testr.New(t),
+	}
+
+	// Creating services requeues
+	assert.Error(t, r.validateExternalNameService(context.TODO(), resource))
+	assert.NoError(t, r.validateExternalNameService(context.TODO(), resource))
+	assert.True(t, meta.IsStatusConditionTrue(resource.Status.Conditions, avov1alpha2.ExternalNameServiceCondition))
+
+	services := &corev1.ServiceList{}
+	assert.NoError(t, mock.Client.List(context.TODO(), services))
+	externalNames := map[string]string{}
+	for _, svc := range services.Items {
+		externalNames[svc.Name] = svc.Spec.ExternalName
+	}
+	assert.Equal(t, map[string]string{
+		"api":     "api.example.com",
+		"oauth":   "oauth.example.com",
+		"unowned": "unowned.example.com",
+	}, externalNames)
+}
+
 func TestInvalidateRoute53RecordCondition(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -534,8 +986,9 @@ func TestInvalidateRoute53RecordCondition(t *testing.T) {
 				},
 			}
 
-			r := &VpcEndpointReconciler{
-				log: testr.New(t),
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{},
+				log:                   testr.New(t),
 			}
 
 			r.invalidateRoute53RecordCondition(resource)
@@ -573,8 +1026,9 @@ func TestInvalidateRoute53RecordCondition_AlsoResetsTagsCondition(t *testing.T)
 		},
 	}
 
-	r := &VpcEndpointReconciler{
-		log: testr.New(t),
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{},
+		log:                   testr.New(t),
 	}
 
 	r.invalidateRoute53RecordCondition(resource)
@@ -614,9 +1068,10 @@ func TestValidateR53PrivateHostedZone_SkipsTagsWhenConditionTrue(t *testing.T) {
 		},
 	}
 
-	r := &VpcEndpointReconciler{
-		awsClient: aws_client.NewMockedThrottlingAwsClient(),
-		log:       testr.New(t),
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{},
+		awsClient:             aws_client.NewMockedThrottlingAwsClient(),
+		log:                   testr.New(t),
 		clusterInfo: &clusterInfo{
 			clusterTag: aws_client.MockLegacyClusterTag,
 			region:     "us-east-1",
@@ -656,9 +1111,12 @@ func TestValidateR53PrivateHostedZone_CallsTagsWhenConditionFalse(t *testing.T)
 	}
 
 	client := testutil.NewTestMock(t, resource).Client
-	r := &VpcEndpointReconciler{
-		Client:    client,
-		Scheme:    client.Scheme(),
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client:   client,
+			Scheme:   client.Scheme(),
+			Recorder: record.NewFakeRecorder(1),
+		},
 		awsClient: aws_client.NewMockedAwsClient(),
 		log:       testr.New(t),
 		clusterInfo: &clusterInfo{
@@ -681,6 +1139,74 @@ func TestValidateR53PrivateHostedZone_CallsTagsWhenConditionFalse(t *testing.T)
 	assert.Equal(t, metav1.ConditionTrue, tagsCond.Status, "AWSRoute53TagsCondition should be set to True after tag verification")
 }
 
+func TestVpcEndpointReconciler_validateTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		conditions  []metav1.Condition
+		statusTags  map[string]string
+		defaultTags map[string]string
+		specTags    map[string]string
+		expected    map[string]string
+	}{
+		{
+			name:       "records tags once the VPC Endpoint is available",
+			conditions: []metav1.Condition{{Type: avov1alpha2.AWSVpcEndpointCondition, Status: metav1.ConditionTrue, Reason: "available"}},
+			statusTags: map[string]string{"team": "sre"},
+			defaultTags: map[string]string{
+				"cost-center": "1234",
+				"owner":       "sre",
+			},
+			specTags: map[string]string{"cost-center": "5678"},
+			expected: map[string]string{"cost-center": "5678", "owner": "sre"},
+		},
+		{
+			name:       "clears removed tags",
+			conditions: []metav1.Condition{{Type: avov1alpha2.AWSVpcEndpointCondition, Status: metav1.ConditionTrue, Reason: "available"}},
+			statusTags: map[string]string{"team": "sre"},
+		},
+		{
+			name:       "keeps tags until the VPC Endpoint is available",
+			conditions: []metav1.Condition{{Type: avov1alpha2.AWSVpcEndpointCondition, Status: metav1.ConditionFalse, Reason: "pendingAcceptance"}},
+			statusTags: map[string]string{"team": "sre"},
+			specTags:   map[string]string{"cost-center": "5678"},
+			expected:   map[string]string{"team": "sre"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mock-tags",
+					Namespace: "mock-namespace",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					Tags: test.specTags,
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					Conditions: test.conditions,
+					Tags:       test.statusTags,
+				},
+			}
+			client := testutil.NewTestMock(t, resource).Client
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:      client,
+					Scheme:      client.Scheme(),
+					DefaultTags: test.defaultTags,
+				},
+				log: testr.New(t),
+			}
+
+			assert.NoError(t, r.validateTags(context.TODO(), resource))
+
+			updated := &avov1alpha2.VpcEndpoint{}
+			assert.NoError(t, r.Get(context.TODO(), ctrlclient.ObjectKeyFromObject(resource), updated))
+			assert.Equal(t, test.expected, updated.Status.Tags)
+		})
+	}
+}
+
 func TestFindOrCreatePrivateHostedZone_ReusesExistingZone(t *testing.T) {
 	resource := &avov1alpha2.VpcEndpoint{
 		ObjectMeta: metav1.ObjectMeta{
@@ -699,9 +1225,11 @@ func TestFindOrCreatePrivateHostedZone_ReusesExistingZone(t *testing.T) {
 	}
 
 	client := testutil.NewTestMock(t, resource).Client
-	r := &VpcEndpointReconciler{
-		Client:    client,
-		Scheme:    client.Scheme(),
+	r := &reconcileScope{
+		VpcEndpointReconciler: &VpcEndpointReconciler{
+			Client: client,
+			Scheme: client.Scheme(),
+		},
 		awsClient: aws_client.NewMockedAwsClient(),
 		log:       testr.New(t),
 		clusterInfo: &clusterInfo{
@@ -857,3 +1385,97 @@ func TestFindOrCreatePrivateHostedZone_ReusesExistingZone(t *testing.T) {
 //		})
 //	}
 //}
+
+func TestVpcEndpointReconciler_applyRejectionPolicy(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           avov1alpha2.RejectionPolicy
+		recreateAttempts int32
+		lastRejection    time.Duration
+		expectTerminal   bool
+		expectRequeue    bool
+	}{
+		{
+			name:           "stay deleted",
+			policy:         avov1alpha2.RejectionPolicy{Action: avov1alpha2.RejectionPolicyStayDeleted},
+			expectTerminal: true,
+		},
+		{
+			name:           "unset action stays deleted",
+			expectTerminal: true,
+		},
+		{
+			name:          "recreate waits for backoff",
+			policy:        avov1alpha2.RejectionPolicy{Action: avov1alpha2.RejectionPolicyRecreate, InitialBackoffSeconds: 60},
+			lastRejection: 30 * time.Second,
+			expectRequeue: true,
+		},
+		{
+			name:          "recreate after backoff",
+			policy:        avov1alpha2.RejectionPolicy{Action: avov1alpha2.RejectionPolicyRecreate, InitialBackoffSeconds: 60},
+			lastRejection: 2 * time.Minute,
+		},
+		{
+			name:             "recreate backoff grows with attempts",
+			policy:           avov1alpha2.RejectionPolicy{Action: avov1alpha2.RejectionPolicyRecreate, InitialBackoffSeconds: 60},
+			recreateAttempts: 2,
+			lastRejection:    2 * time.Minute,
+			expectRequeue:    true,
+		},
+		{
+			name:             "gives up after max attempts",
+			policy:           avov1alpha2.RejectionPolicy{Action: avov1alpha2.RejectionPolicyRecreate, MaxAttempts: 3},
+			recreateAttempts: 3,
+			lastRejection:    24 * time.Hour,
+			expectTerminal:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lastRejection := metav1.NewTime(time.Now().Add(-test.lastRejection))
+			resource := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mock",
+					Namespace: "mock",
+				},
+				Spec: avov1alpha2.VpcEndpointSpec{
+					RejectionPolicy: test.policy,
+				},
+				Status: avov1alpha2.VpcEndpointStatus{
+					RecreateAttempts:  test.recreateAttempts,
+					LastRejectionTime: &lastRejection,
+				},
+			}
+			mock := testutil.NewTestMock(t, resource)
+			r := &reconcileScope{
+				VpcEndpointReconciler: &VpcEndpointReconciler{
+					Client:   mock.Client,
+					Scheme:   mock.Client.Scheme(),
+					Recorder: record.NewFakeRecorder(1),
+				},
+				log: testr.New(t),
+			}
+
+			err := r.applyRejectionPolicy(context.TODO(), resource)
+			var requeueErr *requeueAfterError
+			switch {
+			case test.expectTerminal:
+				assert.True(t, errors.Is(err, reconcile.TerminalError(nil)))
+				assert.Equal(t, vpcEndpointRejectedReason, meta.FindStatusCondition(resource.Status.Conditions, avov1alpha2.AWSVpcEndpointCondition).Reason)
+			case test.expectRequeue:
+				assert.True(t, errors.As(err, &requeueErr))
+				assert.Positive(t, requeueErr.after)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRejectionBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, rejectionBackoff(avov1alpha2.RejectionPolicy{}, 0))
+	assert.Equal(t, 4*time.Minute, rejectionBackoff(avov1alpha2.RejectionPolicy{}, 2))
+	assert.Equal(t, 20*time.Second, rejectionBackoff(avov1alpha2.RejectionPolicy{InitialBackoffSeconds: 10}, 1))
+	assert.Equal(t, time.Hour, rejectionBackoff(avov1alpha2.RejectionPolicy{}, 100))
+}
@@ -22,22 +22,26 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/smithy-go"
 	"github.com/go-logr/logr"
 	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
 	"github.com/openshift/aws-vpce-operator/controllers/util"
 	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
-	"github.com/openshift/aws-vpce-operator/pkg/secrets"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 // VpcEndpointReconciler reconciles a VpcEndpoint object
@@ -51,16 +55,59 @@ type VpcEndpointReconciler struct {
 	// When false, the enablePrivateDns field on VpcEndpoint CRs is ignored.
 	EnablePrivateDns bool
 
+	// DefaultTags are additional AWS tags applied to the AWS resources managed for every VpcEndpoint CR.
+	// Tags in a VpcEndpoint CR's spec.tags take precedence.
+	DefaultTags map[string]string
+
+	// DryRun reconciles every VpcEndpoint CR in dry-run mode, as if it had the avo.openshift.io/dry-run annotation
+	DryRun bool
+
+	// MaxConcurrentReconciles is the maximum number of VpcEndpoint CRs reconciled concurrently, defaults to 1
+	MaxConcurrentReconciles int
+
+	// AWSClientPool shares AWS clients between reconciles, a nil AWSClientPool builds new AWS clients every reconcile
+	AWSClientPool *aws_client.ClientPool
+
+	// awsClientFactory creates the AWS clients used by reconciles instead of aws_client.NewAwsClient, only in tests
+	awsClientFactory func(cfg aws.Config) *aws_client.AWSClient
+}
+
+// newAwsClient returns the AWS client of the given clients
+func (r *VpcEndpointReconciler) newAwsClient(clients *aws_client.Clients) *aws_client.AWSClient {
+	if r.awsClientFactory != nil {
+		return r.awsClientFactory(clients.Config())
+	}
+
+	return clients.AwsClient()
+}
+
+// reconcileScope holds the state of a single reconcile of a VpcEndpoint CR, so that the VpcEndpointReconciler itself
+// is never modified and VpcEndpoint CRs can be reconciled concurrently
+type reconcileScope struct {
+	*VpcEndpointReconciler
+
 	log                    logr.Logger
 	awsClient              *aws_client.AWSClient
 	awsAssociatedVpcClient *aws_client.VpcAssociationClient
 	clusterInfo            *clusterInfo
 
-	// hostedZoneCache stores GetHostedZone responses for the duration of a single reconcile
-	// to avoid duplicate Route53 API calls. Cleared at the start of each Reconcile().
+	// dryRunRecorder records the AWS changes planned during a dry-run reconcile, see reconcileDryRun
+	dryRunRecorder *aws_client.DryRunRecorder
+
+	// hostedZoneCache stores GetHostedZone responses for the duration of the reconcile
+	// to avoid duplicate Route53 API calls
 	hostedZoneCache map[string]*hostedZoneCacheEntry
 }
 
+// newReconcileScope returns the state of a new reconcile of a VpcEndpoint CR
+func (r *VpcEndpointReconciler) newReconcileScope(log logr.Logger) *reconcileScope {
+	return &reconcileScope{
+		VpcEndpointReconciler: r,
+		log:                   log,
+		hostedZoneCache:       map[string]*hostedZoneCacheEntry{},
+	}
+}
+
 // clusterInfo contains naming and AWS information unique to the cluster
 type clusterInfo struct {
 	// clusterTag is the tag that uniquely identifies AWS resources for this cluster
@@ -75,16 +122,20 @@ type clusterInfo struct {
 //+kubebuilder:rbac:groups=avo.openshift.io,resources=vpcendpoints/finalizers,verbs=update
 //+kubebuilder:rbac:groups=config.openshift.io,resources=infrastructures,verbs=get,list
 //+kubebuilder:rbac:groups=config.openshift.io,resources=dnses,verbs=get,list
+//+kubebuilder:rbac:groups=config.openshift.io,resources=networks,verbs=get,list
+//+kubebuilder:rbac:groups="",namespace=kube-system,resources=configmaps,resourceNames=cluster-config-v1,verbs=get
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get
 //+kubebuilder:rbac:groups=v1,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=v1,resources=services/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=hypershift.openshift.io,resources=awsendpointservices,verbs=get;list
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *VpcEndpointReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	r.log = ctrllog.FromContext(ctx).WithName("controller").WithName(ControllerName)
-
-	r.awsClient = nil
+	return r.newReconcileScope(ctrllog.FromContext(ctx).WithName("controller").WithName(ControllerName)).reconcile(ctx, req)
+}
 
+// reconcile reconciles a VpcEndpoint CR with the state of a single reconcile
+func (r *reconcileScope) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	vpce := new(avov1alpha2.VpcEndpoint)
 	if err := r.Get(ctx, req.NamespacedName, vpce); err != nil {
 		// Ignore not-found errors, since they can't be fixed by an immediate
@@ -92,41 +143,38 @@ func (r *VpcEndpointReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	isDeleting := !vpce.DeletionTimestamp.IsZero()
-	if err := r.parseClusterInfo(ctx, vpce, true); err != nil {
-		if isDeleting {
-			// During deletion, HCP resources (HostedControlPlane, AWSEndpointService) may
-			// already be torn down. Attempt cleanup with cached status values if the AWS
-			// client was successfully established.
-			r.log.V(0).Info("parseClusterInfo failed during deletion, attempting cleanup with cached values",
-				"vpcEndpoint", vpce.Name, "namespace", vpce.Namespace, "error", err.Error())
-			vpceCleanupFailure.WithLabelValues("ParseClusterInfoDegraded").Inc()
-			// Re-establish AWS client for this resource if parseClusterInfo failed
-			// before creating one. Cannot reuse a client from a previous reconcile
-			// as it may target a different AWS account or region.
-			if vpce.Spec.AWSCredentialOverrideRef != nil {
-				region := vpce.Spec.Region
-				if region == "" {
-					r.log.V(0).Error(err, "Cannot determine region for AWS client during cleanup: Spec.Region is empty and Infrastructure CR is unavailable")
-					vpceCleanupFailure.WithLabelValues("AWSClientNotEstablished").Inc()
-					return ctrl.Result{}, fmt.Errorf("cannot establish AWS client for cleanup: region unavailable (Infrastructure CR gone and .spec.region not set)")
-				}
-				cfg, credErr := secrets.ParseAWSCredentialOverride(ctx, r.APIReader, region, vpce.Spec.AWSCredentialOverrideRef)
-				if credErr != nil {
-					r.log.V(0).Error(credErr, "Cannot establish AWS client for cleanup")
-					vpceCleanupFailure.WithLabelValues("AWSClientNotEstablished").Inc()
-					return ctrl.Result{}, credErr
-				}
-				r.awsClient = aws_client.NewAwsClient(cfg)
-			} else if r.awsClient == nil {
-				r.log.V(0).Error(err, "Cannot clean up AWS resources: AWS client not established")
-				vpceCleanupFailure.WithLabelValues("AWSClientNotEstablished").Inc()
-				return ctrl.Result{}, err
-			}
-		} else {
+	// A paused VpcEndpoint is checked first, so that neither reconciliation nor cleanup changes anything in AWS
+	if isPaused(vpce) {
+		return r.reconcilePaused(ctx, vpce)
+	}
+	if err := r.clearPaused(ctx, vpce); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// A VpcEndpoint in dry-run mode neither gets a finalizer nor loses it, its planned changes are only reported.
+	// The status written while loading the cluster info is part of the plan too, so it's written to a copy of the
+	// CR with dryRun=All.
+	dryRun := r.DryRun || isDryRun(vpce)
+	scope, planned := r, vpce
+	if dryRun {
+		scope, planned = r.dryRunScope(), vpce.DeepCopy()
+	}
+	if err := scope.loadClusterInfo(ctx, planned); err != nil {
+		if vpce.DeletionTimestamp.IsZero() {
 			awsUnauthorizedOperationMetricHandler(err)
-			return ctrl.Result{}, err
+			if statusErr := r.updateReadyCondition(ctx, vpce, err); statusErr != nil {
+				r.log.V(0).Error(statusErr, "failed to update the Ready condition")
+			}
 		}
+		return ctrl.Result{}, err
+	}
+	r.clusterInfo, r.awsClient = scope.clusterInfo, scope.awsClient
+
+	if dryRun {
+		return r.reconcileDryRun(ctx, vpce, planned)
+	}
+	if err := r.clearPlannedChanges(ctx, vpce); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	if vpce.DeletionTimestamp.IsZero() {
@@ -187,15 +235,23 @@ func (r *VpcEndpointReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
-	if err := r.validateResources(ctx, vpce,
-		[]Validation{
-			r.validateSecurityGroup,
-			r.validateVPCEndpoint,
-			r.validateCustomDns,
-		}); err != nil {
+	err := r.validateResources(ctx, vpce, r.validations())
+	if statusErr := r.updateReadyCondition(ctx, vpce, err); statusErr != nil {
+		if err == nil {
+			return ctrl.Result{}, statusErr
+		}
+		r.log.V(0).Error(statusErr, "failed to update the Ready condition")
+	}
+	if err != nil {
 		awsUnauthorizedOperationMetricHandler(err)
 		vpceNotReadySeconds.WithLabelValues(vpce.Name, vpce.Namespace).Set(time.Since(vpce.CreationTimestamp.Time).Seconds())
 
+		var requeueErr *requeueAfterError
+		if errors.As(err, &requeueErr) {
+			r.log.V(0).Info(requeueErr.reason, "vpcEndpoint", vpce.Name, "namespace", vpce.Namespace, "requeueAfter", requeueErr.after)
+			return ctrl.Result{RequeueAfter: requeueErr.after}, nil
+		}
+
 		// When the VPC endpoint is available but the DNS record hasn't been created yet,
 		// use a fixed 1-minute retry instead of exponential backoff (which grows to 83 minutes).
 		// This only applies when the failure is in the DNS/R53 validation stage, not when
@@ -219,15 +275,91 @@ func (r *VpcEndpointReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{RequeueAfter: time.Minute * 15}, nil
 }
 
+// loadClusterInfo parses the cluster info and gets the AWS client for the VpcEndpoint CR. During deletion, the cluster
+// info may already be gone, so the cached status values are used as long as an AWS client can be established.
+func (r *reconcileScope) loadClusterInfo(ctx context.Context, vpce *avov1alpha2.VpcEndpoint) error {
+	err := r.parseClusterInfo(ctx, vpce, true)
+	if err == nil || vpce.DeletionTimestamp.IsZero() {
+		return err
+	}
+
+	// During deletion, HCP resources (HostedControlPlane, AWSEndpointService) may
+	// already be torn down. Attempt cleanup with cached status values if the AWS
+	// client was successfully established.
+	r.log.V(0).Info("parseClusterInfo failed during deletion, attempting cleanup with cached values",
+		"vpcEndpoint", vpce.Name, "namespace", vpce.Namespace, "error", err.Error())
+	vpceCleanupFailure.WithLabelValues("ParseClusterInfoDegraded").Inc()
+	// Re-establish AWS client for this resource if parseClusterInfo failed
+	// before creating one. Cannot reuse a client from a previous reconcile
+	// as it may target a different AWS account or region.
+	if vpce.Spec.AWSCredentialOverrideRef != nil || vpce.Spec.AssumeRoleArn != "" {
+		region := vpce.Spec.Region
+		if region == "" {
+			r.log.V(0).Error(err, "Cannot determine region for AWS client during cleanup: Spec.Region is empty and Infrastructure CR is unavailable")
+			vpceCleanupFailure.WithLabelValues("AWSClientNotEstablished").Inc()
+			return fmt.Errorf("cannot establish AWS client for cleanup: region unavailable (Infrastructure CR gone and .spec.region not set)")
+		}
+		clients, credErr := r.loadAWSClients(ctx, vpce, region)
+		if credErr != nil {
+			r.log.V(0).Error(credErr, "Cannot establish AWS client for cleanup")
+			vpceCleanupFailure.WithLabelValues("AWSClientNotEstablished").Inc()
+			return credErr
+		}
+		r.awsClient = r.newAwsClient(clients)
+	} else if r.awsClient == nil {
+		r.log.V(0).Error(err, "Cannot clean up AWS resources: AWS client not established")
+		vpceCleanupFailure.WithLabelValues("AWSClientNotEstablished").Inc()
+		return err
+	}
+
+	return nil
+}
+
+// validations returns the validations run for a VpcEndpoint CR that isn't being deleted, in order
+func (r *reconcileScope) validations() []Validation {
+	return []Validation{
+		r.validateSecurityGroup,
+		r.validateVPCEndpoint,
+		r.validateCustomDns,
+		r.validateTags,
+	}
+}
+
+// isStatusOnlyUpdate returns true if a VpcEndpoint CR update only changed its status, i.e. its generation, labels,
+// annotations, finalizers, owner references and deletion timestamp are unchanged
+func isStatusOnlyUpdate(oldObj, newObj client.Object) bool {
+	oldVpce, ok := oldObj.(*avov1alpha2.VpcEndpoint)
+	if !ok {
+		return false
+	}
+	newVpce, ok := newObj.(*avov1alpha2.VpcEndpoint)
+	if !ok {
+		return false
+	}
+
+	return oldVpce.Generation == newVpce.Generation &&
+		equality.Semantic.DeepEqual(oldVpce.Labels, newVpce.Labels) &&
+		equality.Semantic.DeepEqual(oldVpce.Annotations, newVpce.Annotations) &&
+		equality.Semantic.DeepEqual(oldVpce.Finalizers, newVpce.Finalizers) &&
+		equality.Semantic.DeepEqual(oldVpce.OwnerReferences, newVpce.OwnerReferences) &&
+		oldVpce.DeletionTimestamp.Equal(newVpce.DeletionTimestamp)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *VpcEndpointReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.APIReader = mgr.GetAPIReader()
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&avov1alpha2.VpcEndpoint{}).
+		// The reconcile's own status updates, e.g. .status.lastReconcileTime, must not trigger another reconcile
+		For(&avov1alpha2.VpcEndpoint{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return !isStatusOnlyUpdate(e.ObjectOld, e.ObjectNew)
+			},
+		})).
 		Owns(&corev1.Service{}).
 		WithOptions(controller.Options{
-			RateLimiter: util.DefaultAVORateLimiter(),
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+			RateLimiter:             util.DefaultAVORateLimiter(),
 		}).
 		Complete(r)
 }
@@ -0,0 +1,183 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+)
+
+// TestVpcEndpointReconciler_Reconcile_concurrent reconciles several VpcEndpoint CRs in parallel with the same
+// reconciler, as with MaxConcurrentReconciles > 1. Run with -race to detect state shared between reconciles.
+func TestVpcEndpointReconciler_Reconcile_concurrent(t *testing.T) {
+	const count = 8
+
+	objs := []client.Object{
+		&configv1.Infrastructure{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Status: configv1.InfrastructureStatus{
+				InfrastructureName: testutil.MockInfrastructureName,
+				PlatformStatus: &configv1.PlatformStatus{
+					Type: configv1.AWSPlatformType,
+					AWS:  &configv1.AWSPlatformStatus{Region: testutil.MockAWSRegion},
+				},
+			},
+		},
+	}
+	for i := 0; i < count; i++ {
+		objs = append(objs, &avov1alpha2.VpcEndpoint{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       fmt.Sprintf("mock-%d", i),
+				Namespace:  "default",
+				Generation: 1,
+			},
+			Spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: aws_client.MockVpcEndpointServiceName,
+				SecurityGroup: avov1alpha2.SecurityGroup{
+					ManagedSecurityGroup: avov1alpha2.ManagedSecurityGroupDisabled,
+				},
+				Vpc: avov1alpha2.Vpc{
+					SubnetIds: []string{aws_client.MockPrivateSubnetId},
+				},
+			},
+		})
+	}
+
+	kubeClient := testutil.NewTestMock(t, objs...).Client
+	r := &VpcEndpointReconciler{
+		Client:    kubeClient,
+		APIReader: kubeClient,
+		Scheme:    kubeClient.Scheme(),
+		Recorder:  record.NewFakeRecorder(100 * count),
+		// Each reconcile gets its own mocks, so that only the reconciler's state is shared
+		awsClientFactory: func(aws.Config) *aws_client.AWSClient {
+			return aws_client.NewMockedAwsClientWithSubnets()
+		},
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: fmt.Sprintf("mock-%d", i), Namespace: "default"}}
+			// Reconcile twice, the first reconcile adds the finalizer and creates the VPC Endpoint
+			for j := 0; j < 2; j++ {
+				if _, err := r.Reconcile(context.TODO(), req); err != nil {
+					errs[i] = err
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < count; i++ {
+		assert.NoError(t, errs[i])
+
+		actual := &avov1alpha2.VpcEndpoint{}
+		assert.NoError(t, kubeClient.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("mock-%d", i), Namespace: "default"}, actual))
+		assert.Equal(t, []string{avoFinalizer}, actual.Finalizers)
+		assert.Equal(t, testutil.MockInfrastructureName, actual.Status.InfraId)
+		assert.Equal(t, aws_client.MockVpcEndpointServiceName, actual.Status.VPCEndpointServiceName)
+		assert.True(t, meta.IsStatusConditionTrue(actual.Status.Conditions, avov1alpha2.ReadyCondition))
+	}
+}
+
+func TestIsStatusOnlyUpdate(t *testing.T) {
+	old := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "mock",
+			Namespace:       "default",
+			Generation:      1,
+			ResourceVersion: "1",
+			Labels:          map[string]string{"app": "mock"},
+			Finalizers:      []string{avoFinalizer},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		update   func(vpce *avov1alpha2.VpcEndpoint)
+		expected bool
+	}{
+		{
+			name: "status",
+			update: func(vpce *avov1alpha2.VpcEndpoint) {
+				vpce.Status.LastReconcileTime = &metav1.Time{Time: time.Now()}
+			},
+			expected: true,
+		},
+		{
+			name: "spec",
+			update: func(vpce *avov1alpha2.VpcEndpoint) {
+				vpce.Generation++
+			},
+		},
+		{
+			name: "labels",
+			update: func(vpce *avov1alpha2.VpcEndpoint) {
+				vpce.Labels["app"] = "other"
+			},
+		},
+		{
+			name: "annotations",
+			update: func(vpce *avov1alpha2.VpcEndpoint) {
+				vpce.Annotations = map[string]string{avov1alpha2.DryRunAnnotation: "true"}
+			},
+		},
+		{
+			name: "finalizers",
+			update: func(vpce *avov1alpha2.VpcEndpoint) {
+				vpce.Finalizers = nil
+			},
+		},
+		{
+			name: "deletion",
+			update: func(vpce *avov1alpha2.VpcEndpoint) {
+				vpce.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			updated := old.DeepCopy()
+			updated.ResourceVersion = "2"
+			test.update(updated)
+			assert.Equal(t, test.expected, isStatusOnlyUpdate(old, updated))
+		})
+	}
+}
@@ -18,6 +18,8 @@ package vpcendpointacceptance
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -31,7 +33,9 @@ import (
 	"github.com/openshift/aws-vpce-operator/controllers/util"
 	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -41,7 +45,14 @@ import (
 // VpcEndpointAcceptanceReconciler reconciles a VpcEndpointAcceptance object
 type VpcEndpointAcceptanceReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// DryRun only reports the VPC Endpoint connections that would be accepted as PlannedChange events
+	DryRun bool
+
+	// AWSClientPool shares AWS clients between reconciles, a nil AWSClientPool builds new AWS clients every reconcile
+	AWSClientPool *aws_client.ClientPool
 
 	log       logr.Logger
 	awsClient *aws_client.VpcEndpointAcceptanceAWSClient
@@ -51,6 +62,7 @@ type VpcEndpointAcceptanceReconciler struct {
 //+kubebuilder:rbac:groups=avo.openshift.io,resources=vpcendpointacceptances/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=avo.openshift.io,resources=vpcendpointacceptances/finalizers,verbs=update
 //+kubebuilder:rbac:groups=aws.managed.openshift.io,resources=account,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *VpcEndpointAcceptanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	r.log = ctrllog.FromContext(ctx).WithName("controller").WithName(controllerName)
@@ -72,17 +84,28 @@ func (r *VpcEndpointAcceptanceReconciler) Reconcile(ctx context.Context, req ctr
 
 	// Poll AWS for VPCE's in pendingAcceptance based on vpceAcceptance.spec.serviceIds
 	region := vpceAcceptance.Spec.Region
-	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	clients, err := r.AWSClientPool.Get(ctx, aws_client.ClientPoolKey{
+		Region:           region,
+		CredentialSource: aws_client.DefaultCredentialSource,
+		RoleArn:          vpceAcceptance.Spec.AssumeRoleArn,
+	}, func(ctx context.Context) (aws.Config, error) {
+		cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+		if err != nil {
+			return aws.Config{}, err
+		}
+
+		// If an AssumeRoleArn is specified, sts:AssumeRole to the specified role
+		if len(vpceAcceptance.Spec.AssumeRoleArn) > 0 {
+			cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), vpceAcceptance.Spec.AssumeRoleArn))
+		}
+
+		return cfg, nil
+	})
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	// If an AssumeRoleArn is specified, sts:AssumeRole to the specified role
-	if len(vpceAcceptance.Spec.AssumeRoleArn) > 0 {
-		cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), vpceAcceptance.Spec.AssumeRoleArn))
-	}
-
-	r.awsClient = aws_client.NewVpcEndpointAcceptanceAwsClient(cfg)
+	r.awsClient = clients.VpcEndpointAcceptanceClient()
 
 	// List VPC Endpoint Connections in a pendingAcceptance state
 	connections, err := r.awsClient.GetVpcEndpointConnectionsPendingAcceptance(ctx, vpceAcceptance.Spec.Id)
@@ -119,6 +142,14 @@ func (r *VpcEndpointAcceptanceReconciler) Reconcile(ctx context.Context, req ctr
 	}
 	vpcEndpointAcceptanceQueue.WithLabelValues(vpceAcceptance.Name, vpceAcceptance.Namespace).Set(float64(len(vpceToAccept)))
 
+	if r.DryRun && len(vpceToAccept) > 0 {
+		message := fmt.Sprintf("AcceptVpcEndpointConnections %s: vpcEndpointIds=%s", vpceAcceptance.Spec.Id, strings.Join(vpceToAccept, ","))
+		r.log.V(0).Info("Planned change in dry-run mode", "vpcEndpointAcceptance", vpceAcceptance.Name, "namespace", vpceAcceptance.Namespace, "change", message)
+		r.Recorder.Event(vpceAcceptance, corev1.EventTypeNormal, "PlannedChange", message)
+
+		return ctrl.Result{RequeueAfter: time.Minute * 1}, nil
+	}
+
 	// If valid, accept the VPCE connection
 	if _, err := r.awsClient.AcceptVpcEndpointConnections(ctx, vpceAcceptance.Spec.Id, vpceToAccept...); err != nil {
 		return ctrl.Result{}, err
@@ -157,7 +157,7 @@ func (r *VpcEndpointTemplateReconciler) ValidateVpcEndpointForHostedControlPlane
 				vpce := vpce
 				// Make sure we only keep one matching VpcEnpoint
 				// If we've already found one that's matching, delete the extras
-				if reflect.DeepEqual(vpce.Spec, vpcet.Spec.Template.Spec) {
+				if reflect.DeepEqual(vpce.Spec, templateSpec(&vpce, vpcet)) {
 					found = true
 					continue
 				}
@@ -209,8 +209,9 @@ func (r *VpcEndpointTemplateReconciler) CreateVpcEndpoint(ctx context.Context, v
 
 // ReplaceVpcEndpointSpec effectively does a "kubectl replace" if the provided actual VpcEndpoint doesn't match the vpcet
 func (r *VpcEndpointTemplateReconciler) ReplaceVpcEndpointSpec(ctx context.Context, actual *avov1alpha2.VpcEndpoint, vpcet *avov1alpha2.VpcEndpointTemplate) error {
-	if !reflect.DeepEqual(actual.Spec, vpcet.Spec.Template.Spec) {
-		actual.Spec = *vpcet.Spec.Template.Spec.DeepCopy()
+	expected := templateSpec(actual, vpcet)
+	if !reflect.DeepEqual(actual.Spec, expected) {
+		actual.Spec = expected
 		r.log.V(0).Info("Replacing VpcEndpoint", "namespace", actual.Namespace, "name", actual.Name)
 		if err := r.Update(ctx, actual); err != nil {
 			return err
@@ -220,6 +221,18 @@ func (r *VpcEndpointTemplateReconciler) ReplaceVpcEndpointSpec(ctx context.Conte
 	return nil
 }
 
+// templateSpec returns the spec the provided actual VpcEndpoint should have according to the vpcet. The VpcEndpoint
+// webhook defaults .spec.region when a VpcEndpoint is created without one, so the actual region is kept when the
+// vpcet doesn't set one.
+func templateSpec(actual *avov1alpha2.VpcEndpoint, vpcet *avov1alpha2.VpcEndpointTemplate) avov1alpha2.VpcEndpointSpec {
+	spec := *vpcet.Spec.Template.Spec.DeepCopy()
+	if spec.Region == "" {
+		spec.Region = actual.Spec.Region
+	}
+
+	return spec
+}
+
 // FilterHostedControlPlanes returns a list of all hostedcontrolplane resources in all namespaces.
 // Basically does `oc get hostedcontrolplane -A`
 // TODO: Filter over private hostedcontrolplanes in the future
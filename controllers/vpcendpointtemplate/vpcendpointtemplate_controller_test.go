@@ -111,6 +111,78 @@ func TestCreateVpcEndpoint(t *testing.T) {
 	}
 }
 
+func TestReplaceVpcEndpointSpec(t *testing.T) {
+	tests := []struct {
+		name           string
+		templateSpec   avov1alpha2.VpcEndpointSpec
+		actualSpec     avov1alpha2.VpcEndpointSpec
+		expectedSpec   avov1alpha2.VpcEndpointSpec
+		expectReplaced bool
+	}{
+		{
+			name:         "defaulted region",
+			templateSpec: avov1alpha2.VpcEndpointSpec{ServiceName: "mock-service"},
+			actualSpec:   avov1alpha2.VpcEndpointSpec{ServiceName: "mock-service", Region: "us-east-1"},
+			expectedSpec: avov1alpha2.VpcEndpointSpec{ServiceName: "mock-service", Region: "us-east-1"},
+		},
+		{
+			name:           "changed spec keeps the defaulted region",
+			templateSpec:   avov1alpha2.VpcEndpointSpec{ServiceName: "other-service"},
+			actualSpec:     avov1alpha2.VpcEndpointSpec{ServiceName: "mock-service", Region: "us-east-1"},
+			expectedSpec:   avov1alpha2.VpcEndpointSpec{ServiceName: "other-service", Region: "us-east-1"},
+			expectReplaced: true,
+		},
+		{
+			name:           "changed region",
+			templateSpec:   avov1alpha2.VpcEndpointSpec{ServiceName: "mock-service", Region: "us-west-2"},
+			actualSpec:     avov1alpha2.VpcEndpointSpec{ServiceName: "mock-service", Region: "us-east-1"},
+			expectedSpec:   avov1alpha2.VpcEndpointSpec{ServiceName: "mock-service", Region: "us-west-2"},
+			expectReplaced: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sample",
+					Namespace: "test-ns",
+				},
+				Spec: test.actualSpec,
+			}
+			vpcet := &avov1alpha2.VpcEndpointTemplate{
+				Spec: avov1alpha2.VpcEndpointTemplateSpec{
+					Template: avov1alpha2.VpceTemplateSpec{Spec: test.templateSpec},
+				},
+			}
+
+			c := testutil.NewTestMock(t, actual).Client
+			r := &VpcEndpointTemplateReconciler{
+				Client: c,
+				Scheme: c.Scheme(),
+				log:    testr.New(t),
+			}
+
+			resourceVersion := actual.ResourceVersion
+			if err := r.ReplaceVpcEndpointSpec(context.TODO(), actual, vpcet); err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+
+			vpce := new(avov1alpha2.VpcEndpoint)
+			if err := r.Get(context.TODO(), client.ObjectKeyFromObject(actual), vpce); err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+
+			if !reflect.DeepEqual(test.expectedSpec, vpce.Spec) {
+				t.Errorf("mismatched spec, expected %v, got %v", test.expectedSpec, vpce.Spec)
+			}
+			if replaced := vpce.ResourceVersion != resourceVersion; replaced != test.expectReplaced {
+				t.Errorf("expected replaced to be %v, got %v", test.expectReplaced, replaced)
+			}
+		})
+	}
+}
+
 func TestFilterHostedControlPlanes(t *testing.T) {
 	tests := []struct {
 		name          string
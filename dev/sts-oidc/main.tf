@@ -105,12 +105,21 @@ data "aws_iam_policy_document" "iam_test" {
       # Create and manage security group rules
       "ec2:AuthorizeSecurityGroupIngress",
       "ec2:AuthorizeSecurityGroupEgress",
+      "ec2:RevokeSecurityGroupIngress",
+      "ec2:RevokeSecurityGroupEgress",
       "ec2:DescribeSecurityGroupRules",
       # Create and manage a VPC endpoint
       "ec2:CreateVpcEndpoint",
       "ec2:DeleteVpcEndpoints",
       "ec2:DescribeVpcEndpoints",
+      "ec2:DescribeNetworkInterfaces",
       "ec2:ModifyVpcEndpoint",
+      # Associate gateway VPC endpoints with route tables
+      "ec2:DescribeRouteTables",
+      # Route traffic through GatewayLoadBalancer VPC endpoints
+      "ec2:CreateRoute",
+      "ec2:ReplaceRoute",
+      "ec2:DeleteRoute",
       # Create and manage a Route53 Record
       "route53:ChangeResourceRecordSets",
       "route53:ListHostedZonesByName",
@@ -44,6 +44,8 @@ import (
 	"github.com/openshift/aws-vpce-operator/controllers/vpcendpoint"
 	"github.com/openshift/aws-vpce-operator/controllers/vpcendpointacceptance"
 	"github.com/openshift/aws-vpce-operator/controllers/vpcendpointtemplate"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	vpcendpointwebhook "github.com/openshift/aws-vpce-operator/webhooks/vpcendpoint"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -129,17 +131,60 @@ func main() {
 		ctrlConfig.EnablePrivateDns = &falseBool
 	}
 
+	if ctrlConfig.DryRun == nil {
+		ctrlConfig.DryRun = &falseBool
+	}
+
+	// AWS clients and their credentials are shared by the controllers, keyed by region and credentials
+	awsClientPool := aws_client.NewClientPool()
+
 	if *ctrlConfig.EnableVpcEndpointController {
-		setupLog.Info("starting controller", "controller", vpcendpoint.ControllerName, "enablePrivateDns", *ctrlConfig.EnablePrivateDns)
+		maxConcurrentReconciles := 1
+		if ctrlConfig.VpcEndpointMaxConcurrentReconciles != nil && *ctrlConfig.VpcEndpointMaxConcurrentReconciles > 0 {
+			maxConcurrentReconciles = *ctrlConfig.VpcEndpointMaxConcurrentReconciles
+		}
+
+		setupLog.Info("starting controller", "controller", vpcendpoint.ControllerName, "enablePrivateDns", *ctrlConfig.EnablePrivateDns, "defaultTags", ctrlConfig.DefaultTags, "dryRun", *ctrlConfig.DryRun, "maxConcurrentReconciles", maxConcurrentReconciles)
 		if err = (&vpcendpoint.VpcEndpointReconciler{
-			Client:           mgr.GetClient(),
-			Scheme:           mgr.GetScheme(),
-			Recorder:         mgr.GetEventRecorderFor(vpcendpoint.ControllerName),
-			EnablePrivateDns: *ctrlConfig.EnablePrivateDns,
+			Client:                  mgr.GetClient(),
+			Scheme:                  mgr.GetScheme(),
+			Recorder:                mgr.GetEventRecorderFor(vpcendpoint.ControllerName),
+			EnablePrivateDns:        *ctrlConfig.EnablePrivateDns,
+			DefaultTags:             ctrlConfig.DefaultTags,
+			DryRun:                  *ctrlConfig.DryRun,
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			AWSClientPool:           awsClientPool,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", vpcendpoint.ControllerName)
 			os.Exit(1)
 		}
+
+		if ctrlConfig.EnableGarbageCollector == nil {
+			ctrlConfig.EnableGarbageCollector = &trueBool
+		}
+
+		if ctrlConfig.GarbageCollectorDeleteOrphans == nil {
+			ctrlConfig.GarbageCollectorDeleteOrphans = &falseBool
+		}
+
+		if *ctrlConfig.EnableGarbageCollector {
+			interval := vpcendpoint.DefaultGarbageCollectorInterval
+			if ctrlConfig.GarbageCollectorInterval != nil && ctrlConfig.GarbageCollectorInterval.Duration > 0 {
+				interval = ctrlConfig.GarbageCollectorInterval.Duration
+			}
+
+			setupLog.Info("starting garbage collector", "interval", interval, "deleteOrphans", *ctrlConfig.GarbageCollectorDeleteOrphans)
+			if err = (&vpcendpoint.GarbageCollector{
+				Client:        mgr.GetClient(),
+				Recorder:      mgr.GetEventRecorderFor(vpcendpoint.GarbageCollectorName),
+				Interval:      interval,
+				DeleteOrphans: *ctrlConfig.GarbageCollectorDeleteOrphans && !*ctrlConfig.DryRun,
+				AWSClientPool: awsClientPool,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create garbage collector")
+				os.Exit(1)
+			}
+		}
 	}
 
 	if ctrlConfig.EnableVpcEndpointAcceptanceController == nil {
@@ -147,10 +192,13 @@ func main() {
 	}
 
 	if *ctrlConfig.EnableVpcEndpointAcceptanceController {
-		setupLog.Info("starting controller", "controller", "VpcEndpointAcceptance")
+		setupLog.Info("starting controller", "controller", "VpcEndpointAcceptance", "dryRun", *ctrlConfig.DryRun)
 		if err = (&vpcendpointacceptance.VpcEndpointAcceptanceReconciler{
-			Client: mgr.GetClient(),
-			Scheme: mgr.GetScheme(),
+			Client:        mgr.GetClient(),
+			Scheme:        mgr.GetScheme(),
+			Recorder:      mgr.GetEventRecorderFor("vpcendpointacceptance"),
+			DryRun:        *ctrlConfig.DryRun,
+			AWSClientPool: awsClientPool,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "VpcEndpointAcceptance")
 			os.Exit(1)
@@ -171,6 +219,27 @@ func main() {
 			os.Exit(1)
 		}
 	}
+
+	// Webhooks can be disabled when running the operator locally, where there are no serving certificates
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if ctrlConfig.EnableWebhookAWSValidation == nil {
+			ctrlConfig.EnableWebhookAWSValidation = &falseBool
+		}
+
+		setupLog.Info("starting webhook", "webhook", vpcendpointwebhook.WebhookName, "enableAWSValidation", *ctrlConfig.EnableWebhookAWSValidation)
+		if err = (&vpcendpointwebhook.VpcEndpointWebhook{
+			Client:              mgr.GetClient(),
+			EnableAWSValidation: *ctrlConfig.EnableWebhookAWSValidation,
+		}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", vpcendpointwebhook.WebhookName)
+			os.Exit(1)
+		}
+
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			setupLog.Error(err, "unable to set up webhook ready check")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", util.AWSEnvVarHealtzChecker); err != nil {
@@ -0,0 +1,264 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws_client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+const (
+	// DefaultCredentialSource is the ClientPoolKey.CredentialSource of the controller's default AWS credentials
+	DefaultCredentialSource = "default"
+
+	// clientPoolIdleTimeout is how long a pooled entry that isn't used is kept, e.g. after its VpcEndpoint is deleted
+	clientPoolIdleTimeout = time.Hour
+)
+
+// ClientPoolKey identifies the AWS clients of a ClientPool entry
+type ClientPoolKey struct {
+	// Region is the AWS region of the clients
+	Region string
+
+	// CredentialSource is DefaultCredentialSource or identifies the credential override secret, e.g. by its
+	// namespace and name
+	CredentialSource string
+
+	// SecretResourceVersion is the resourceVersion of the credential override secret, so that the clients are
+	// rebuilt when the secret changes
+	SecretResourceVersion string
+
+	// RoleArn is the IAM role assumed with the source credentials, if any
+	RoleArn string
+
+	// AssumeRoleOptions distinguishes the sessions of the same role, e.g. by external id, session name and tags
+	AssumeRoleOptions string
+}
+
+// sameCredentials returns true if both keys only differ by the credential override secret's resourceVersion
+func (k ClientPoolKey) sameCredentials(other ClientPoolKey) bool {
+	k.SecretResourceVersion = other.SecretResourceVersion
+	return k == other
+}
+
+// ClientPool shares the AWS clients, and their cached credentials, between reconciles and controllers instead of
+// loading the AWS config and assuming roles again on every reconcile. Entries are dropped when the credential
+// override secret changes, when their credentials have expired and when they haven't been used for an hour.
+// It's safe for concurrent use, and a nil ClientPool builds new clients every time.
+type ClientPool struct {
+	mu      sync.Mutex
+	entries map[ClientPoolKey]*Clients
+	now     func() time.Time
+}
+
+// NewClientPool returns an empty ClientPool
+func NewClientPool() *ClientPool {
+	return &ClientPool{
+		entries: map[ClientPoolKey]*Clients{},
+		now:     time.Now,
+	}
+}
+
+// Get returns the pooled clients for the key, calling load to build the AWS config of new clients
+func (p *ClientPool) Get(ctx context.Context, key ClientPoolKey, load func(ctx context.Context) (aws.Config, error)) (*Clients, error) {
+	if p == nil {
+		cfg, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return newClients(cfg, false), nil
+	}
+
+	if c := p.lookup(key); c != nil {
+		return c, nil
+	}
+
+	// Load without holding the lock, loading the AWS config may be slow and other keys shouldn't wait for it
+	cfg, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if c, ok := p.entries[key]; ok {
+		// Another reconcile loaded the same clients concurrently
+		return c, nil
+	}
+	c := newClients(cfg, true)
+	c.lastUsed = p.now()
+	p.entries[key] = c
+
+	return c, nil
+}
+
+// lookup drops the stale entries and returns the pooled clients for the key, if any
+func (p *ClientPool) lookup(key ClientPoolKey) *Clients {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	for k, c := range p.entries {
+		switch {
+		case k == key && c.expired(now):
+			delete(p.entries, k)
+		case k != key && (k.sameCredentials(key) || now.Sub(c.lastUsed) > clientPoolIdleTimeout):
+			// The credential override secret changed, or nothing used the clients in a while
+			delete(p.entries, k)
+		}
+	}
+
+	c, ok := p.entries[key]
+	if !ok {
+		return nil
+	}
+	c.lastUsed = now
+
+	return c
+}
+
+// Invalidate drops the clients for the key, e.g. after their credentials were rejected
+func (p *ClientPool) Invalidate(key ClientPoolKey) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.entries, key)
+}
+
+// Len returns the number of pooled entries
+func (p *ClientPool) Len() int {
+	if p == nil {
+		return 0
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.entries)
+}
+
+// Clients are the AWS clients built from the same AWS config, each built on first use
+type Clients struct {
+	cfg aws.Config
+
+	// lastUsed is guarded by the pool's mutex
+	lastUsed time.Time
+
+	credentials *expiryTrackingProvider
+
+	awsClientOnce        sync.Once
+	awsClient            *AWSClient
+	associationOnce      sync.Once
+	associationClient    *VpcAssociationClient
+	acceptanceClientOnce sync.Once
+	acceptanceClient     *VpcEndpointAcceptanceAWSClient
+}
+
+// newClients returns the Clients for an AWS config, tracking the expiry of its credentials if pooled
+func newClients(cfg aws.Config, pooled bool) *Clients {
+	c := &Clients{}
+	if cfg.Credentials != nil && pooled {
+		c.credentials = &expiryTrackingProvider{CredentialsProvider: cfg.Credentials}
+		cfg.Credentials = c.credentials
+	}
+	c.cfg = cfg
+
+	return c
+}
+
+// Config returns the AWS config the clients are built from
+func (c *Clients) Config() aws.Config {
+	return c.cfg
+}
+
+// AwsClient returns the client used to manage VPC Endpoints
+func (c *Clients) AwsClient() *AWSClient {
+	c.awsClientOnce.Do(func() {
+		c.awsClient = NewAwsClient(c.cfg)
+	})
+
+	return c.awsClient
+}
+
+// VpcAssociationClient returns the client used to associate VPCs with hosted zones
+func (c *Clients) VpcAssociationClient() *VpcAssociationClient {
+	c.associationOnce.Do(func() {
+		c.associationClient = NewVpcAssociationClient(c.cfg)
+	})
+
+	return c.associationClient
+}
+
+// VpcEndpointAcceptanceClient returns the client used to accept VPC Endpoint connections
+func (c *Clients) VpcEndpointAcceptanceClient() *VpcEndpointAcceptanceAWSClient {
+	c.acceptanceClientOnce.Do(func() {
+		c.acceptanceClient = NewVpcEndpointAcceptanceAwsClient(c.cfg)
+	})
+
+	return c.acceptanceClient
+}
+
+// expired returns true once the last credentials retrieved by the clients have expired
+func (c *Clients) expired(now time.Time) bool {
+	if c.credentials == nil {
+		return false
+	}
+
+	return c.credentials.expired(now)
+}
+
+// expiryTrackingProvider records when the credentials it retrieves expire
+type expiryTrackingProvider struct {
+	aws.CredentialsProvider
+
+	mu      sync.Mutex
+	expires time.Time
+}
+
+// Retrieve returns the wrapped provider's credentials and records when they expire
+func (e *expiryTrackingProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	creds, err := e.CredentialsProvider.Retrieve(ctx)
+	if err != nil {
+		return creds, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if creds.CanExpire {
+		e.expires = creds.Expires
+	} else {
+		e.expires = time.Time{}
+	}
+
+	return creds, nil
+}
+
+// expired returns true if the last retrieved credentials can expire and have expired
+func (e *expiryTrackingProvider) expired(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
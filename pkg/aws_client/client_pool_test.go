@@ -0,0 +1,149 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws_client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockCredentialsProvider returns credentials expiring at expires, if set
+type mockCredentialsProvider struct {
+	expires time.Time
+}
+
+func (m mockCredentialsProvider) Retrieve(context.Context) (aws.Credentials, error) {
+	return aws.Credentials{
+		AccessKeyID:     "mock",
+		SecretAccessKey: "mock",
+		CanExpire:       !m.expires.IsZero(),
+		Expires:         m.expires,
+	}, nil
+}
+
+// countingLoader returns a load function for ClientPool.Get that counts how often it's called
+func countingLoader(count *int, provider aws.CredentialsProvider) func(context.Context) (aws.Config, error) {
+	return func(context.Context) (aws.Config, error) {
+		*count++
+		return aws.Config{Region: "us-east-1", Credentials: provider}, nil
+	}
+}
+
+func TestClientPool_Get(t *testing.T) {
+	now := time.Now()
+	pool := NewClientPool()
+	pool.now = func() time.Time { return now }
+
+	key := ClientPoolKey{Region: "us-east-1", CredentialSource: "secret/default/mock", SecretResourceVersion: "1"}
+	loads := 0
+	load := countingLoader(&loads, mockCredentialsProvider{})
+
+	// Clients are reused for the same key
+	first, err := pool.Get(context.TODO(), key, load)
+	assert.NoError(t, err)
+	second, err := pool.Get(context.TODO(), key, load)
+	assert.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Same(t, first.AwsClient(), second.AwsClient())
+	assert.Equal(t, 1, loads)
+
+	// Another region gets its own clients
+	otherRegion := key
+	otherRegion.Region = "us-west-2"
+	_, err = pool.Get(context.TODO(), otherRegion, load)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, loads)
+	assert.Equal(t, 2, pool.Len())
+
+	// A new secret resourceVersion replaces the clients of the previous one
+	updated := key
+	updated.SecretResourceVersion = "2"
+	third, err := pool.Get(context.TODO(), updated, load)
+	assert.NoError(t, err)
+	assert.NotSame(t, first, third)
+	assert.Equal(t, 3, loads)
+	assert.Equal(t, 2, pool.Len())
+
+	// Invalidated clients are loaded again
+	pool.Invalidate(updated)
+	_, err = pool.Get(context.TODO(), updated, load)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, loads)
+
+	// Idle clients are dropped
+	now = now.Add(2 * clientPoolIdleTimeout)
+	_, err = pool.Get(context.TODO(), updated, load)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pool.Len())
+}
+
+func TestClientPool_Get_expiredCredentials(t *testing.T) {
+	now := time.Now()
+	pool := NewClientPool()
+	pool.now = func() time.Time { return now }
+
+	key := ClientPoolKey{Region: "us-east-1", CredentialSource: DefaultCredentialSource, RoleArn: "arn:aws:iam::123456789012:role/mock"}
+	loads := 0
+	load := countingLoader(&loads, mockCredentialsProvider{expires: now.Add(time.Hour)})
+
+	clients, err := pool.Get(context.TODO(), key, load)
+	assert.NoError(t, err)
+	_, err = clients.Config().Credentials.Retrieve(context.TODO())
+	assert.NoError(t, err)
+
+	// Clients are reused until their credentials expire
+	now = now.Add(30 * time.Minute)
+	_, err = pool.Get(context.TODO(), key, load)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, loads)
+
+	now = now.Add(time.Hour)
+	_, err = pool.Get(context.TODO(), key, load)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, loads)
+}
+
+func TestClientPool_Get_loadError(t *testing.T) {
+	pool := NewClientPool()
+	key := ClientPoolKey{Region: "us-east-1", CredentialSource: DefaultCredentialSource}
+
+	_, err := pool.Get(context.TODO(), key, func(context.Context) (aws.Config, error) {
+		return aws.Config{}, errors.New("mock")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 0, pool.Len())
+}
+
+func TestClientPool_Get_nil(t *testing.T) {
+	var pool *ClientPool
+	key := ClientPoolKey{Region: "us-east-1", CredentialSource: DefaultCredentialSource}
+	loads := 0
+	load := countingLoader(&loads, mockCredentialsProvider{})
+
+	first, err := pool.Get(context.TODO(), key, load)
+	assert.NoError(t, err)
+	second, err := pool.Get(context.TODO(), key, load)
+	assert.NoError(t, err)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, 2, loads)
+	pool.Invalidate(key)
+}
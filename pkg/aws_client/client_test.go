@@ -41,21 +41,61 @@ func (m mockAvoEC2API) DescribeSecurityGroupRules(ctx context.Context, params *e
 	panic("implement me")
 }
 
+func (m mockAvoEC2API) RevokeSecurityGroupEgress(ctx context.Context, params *ec2.RevokeSecurityGroupEgressInput, optFns ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m mockAvoEC2API) RevokeSecurityGroupIngress(ctx context.Context, params *ec2.RevokeSecurityGroupIngressInput, optFns ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (m mockAvoEC2API) DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
 	//TODO implement me
 	panic("implement me")
 }
 
+func (m mockAvoEC2API) DescribeNetworkInterfaces(ctx context.Context, params *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (m mockAvoEC2API) DescribeVpcs(ctx context.Context, params *ec2.DescribeVpcsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error) {
 	//TODO implement me
 	panic("implement me")
 }
 
+func (m mockAvoEC2API) DescribeRouteTables(ctx context.Context, params *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m mockAvoEC2API) CreateRoute(ctx context.Context, params *ec2.CreateRouteInput, optFns ...func(*ec2.Options)) (*ec2.CreateRouteOutput, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m mockAvoEC2API) ReplaceRoute(ctx context.Context, params *ec2.ReplaceRouteInput, optFns ...func(*ec2.Options)) (*ec2.ReplaceRouteOutput, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m mockAvoEC2API) DeleteRoute(ctx context.Context, params *ec2.DeleteRouteInput, optFns ...func(*ec2.Options)) (*ec2.DeleteRouteOutput, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (m mockAvoEC2API) CreateTags(ctx context.Context, params *ec2.CreateTagsInput, optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
 	//TODO implement me
 	panic("implement me")
 }
 
+func (m mockAvoEC2API) DeleteTags(ctx context.Context, params *ec2.DeleteTagsInput, optFns ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (m mockAvoEC2API) CreateVpcEndpoint(ctx context.Context, params *ec2.CreateVpcEndpointInput, optFns ...func(*ec2.Options)) (*ec2.CreateVpcEndpointOutput, error) {
 	//TODO implement me
 	panic("implement me")
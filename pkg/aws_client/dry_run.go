@@ -0,0 +1,319 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+)
+
+// ErrDryRun is returned in dry-run mode by the mutations whose result the following changes depend on, e.g. the id of
+// a VPC Endpoint that would be created, so that planning stops there instead of continuing with made-up ids
+var ErrDryRun = errors.New("dry-run: stopped at a change that the rest of the plan depends on")
+
+// DryRunRecorder records the AWS changes that a dry-run AWSClient would have made
+type DryRunRecorder struct {
+	mu      sync.Mutex
+	changes []avov1alpha2.PlannedChange
+}
+
+// NewDryRunRecorder returns an empty DryRunRecorder
+func NewDryRunRecorder() *DryRunRecorder {
+	return &DryRunRecorder{}
+}
+
+// Changes returns the recorded changes in the order they would have been made
+func (d *DryRunRecorder) Changes() []avov1alpha2.PlannedChange {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]avov1alpha2.PlannedChange(nil), d.changes...)
+}
+
+func (d *DryRunRecorder) record(operation, resource, details string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.changes = append(d.changes, avov1alpha2.PlannedChange{
+		Operation: operation,
+		Resource:  resource,
+		Details:   details,
+	})
+}
+
+// NewDryRunAwsClient returns an AWSClient that reads from AWS through c, but records its mutations in recorder
+// instead of making them
+func NewDryRunAwsClient(c *AWSClient, recorder *DryRunRecorder) *AWSClient {
+	return NewAwsClientWithServiceClients(
+		&dryRunEC2{AvoEC2API: c.ec2Client, recorder: recorder},
+		&dryRunRoute53{AvoRoute53API: c.route53Client, recorder: recorder},
+	)
+}
+
+// NewDryRunVpcAssociationClient returns a VpcAssociationClient that records its VPC associations in recorder instead
+// of making them
+func NewDryRunVpcAssociationClient(c *VpcAssociationClient, recorder *DryRunRecorder) *VpcAssociationClient {
+	return NewVpcAssociationClientWithServiceClients(&dryRunVpcAssociation{VpcAssociationAPI: c.route53Client, recorder: recorder})
+}
+
+// dryRunEC2 passes reads through to the embedded AvoEC2API and records mutations
+type dryRunEC2 struct {
+	AvoEC2API
+	recorder *DryRunRecorder
+}
+
+func (d *dryRunEC2) AuthorizeSecurityGroupEgress(_ context.Context, params *ec2.AuthorizeSecurityGroupEgressInput, _ ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	d.recorder.record("AuthorizeSecurityGroupEgress", aws.ToString(params.GroupId), describeIpPermissions(params.IpPermissions))
+	return &ec2.AuthorizeSecurityGroupEgressOutput{}, nil
+}
+
+func (d *dryRunEC2) AuthorizeSecurityGroupIngress(_ context.Context, params *ec2.AuthorizeSecurityGroupIngressInput, _ ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	d.recorder.record("AuthorizeSecurityGroupIngress", aws.ToString(params.GroupId), describeIpPermissions(params.IpPermissions))
+	return &ec2.AuthorizeSecurityGroupIngressOutput{}, nil
+}
+
+func (d *dryRunEC2) CreateSecurityGroup(_ context.Context, params *ec2.CreateSecurityGroupInput, _ ...func(*ec2.Options)) (*ec2.CreateSecurityGroupOutput, error) {
+	d.recorder.record("CreateSecurityGroup", "", fmt.Sprintf("name=%s vpc=%s", aws.ToString(params.GroupName), aws.ToString(params.VpcId)))
+	return nil, ErrDryRun
+}
+
+func (d *dryRunEC2) DeleteSecurityGroup(_ context.Context, params *ec2.DeleteSecurityGroupInput, _ ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error) {
+	d.recorder.record("DeleteSecurityGroup", aws.ToString(params.GroupId), "")
+	return &ec2.DeleteSecurityGroupOutput{}, nil
+}
+
+func (d *dryRunEC2) RevokeSecurityGroupEgress(_ context.Context, params *ec2.RevokeSecurityGroupEgressInput, _ ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	d.recorder.record("RevokeSecurityGroupEgress", aws.ToString(params.GroupId), "rules="+strings.Join(params.SecurityGroupRuleIds, ","))
+	return &ec2.RevokeSecurityGroupEgressOutput{}, nil
+}
+
+func (d *dryRunEC2) RevokeSecurityGroupIngress(_ context.Context, params *ec2.RevokeSecurityGroupIngressInput, _ ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	d.recorder.record("RevokeSecurityGroupIngress", aws.ToString(params.GroupId), "rules="+strings.Join(params.SecurityGroupRuleIds, ","))
+	return &ec2.RevokeSecurityGroupIngressOutput{}, nil
+}
+
+func (d *dryRunEC2) CreateRoute(_ context.Context, params *ec2.CreateRouteInput, _ ...func(*ec2.Options)) (*ec2.CreateRouteOutput, error) {
+	d.recorder.record("CreateRoute", aws.ToString(params.RouteTableId), fmt.Sprintf("destination=%s target=%s",
+		routeDestination(params.DestinationCidrBlock, params.DestinationIpv6CidrBlock), aws.ToString(params.VpcEndpointId)))
+	return &ec2.CreateRouteOutput{}, nil
+}
+
+func (d *dryRunEC2) ReplaceRoute(_ context.Context, params *ec2.ReplaceRouteInput, _ ...func(*ec2.Options)) (*ec2.ReplaceRouteOutput, error) {
+	d.recorder.record("ReplaceRoute", aws.ToString(params.RouteTableId), fmt.Sprintf("destination=%s target=%s",
+		routeDestination(params.DestinationCidrBlock, params.DestinationIpv6CidrBlock), aws.ToString(params.VpcEndpointId)))
+	return &ec2.ReplaceRouteOutput{}, nil
+}
+
+func (d *dryRunEC2) DeleteRoute(_ context.Context, params *ec2.DeleteRouteInput, _ ...func(*ec2.Options)) (*ec2.DeleteRouteOutput, error) {
+	d.recorder.record("DeleteRoute", aws.ToString(params.RouteTableId), "destination="+routeDestination(params.DestinationCidrBlock, params.DestinationIpv6CidrBlock))
+	return &ec2.DeleteRouteOutput{}, nil
+}
+
+func (d *dryRunEC2) CreateTags(_ context.Context, params *ec2.CreateTagsInput, _ ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+	tags := make([]string, 0, len(params.Tags))
+	for _, tag := range params.Tags {
+		tags = append(tags, fmt.Sprintf("%s=%s", aws.ToString(tag.Key), aws.ToString(tag.Value)))
+	}
+	d.recorder.record("CreateTags", strings.Join(params.Resources, ","), "tags="+strings.Join(tags, ","))
+	return &ec2.CreateTagsOutput{}, nil
+}
+
+func (d *dryRunEC2) DeleteTags(_ context.Context, params *ec2.DeleteTagsInput, _ ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
+	keys := make([]string, 0, len(params.Tags))
+	for _, tag := range params.Tags {
+		keys = append(keys, aws.ToString(tag.Key))
+	}
+	d.recorder.record("DeleteTags", strings.Join(params.Resources, ","), "keys="+strings.Join(keys, ","))
+	return &ec2.DeleteTagsOutput{}, nil
+}
+
+func (d *dryRunEC2) CreateVpcEndpoint(_ context.Context, params *ec2.CreateVpcEndpointInput, _ ...func(*ec2.Options)) (*ec2.CreateVpcEndpointOutput, error) {
+	details := fmt.Sprintf("type=%s service=%s vpc=%s", params.VpcEndpointType, aws.ToString(params.ServiceName), aws.ToString(params.VpcId))
+	if len(params.SubnetIds) > 0 {
+		details += " subnets=" + strings.Join(params.SubnetIds, ",")
+	}
+	if len(params.SecurityGroupIds) > 0 {
+		details += " securityGroups=" + strings.Join(params.SecurityGroupIds, ",")
+	}
+	d.recorder.record("CreateVpcEndpoint", "", details)
+	return nil, ErrDryRun
+}
+
+// DeleteVpcEndpoints stops the plan, because AVO waits for a VPC Endpoint to be deleted before deleting its security
+// group
+func (d *dryRunEC2) DeleteVpcEndpoints(_ context.Context, params *ec2.DeleteVpcEndpointsInput, _ ...func(*ec2.Options)) (*ec2.DeleteVpcEndpointsOutput, error) {
+	d.recorder.record("DeleteVpcEndpoints", strings.Join(params.VpcEndpointIds, ","), "")
+	return nil, ErrDryRun
+}
+
+func (d *dryRunEC2) ModifyVpcEndpoint(_ context.Context, params *ec2.ModifyVpcEndpointInput, _ ...func(*ec2.Options)) (*ec2.ModifyVpcEndpointOutput, error) {
+	var details []string
+	for _, field := range []struct {
+		name string
+		ids  []string
+	}{
+		{"addSubnets", params.AddSubnetIds},
+		{"removeSubnets", params.RemoveSubnetIds},
+		{"addSecurityGroups", params.AddSecurityGroupIds},
+		{"removeSecurityGroups", params.RemoveSecurityGroupIds},
+		{"addRouteTables", params.AddRouteTableIds},
+		{"removeRouteTables", params.RemoveRouteTableIds},
+	} {
+		if len(field.ids) > 0 {
+			details = append(details, fmt.Sprintf("%s=%s", field.name, strings.Join(field.ids, ",")))
+		}
+	}
+	if params.PolicyDocument != nil {
+		details = append(details, "policy=updated")
+	}
+	if aws.ToBool(params.ResetPolicy) {
+		details = append(details, "policy=reset")
+	}
+	if params.DnsOptions != nil {
+		details = append(details, fmt.Sprintf("dnsRecordIpType=%s", params.DnsOptions.DnsRecordIpType))
+	}
+	if params.IpAddressType != "" {
+		details = append(details, fmt.Sprintf("ipAddressType=%s", params.IpAddressType))
+	}
+	if params.PrivateDnsEnabled != nil {
+		details = append(details, fmt.Sprintf("privateDnsEnabled=%t", aws.ToBool(params.PrivateDnsEnabled)))
+	}
+	d.recorder.record("ModifyVpcEndpoint", aws.ToString(params.VpcEndpointId), strings.Join(details, " "))
+	return &ec2.ModifyVpcEndpointOutput{Return: aws.Bool(true)}, nil
+}
+
+// dryRunRoute53 passes reads through to the embedded AvoRoute53API and records mutations
+type dryRunRoute53 struct {
+	AvoRoute53API
+	recorder *DryRunRecorder
+}
+
+func (d *dryRunRoute53) ChangeResourceRecordSets(_ context.Context, params *route53.ChangeResourceRecordSetsInput, _ ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+	if params.ChangeBatch != nil {
+		for _, change := range params.ChangeBatch.Changes {
+			if change.ResourceRecordSet == nil {
+				continue
+			}
+			d.recorder.record("ChangeResourceRecordSets", aws.ToString(params.HostedZoneId), fmt.Sprintf("%s %s %s",
+				change.Action, change.ResourceRecordSet.Type, aws.ToString(change.ResourceRecordSet.Name)))
+		}
+	}
+	return &route53.ChangeResourceRecordSetsOutput{ChangeInfo: &route53Types.ChangeInfo{Status: route53Types.ChangeStatusPending}}, nil
+}
+
+func (d *dryRunRoute53) ChangeTagsForResource(_ context.Context, params *route53.ChangeTagsForResourceInput, _ ...func(*route53.Options)) (*route53.ChangeTagsForResourceOutput, error) {
+	var details []string
+	if len(params.AddTags) > 0 {
+		tags := make([]string, 0, len(params.AddTags))
+		for _, tag := range params.AddTags {
+			tags = append(tags, fmt.Sprintf("%s=%s", aws.ToString(tag.Key), aws.ToString(tag.Value)))
+		}
+		details = append(details, "tags="+strings.Join(tags, ","))
+	}
+	if len(params.RemoveTagKeys) > 0 {
+		details = append(details, "removeKeys="+strings.Join(params.RemoveTagKeys, ","))
+	}
+	d.recorder.record("ChangeTagsForResource", aws.ToString(params.ResourceId), strings.Join(details, " "))
+	return &route53.ChangeTagsForResourceOutput{}, nil
+}
+
+func (d *dryRunRoute53) CreateHostedZone(_ context.Context, params *route53.CreateHostedZoneInput, _ ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error) {
+	details := "name=" + aws.ToString(params.Name)
+	if params.VPC != nil {
+		details += " vpc=" + aws.ToString(params.VPC.VPCId)
+	}
+	d.recorder.record("CreateHostedZone", "", details)
+	return nil, ErrDryRun
+}
+
+func (d *dryRunRoute53) CreateVPCAssociationAuthorization(_ context.Context, params *route53.CreateVPCAssociationAuthorizationInput, _ ...func(*route53.Options)) (*route53.CreateVPCAssociationAuthorizationOutput, error) {
+	d.recorder.record("CreateVPCAssociationAuthorization", aws.ToString(params.HostedZoneId), "vpc="+describeRoute53Vpc(params.VPC))
+	return &route53.CreateVPCAssociationAuthorizationOutput{HostedZoneId: params.HostedZoneId, VPC: params.VPC}, nil
+}
+
+func (d *dryRunRoute53) DeleteHostedZone(_ context.Context, params *route53.DeleteHostedZoneInput, _ ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+	d.recorder.record("DeleteHostedZone", aws.ToString(params.Id), "")
+	return &route53.DeleteHostedZoneOutput{ChangeInfo: &route53Types.ChangeInfo{Status: route53Types.ChangeStatusPending}}, nil
+}
+
+// dryRunVpcAssociation records VPC associations instead of making them
+type dryRunVpcAssociation struct {
+	VpcAssociationAPI
+	recorder *DryRunRecorder
+}
+
+func (d *dryRunVpcAssociation) AssociateVPCWithHostedZone(_ context.Context, params *route53.AssociateVPCWithHostedZoneInput, _ ...func(*route53.Options)) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+	d.recorder.record("AssociateVPCWithHostedZone", aws.ToString(params.HostedZoneId), "vpc="+describeRoute53Vpc(params.VPC))
+	return &route53.AssociateVPCWithHostedZoneOutput{ChangeInfo: &route53Types.ChangeInfo{Status: route53Types.ChangeStatusPending}}, nil
+}
+
+// describeIpPermissions summarizes security group rules as protocol/ports from sources, e.g. tcp/443 from 10.0.0.0/16
+func describeIpPermissions(permissions []ec2Types.IpPermission) string {
+	rules := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		var sources []string
+		for _, r := range p.IpRanges {
+			sources = append(sources, aws.ToString(r.CidrIp))
+		}
+		for _, r := range p.Ipv6Ranges {
+			sources = append(sources, aws.ToString(r.CidrIpv6))
+		}
+		for _, r := range p.PrefixListIds {
+			sources = append(sources, aws.ToString(r.PrefixListId))
+		}
+		for _, r := range p.UserIdGroupPairs {
+			sources = append(sources, aws.ToString(r.GroupId))
+		}
+		sort.Strings(sources)
+
+		ports := fmt.Sprintf("%d", aws.ToInt32(p.FromPort))
+		if aws.ToInt32(p.ToPort) != aws.ToInt32(p.FromPort) {
+			ports = fmt.Sprintf("%d-%d", aws.ToInt32(p.FromPort), aws.ToInt32(p.ToPort))
+		}
+		rules = append(rules, fmt.Sprintf("%s/%s from %s", aws.ToString(p.IpProtocol), ports, strings.Join(sources, ",")))
+	}
+
+	return strings.Join(rules, "; ")
+}
+
+func routeDestination(cidr, ipv6Cidr *string) string {
+	if cidr != nil {
+		return aws.ToString(cidr)
+	}
+
+	return aws.ToString(ipv6Cidr)
+}
+
+func describeRoute53Vpc(vpc *route53Types.VPC) string {
+	if vpc == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s", aws.ToString(vpc.VPCId), vpc.VPCRegion)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws_client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/assert"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+)
+
+func TestNewDryRunAwsClient(t *testing.T) {
+	ec2Client := &MockedEC2{}
+	route53Client := &MockedRoute53{}
+	recorder := NewDryRunRecorder()
+	client := NewDryRunAwsClient(NewAwsClientWithServiceClients(ec2Client, route53Client), recorder)
+
+	// Reads are passed through
+	resp, err := client.DescribeSingleVPCEndpointById(context.TODO(), testutil.MockVpcEndpointId)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.VpcEndpoints)
+
+	_, err = client.AuthorizeSecurityGroupRules(context.TODO(), &ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId: aws.String(MockSecurityGroupId),
+		IpPermissions: []types.IpPermission{
+			{
+				IpProtocol: aws.String("tcp"),
+				FromPort:   aws.Int32(443),
+				ToPort:     aws.Int32(443),
+				IpRanges:   []types.IpRange{{CidrIp: aws.String(MockVpcCidr)}},
+			},
+		},
+	}, &ec2.AuthorizeSecurityGroupEgressInput{})
+	assert.NoError(t, err)
+
+	_, err = client.ModifyVpcEndpoint(context.TODO(), &ec2.ModifyVpcEndpointInput{
+		VpcEndpointId: aws.String(testutil.MockVpcEndpointId),
+		AddSubnetIds:  []string{MockPrivateSubnetId},
+	})
+	assert.NoError(t, err)
+
+	_, err = client.UpsertResourceRecordSet(context.TODO(), &route53Types.ResourceRecordSet{
+		Name: aws.String("mock.example.com"),
+		Type: route53Types.RRTypeCname,
+	}, MockHostedZoneId)
+	assert.NoError(t, err)
+
+	// Creating a resource stops the plan, since the following changes depend on its id
+	_, err = client.CreateSecurityGroup(context.TODO(), "mock-sg", MockVpcId, MockLegacyClusterTag, nil)
+	assert.ErrorIs(t, err, ErrDryRun)
+
+	assert.Equal(t, []avov1alpha2.PlannedChange{
+		{Operation: "AuthorizeSecurityGroupIngress", Resource: MockSecurityGroupId, Details: "tcp/443 from " + MockVpcCidr},
+		{Operation: "ModifyVpcEndpoint", Resource: testutil.MockVpcEndpointId, Details: "addSubnets=" + MockPrivateSubnetId},
+		{Operation: "ChangeResourceRecordSets", Resource: MockHostedZoneId, Details: "UPSERT CNAME mock.example.com"},
+		{Operation: "CreateSecurityGroup", Details: "name=mock-sg vpc=" + MockVpcId},
+	}, recorder.Changes())
+
+	// Nothing reached the underlying clients
+	assert.Empty(t, route53Client.ChangeResourceRecordSetsInputs)
+	assert.Nil(t, ec2Client.LastCreateVpcEndpointInput)
+}
+
+func TestNewDryRunVpcAssociationClient(t *testing.T) {
+	recorder := NewDryRunRecorder()
+	// The association is only recorded, so the underlying client is never called
+	client := NewDryRunVpcAssociationClient(NewVpcAssociationClientWithServiceClients(nil), recorder)
+
+	_, err := client.AssociateVPCWithHostedZone(context.TODO(), MockHostedZoneId, MockVpcId, "us-east-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []avov1alpha2.PlannedChange{
+		{Operation: "AssociateVPCWithHostedZone", Resource: MockHostedZoneId, Details: "vpc=" + MockVpcId + "/us-east-1"},
+	}, recorder.Changes())
+}
@@ -19,6 +19,7 @@ package aws_client
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -27,6 +28,7 @@ import (
 	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 	"github.com/aws/smithy-go"
 	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+	"github.com/openshift/aws-vpce-operator/pkg/util"
 )
 
 const (
@@ -36,11 +38,20 @@ const (
 	MockHostedZoneId           = "R53HZ12345"
 	MockPublicSubnetId         = "subnet-pub12345"
 	MockPrivateSubnetId        = "subnet-priv12345"
+	MockRouteTableId           = "rtb-12345"
 	MockSecurityGroupId        = "sg-12345"
 	MockVpcId                  = "vpc-12345"
 	MockVpcEndpointServiceName = "com.amazonaws.vpce.service.mock-12345"
 	MockVpcEndpointServiceId   = "vpce-svc-12345"
 	MockVpcCidr                = "10.0.0.0/16"
+	MockVpcIpv6Cidr            = "2600:1f18:1234:5600::/56"
+	MockVpcEndpointHostedZone  = "Z7HUB22UULQXV"
+	MockNetworkInterfaceId     = "eni-12345"
+	MockNetworkInterfaceIp     = "10.0.1.10"
+	MockNetworkInterfaceIpv6   = "2600:1f18:1234:5601::10"
+	MockAvailabilityZone       = "us-east-1a"
+	MockAvailabilityZoneId     = "use1-az1"
+	MockVpcEndpointZonalDns    = "vpce-12345-us-east-1a.amazonaws.com"
 )
 
 type MockedEC2 struct {
@@ -48,9 +59,52 @@ type MockedEC2 struct {
 
 	Subnets []*ec2Types.Subnet
 
+	// RouteTables are returned by DescribeRouteTables when describing route tables by id or filtering them by VPC id
+	// and tags
+	RouteTables []ec2Types.RouteTable
+
+	// CreateRouteInputs, ReplaceRouteInputs and DeleteRouteInputs capture the CreateRoute, ReplaceRoute and
+	// DeleteRoute call inputs for test assertions
+	CreateRouteInputs  []*ec2.CreateRouteInput
+	ReplaceRouteInputs []*ec2.ReplaceRouteInput
+	DeleteRouteInputs  []*ec2.DeleteRouteInput
+
+	// SecurityGroups, when set, are returned by DescribeSecurityGroups when described by id or when they match the VPC
+	// id and tag filters
+	SecurityGroups []ec2Types.SecurityGroup
+
+	// SecurityGroupRules, when set, replaces the default "pre-existing" rules returned by DescribeSecurityGroupRules
+	SecurityGroupRules []ec2Types.SecurityGroupRule
+
+	// RevokedSecurityGroupRuleIds captures the security group rule ids revoked for test assertions
+	RevokedSecurityGroupRuleIds []string
+
+	// DeletedSecurityGroupIds captures the security group ids deleted for test assertions
+	DeletedSecurityGroupIds []string
+
+	// SecurityGroupExists causes CreateSecurityGroup to fail with InvalidGroup.Duplicate, simulating a retried creation
+	SecurityGroupExists bool
+
+	// CreateTagsInputs and DeleteTagsInputs capture the CreateTags and DeleteTags call inputs for test assertions
+	CreateTagsInputs []*ec2.CreateTagsInput
+	DeleteTagsInputs []*ec2.DeleteTagsInput
+
+	// VpcEndpoints, when set, are returned by DescribeVpcEndpoints when described by id or when they match the tag
+	// filters, and haven't been deleted via DeleteVpcEndpoints
+	VpcEndpoints []ec2Types.VpcEndpoint
+
 	// LastCreateVpcEndpointInput captures the most recent CreateVpcEndpoint call input for test assertions
 	LastCreateVpcEndpointInput *ec2.CreateVpcEndpointInput
 
+	// ModifyVpcEndpointInputs captures the ModifyVpcEndpoint call inputs for test assertions
+	ModifyVpcEndpointInputs []*ec2.ModifyVpcEndpointInput
+
+	// DescribeNetworkInterfacesInputs captures the DescribeNetworkInterfaces call inputs for test assertions
+	DescribeNetworkInterfacesInputs []*ec2.DescribeNetworkInterfacesInput
+
+	// DeletedVpcEndpointIds captures the VPC endpoint ids deleted for test assertions
+	DeletedVpcEndpointIds []string
+
 	// deletedVpceIds tracks VPCE IDs that have been deleted via DeleteVpcEndpoints,
 	// so DescribeVpcEndpoints can return NotFound for them.
 	deletedVpceIds map[string]bool
@@ -58,6 +112,42 @@ type MockedEC2 struct {
 
 type MockedRoute53 struct {
 	AvoRoute53API
+
+	// HostedZoneTags are the tags returned by ListTagsForResource
+	HostedZoneTags []route53Types.Tag
+
+	// HostedZoneTagsById, when set, are the tags returned by ListTagsForResource for the hosted zone with the
+	// matching id instead of HostedZoneTags
+	HostedZoneTagsById map[string][]route53Types.Tag
+
+	// HostedZones are returned by ListHostedZones
+	HostedZones []route53Types.HostedZone
+
+	// ResourceRecordSets, when set, are the records returned by ListResourceRecordSets for the hosted zone with the
+	// matching id instead of the default mock record
+	ResourceRecordSets map[string][]route53Types.ResourceRecordSet
+
+	// ResourceRecordSetsPageSize, when set, is the number of records returned per ListResourceRecordSets page
+	ResourceRecordSetsPageSize int
+
+	// ListResourceRecordSetsInputs captures the ListResourceRecordSets call inputs for test assertions
+	ListResourceRecordSetsInputs []*route53.ListResourceRecordSetsInput
+
+	// ListTagsForResourcesInputs captures the ListTagsForResources call inputs for test assertions
+	ListTagsForResourcesInputs []*route53.ListTagsForResourcesInput
+
+	// ChangeTagsInputs captures the ChangeTagsForResource call inputs for test assertions
+	ChangeTagsInputs []*route53.ChangeTagsForResourceInput
+
+	// ChangeResourceRecordSetsInputs captures the ChangeResourceRecordSets call inputs for test assertions
+	ChangeResourceRecordSetsInputs []*route53.ChangeResourceRecordSetsInput
+
+	// DeletedHostedZoneIds captures the hosted zone ids deleted for test assertions
+	DeletedHostedZoneIds []string
+
+	// hostedZones tracks hosted zones created via CreateHostedZone by CallerReference,
+	// so retries with the same CallerReference fail like they do in AWS.
+	hostedZones map[string]route53Types.HostedZone
 }
 
 // MockedThrottlingRoute53 returns Throttling errors for ChangeResourceRecordSets
@@ -101,7 +191,9 @@ var mockSubnets = []*ec2Types.Subnet{
 				Value: aws.String("shared"),
 			},
 		},
-		VpcId: aws.String(MockVpcId),
+		VpcId:              aws.String(MockVpcId),
+		AvailabilityZone:   aws.String(MockAvailabilityZone),
+		AvailabilityZoneId: aws.String(MockAvailabilityZoneId),
 	},
 	{
 		SubnetId: aws.String(MockPublicSubnetId),
@@ -129,6 +221,19 @@ func NewMockedAwsClientWithSubnets() *AWSClient {
 }
 
 func (m *MockedEC2) DescribeSubnets(ctx context.Context, params *ec2.DescribeSubnetsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	if len(params.SubnetIds) > 0 {
+		resp := &ec2.DescribeSubnetsOutput{}
+		for _, id := range params.SubnetIds {
+			for _, subnet := range m.Subnets {
+				if *subnet.SubnetId == id {
+					resp.Subnets = append(resp.Subnets, *subnet)
+				}
+			}
+		}
+
+		return resp, nil
+	}
+
 	tagKeys := map[string]bool{}
 	for _, filter := range params.Filters {
 		for _, tagKey := range filter.Values {
@@ -156,7 +261,71 @@ func (m *MockedEC2) DescribeSubnets(ctx context.Context, params *ec2.DescribeSub
 	return &ec2.DescribeSubnetsOutput{}, nil
 }
 
+func (m *MockedEC2) DescribeRouteTables(ctx context.Context, params *ec2.DescribeRouteTablesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	resp := &ec2.DescribeRouteTablesOutput{}
+	if len(params.RouteTableIds) > 0 {
+		for _, id := range params.RouteTableIds {
+			for _, rt := range m.RouteTables {
+				if aws.ToString(rt.RouteTableId) == id {
+					resp.RouteTables = append(resp.RouteTables, rt)
+				}
+			}
+		}
+
+		return resp, nil
+	}
+
+	for _, rt := range m.RouteTables {
+		matches := true
+		for _, filter := range params.Filters {
+			switch name := aws.ToString(filter.Name); {
+			case name == "vpc-id":
+				matches = matches && aws.ToString(rt.VpcId) == filter.Values[0]
+			case name == "tag-key":
+				found := false
+				for _, tag := range rt.Tags {
+					found = found || aws.ToString(tag.Key) == filter.Values[0]
+				}
+				matches = matches && found
+			case strings.HasPrefix(name, "tag:"):
+				found := false
+				for _, tag := range rt.Tags {
+					found = found || ("tag:"+aws.ToString(tag.Key) == name && aws.ToString(tag.Value) == filter.Values[0])
+				}
+				matches = matches && found
+			}
+		}
+		if matches {
+			resp.RouteTables = append(resp.RouteTables, rt)
+		}
+	}
+
+	return resp, nil
+}
+
+func (m *MockedEC2) CreateRoute(ctx context.Context, params *ec2.CreateRouteInput, optFns ...func(*ec2.Options)) (*ec2.CreateRouteOutput, error) {
+	m.CreateRouteInputs = append(m.CreateRouteInputs, params)
+	return &ec2.CreateRouteOutput{Return: aws.Bool(true)}, nil
+}
+
+func (m *MockedEC2) ReplaceRoute(ctx context.Context, params *ec2.ReplaceRouteInput, optFns ...func(*ec2.Options)) (*ec2.ReplaceRouteOutput, error) {
+	m.ReplaceRouteInputs = append(m.ReplaceRouteInputs, params)
+	return &ec2.ReplaceRouteOutput{}, nil
+}
+
+func (m *MockedEC2) DeleteRoute(ctx context.Context, params *ec2.DeleteRouteInput, optFns ...func(*ec2.Options)) (*ec2.DeleteRouteOutput, error) {
+	m.DeleteRouteInputs = append(m.DeleteRouteInputs, params)
+	return &ec2.DeleteRouteOutput{}, nil
+}
+
 func (m *MockedEC2) CreateSecurityGroup(ctx context.Context, params *ec2.CreateSecurityGroupInput, optFns ...func(*ec2.Options)) (*ec2.CreateSecurityGroupOutput, error) {
+	if m.SecurityGroupExists {
+		return nil, &smithy.GenericAPIError{
+			Code:    "InvalidGroup.Duplicate",
+			Message: fmt.Sprintf("The security group '%s' already exists for VPC '%s'", *params.GroupName, *params.VpcId),
+		}
+	}
+
 	if len(params.TagSpecifications) > 0 {
 		return &ec2.CreateSecurityGroupOutput{
 			GroupId: aws.String(MockSecurityGroupId),
@@ -170,6 +339,7 @@ func (m *MockedEC2) CreateSecurityGroup(ctx context.Context, params *ec2.CreateS
 }
 
 func (m *MockedEC2) DeleteSecurityGroup(ctx context.Context, params *ec2.DeleteSecurityGroupInput, optFns ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error) {
+	m.DeletedSecurityGroupIds = append(m.DeletedSecurityGroupIds, aws.ToString(params.GroupId))
 	return &ec2.DeleteSecurityGroupOutput{}, nil
 }
 
@@ -179,6 +349,12 @@ func (m *MockedEC2) DescribeSecurityGroups(ctx context.Context, params *ec2.Desc
 		for i, groupId := range params.GroupIds {
 			securityGroups[i] = ec2Types.SecurityGroup{
 				GroupId: aws.String(groupId),
+				VpcId:   aws.String(MockVpcId),
+			}
+			for _, sg := range m.SecurityGroups {
+				if aws.ToString(sg.GroupId) == groupId {
+					securityGroups[i] = sg
+				}
 			}
 		}
 		return &ec2.DescribeSecurityGroupsOutput{
@@ -186,6 +362,22 @@ func (m *MockedEC2) DescribeSecurityGroups(ctx context.Context, params *ec2.Desc
 		}, nil
 	}
 
+	if m.SecurityGroups != nil && len(params.Filters) > 0 {
+		var securityGroups []ec2Types.SecurityGroup
+		for _, sg := range m.SecurityGroups {
+			if aws.ToString(sg.VpcId) != "" && !matchesFilter(params.Filters, "vpc-id", aws.ToString(sg.VpcId)) {
+				continue
+			}
+			if matchesTagFilters(params.Filters, sg.Tags) {
+				securityGroups = append(securityGroups, sg)
+			}
+		}
+
+		return &ec2.DescribeSecurityGroupsOutput{
+			SecurityGroups: securityGroups,
+		}, nil
+	}
+
 	if len(params.Filters) > 0 {
 		for _, filter := range params.Filters {
 			switch *filter.Name {
@@ -203,6 +395,21 @@ func (m *MockedEC2) DescribeSecurityGroups(ctx context.Context, params *ec2.Desc
 						},
 					},
 				}, nil
+			case "group-name":
+				return &ec2.DescribeSecurityGroupsOutput{
+					SecurityGroups: []ec2Types.SecurityGroup{
+						{
+							GroupId:   aws.String(MockSecurityGroupId),
+							GroupName: aws.String(filter.Values[0]),
+							Tags: []ec2Types.Tag{
+								{
+									Key:   aws.String(util.OperatorTagKey),
+									Value: aws.String(util.OperatorTagValue),
+								},
+							},
+						},
+					},
+				}, nil
 			case "Tag:Name":
 				return &ec2.DescribeSecurityGroupsOutput{
 					SecurityGroups: []ec2Types.SecurityGroup{
@@ -225,6 +432,12 @@ func (m *MockedEC2) DescribeSecurityGroups(ctx context.Context, params *ec2.Desc
 }
 
 func (m *MockedEC2) DescribeSecurityGroupRules(ctx context.Context, params *ec2.DescribeSecurityGroupRulesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupRulesOutput, error) {
+	if m.SecurityGroupRules != nil {
+		return &ec2.DescribeSecurityGroupRulesOutput{
+			SecurityGroupRules: m.SecurityGroupRules,
+		}, nil
+	}
+
 	// Mock now contains "pre-existing" rules to ensure SG rules created by customer using IP's over SGs do not cause failures
 	// while reconciling security group rules
 	return &ec2.DescribeSecurityGroupRulesOutput{
@@ -270,6 +483,14 @@ func (m *MockedEC2) DescribeVpcs(ctx context.Context, params *ec2.DescribeVpcsIn
 				{
 					VpcId:     aws.String(params.VpcIds[0]),
 					CidrBlock: aws.String(MockVpcCidr),
+					Ipv6CidrBlockAssociationSet: []ec2Types.VpcIpv6CidrBlockAssociation{
+						{
+							Ipv6CidrBlock: aws.String(MockVpcIpv6Cidr),
+							Ipv6CidrBlockState: &ec2Types.VpcCidrBlockState{
+								State: ec2Types.VpcCidrBlockStateCodeAssociated,
+							},
+						},
+					},
 				},
 			},
 		}, nil
@@ -308,11 +529,30 @@ func (m *MockedEC2) AuthorizeSecurityGroupEgress(ctx context.Context, params *ec
 	}, nil
 }
 
+func (m *MockedEC2) RevokeSecurityGroupIngress(ctx context.Context, params *ec2.RevokeSecurityGroupIngressInput, optFns ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	m.RevokedSecurityGroupRuleIds = append(m.RevokedSecurityGroupRuleIds, params.SecurityGroupRuleIds...)
+	return &ec2.RevokeSecurityGroupIngressOutput{
+		Return: aws.Bool(true),
+	}, nil
+}
+
+func (m *MockedEC2) RevokeSecurityGroupEgress(ctx context.Context, params *ec2.RevokeSecurityGroupEgressInput, optFns ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	m.RevokedSecurityGroupRuleIds = append(m.RevokedSecurityGroupRuleIds, params.SecurityGroupRuleIds...)
+	return &ec2.RevokeSecurityGroupEgressOutput{
+		Return: aws.Bool(true),
+	}, nil
+}
+
 func (m *MockedEC2) CreateTags(ctx context.Context, params *ec2.CreateTagsInput, optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
-	// TODO: this is a no-op
+	m.CreateTagsInputs = append(m.CreateTagsInputs, params)
 	return &ec2.CreateTagsOutput{}, nil
 }
 
+func (m *MockedEC2) DeleteTags(ctx context.Context, params *ec2.DeleteTagsInput, optFns ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
+	m.DeleteTagsInputs = append(m.DeleteTagsInputs, params)
+	return &ec2.DeleteTagsOutput{}, nil
+}
+
 func (m *MockedEC2) CreateVpcEndpoint(ctx context.Context, params *ec2.CreateVpcEndpointInput, optFns ...func(*ec2.Options)) (*ec2.CreateVpcEndpointOutput, error) {
 	m.LastCreateVpcEndpointInput = params
 	return &ec2.CreateVpcEndpointOutput{
@@ -343,6 +583,7 @@ func (m *MockedEC2) DeleteVpcEndpoints(ctx context.Context, params *ec2.DeleteVp
 	for _, id := range params.VpcEndpointIds {
 		m.deletedVpceIds[id] = true
 	}
+	m.DeletedVpcEndpointIds = append(m.DeletedVpcEndpointIds, params.VpcEndpointIds...)
 	return &ec2.DeleteVpcEndpointsOutput{}, nil
 }
 
@@ -355,21 +596,44 @@ func (m *MockedEC2) DescribeVpcEndpoints(ctx context.Context, params *ec2.Descri
 				Message: fmt.Sprintf("VpcEndpointId '%s' does not exist", params.VpcEndpointIds[0]),
 			}
 		}
+		for _, vpce := range m.VpcEndpoints {
+			if aws.ToString(vpce.VpcEndpointId) == params.VpcEndpointIds[0] {
+				return &ec2.DescribeVpcEndpointsOutput{VpcEndpoints: []ec2Types.VpcEndpoint{vpce}}, nil
+			}
+		}
 		return &ec2.DescribeVpcEndpointsOutput{
 			VpcEndpoints: []ec2Types.VpcEndpoint{
 				{
 					VpcEndpointId: aws.String(params.VpcEndpointIds[0]),
 					DnsEntries: []ec2Types.DnsEntry{
 						{
-							DnsName: aws.String(testutil.MockVpcEndpointDnsName),
+							DnsName:      aws.String(testutil.MockVpcEndpointDnsName),
+							HostedZoneId: aws.String(MockVpcEndpointHostedZone),
+						},
+						{
+							DnsName:      aws.String(MockVpcEndpointZonalDns),
+							HostedZoneId: aws.String(MockVpcEndpointHostedZone),
 						},
 					},
-					State: "available",
+					NetworkInterfaceIds: []string{MockNetworkInterfaceId},
+					SubnetIds:           []string{MockPrivateSubnetId},
+					State:               "available",
 				},
 			},
 		}, nil
 	}
 
+	if m.VpcEndpoints != nil && len(params.Filters) > 0 {
+		var vpcEndpoints []ec2Types.VpcEndpoint
+		for _, vpce := range m.VpcEndpoints {
+			if !m.deletedVpceIds[aws.ToString(vpce.VpcEndpointId)] && matchesTagFilters(params.Filters, vpce.Tags) {
+				vpcEndpoints = append(vpcEndpoints, vpce)
+			}
+		}
+
+		return &ec2.DescribeVpcEndpointsOutput{VpcEndpoints: vpcEndpoints}, nil
+	}
+
 	// Mock a VPC Endpoint with a specified tag-key
 	if len(params.Filters) > 0 {
 		for _, filter := range params.Filters {
@@ -400,8 +664,25 @@ func (m *MockedEC2) DescribeVpcEndpoints(ctx context.Context, params *ec2.Descri
 	return &ec2.DescribeVpcEndpointsOutput{}, nil
 }
 
+func (m *MockedEC2) DescribeNetworkInterfaces(ctx context.Context, params *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	m.DescribeNetworkInterfacesInputs = append(m.DescribeNetworkInterfacesInputs, params)
+	enis := make([]ec2Types.NetworkInterface, len(params.NetworkInterfaceIds))
+	for i, id := range params.NetworkInterfaceIds {
+		enis[i] = ec2Types.NetworkInterface{
+			NetworkInterfaceId: aws.String(id),
+			AvailabilityZone:   aws.String(MockAvailabilityZone),
+			PrivateIpAddress:   aws.String(MockNetworkInterfaceIp),
+			Ipv6Addresses: []ec2Types.NetworkInterfaceIpv6Address{
+				{Ipv6Address: aws.String(MockNetworkInterfaceIpv6)},
+			},
+		}
+	}
+
+	return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: enis}, nil
+}
+
 func (m *MockedEC2) ModifyVpcEndpoint(ctx context.Context, params *ec2.ModifyVpcEndpointInput, optFns ...func(*ec2.Options)) (*ec2.ModifyVpcEndpointOutput, error) {
-	// TODO: This is a no-op
+	m.ModifyVpcEndpointInputs = append(m.ModifyVpcEndpointInputs, params)
 	return &ec2.ModifyVpcEndpointOutput{}, nil
 }
 
@@ -415,36 +696,139 @@ func (m *MockedRoute53) GetHostedZone(ctx context.Context, params *route53.GetHo
 }
 
 func (m *MockedRoute53) ListHostedZonesByName(ctx context.Context, params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByNameOutput, error) {
+	hostedZones := []route53Types.HostedZone{
+		{
+			Id:   aws.String(MockHostedZoneId),
+			Name: params.DNSName,
+		},
+	}
+	for _, hz := range m.hostedZones {
+		hostedZones = append(hostedZones, hz)
+	}
+
 	return &route53.ListHostedZonesByNameOutput{
 		DNSName:      params.DNSName,
 		HostedZoneId: aws.String(MockHostedZoneId),
-		HostedZones: []route53Types.HostedZone{
-			{
-				Id:   aws.String(MockHostedZoneId),
-				Name: params.DNSName,
-			},
-		},
+		HostedZones:  hostedZones,
 	}, nil
 }
 
+func (m *MockedRoute53) CreateHostedZone(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error) {
+	if m.hostedZones == nil {
+		m.hostedZones = make(map[string]route53Types.HostedZone)
+	}
+
+	if _, ok := m.hostedZones[*params.CallerReference]; ok {
+		return nil, &route53Types.HostedZoneAlreadyExists{
+			Message: aws.String("The hosted zone you are trying to create already exists"),
+		}
+	}
+
+	hz := route53Types.HostedZone{
+		Id:              aws.String(fmt.Sprintf("/hostedzone/%s-%d", MockHostedZoneId, len(m.hostedZones))),
+		Name:            params.Name,
+		CallerReference: params.CallerReference,
+		Config:          params.HostedZoneConfig,
+	}
+	m.hostedZones[*params.CallerReference] = hz
+
+	return &route53.CreateHostedZoneOutput{HostedZone: &hz}, nil
+}
+
+func (m *MockedRoute53) ListHostedZones(ctx context.Context, params *route53.ListHostedZonesInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesOutput, error) {
+	return &route53.ListHostedZonesOutput{
+		HostedZones: append([]route53Types.HostedZone{}, m.HostedZones...),
+	}, nil
+}
+
+func (m *MockedRoute53) DeleteHostedZone(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+	m.DeletedHostedZoneIds = append(m.DeletedHostedZoneIds, aws.ToString(params.Id))
+	return &route53.DeleteHostedZoneOutput{}, nil
+}
+
 func (m *MockedRoute53) ListResourceRecordSets(ctx context.Context, params *route53.ListResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ListResourceRecordSetsOutput, error) {
+	m.ListResourceRecordSetsInputs = append(m.ListResourceRecordSetsInputs, params)
+	if rrsets, ok := m.ResourceRecordSets[aws.ToString(params.HostedZoneId)]; ok {
+		// The records are expected in the order Route 53 lists them, starting from the requested record
+		start := 0
+		if params.StartRecordName != nil {
+			start = len(rrsets)
+			for i, rrs := range rrsets {
+				if Route53RecordNameEqual(aws.ToString(rrs.Name), *params.StartRecordName) && (params.StartRecordType == "" || rrs.Type == params.StartRecordType) {
+					start = i
+					break
+				}
+			}
+		}
+
+		end := len(rrsets)
+		if m.ResourceRecordSetsPageSize > 0 && start+m.ResourceRecordSetsPageSize < end {
+			end = start + m.ResourceRecordSetsPageSize
+		}
+
+		out := &route53.ListResourceRecordSetsOutput{
+			ResourceRecordSets: append([]route53Types.ResourceRecordSet{}, rrsets[start:end]...),
+		}
+		if end < len(rrsets) {
+			out.IsTruncated = true
+			out.NextRecordName = rrsets[end].Name
+			out.NextRecordType = rrsets[end].Type
+		}
+
+		return out, nil
+	}
+
 	return &route53.ListResourceRecordSetsOutput{
 		ResourceRecordSets: []route53Types.ResourceRecordSet{*mockResourceRecordSet},
 	}, nil
 }
 
 func (m *MockedRoute53) ChangeResourceRecordSets(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+	m.ChangeResourceRecordSetsInputs = append(m.ChangeResourceRecordSetsInputs, params)
 	return &route53.ChangeResourceRecordSetsOutput{}, nil
 }
 
 func (m *MockedRoute53) ListTagsForResource(ctx context.Context, params *route53.ListTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ListTagsForResourceOutput, error) {
+	if tags, ok := m.HostedZoneTagsById[aws.ToString(params.ResourceId)]; ok {
+		return &route53.ListTagsForResourceOutput{
+			ResourceTagSet: &route53Types.ResourceTagSet{
+				Tags: append([]route53Types.Tag{}, tags...),
+			},
+		}, nil
+	}
+
 	return &route53.ListTagsForResourceOutput{
 		ResourceTagSet: &route53Types.ResourceTagSet{
-			Tags: []route53Types.Tag{},
+			Tags: append([]route53Types.Tag{}, m.HostedZoneTags...),
 		},
 	}, nil
 }
 
+func (m *MockedRoute53) ListTagsForResources(ctx context.Context, params *route53.ListTagsForResourcesInput, optFns ...func(*route53.Options)) (*route53.ListTagsForResourcesOutput, error) {
+	m.ListTagsForResourcesInputs = append(m.ListTagsForResourcesInputs, params)
+	if len(params.ResourceIds) > 10 {
+		return nil, &smithy.GenericAPIError{
+			Code:    "InvalidInput",
+			Message: "A maximum of 10 resource IDs can be specified",
+		}
+	}
+
+	out := &route53.ListTagsForResourcesOutput{}
+	for _, id := range params.ResourceIds {
+		tags, ok := m.HostedZoneTagsById[id]
+		if !ok {
+			tags = m.HostedZoneTags
+		}
+		out.ResourceTagSets = append(out.ResourceTagSets, route53Types.ResourceTagSet{
+			ResourceId:   aws.String(id),
+			ResourceType: params.ResourceType,
+			Tags:         append([]route53Types.Tag{}, tags...),
+		})
+	}
+
+	return out, nil
+}
+
 func (m *MockedRoute53) ListHostedZonesByVPC(ctx context.Context, params *route53.ListHostedZonesByVPCInput, optFns ...func(*route53.Options)) (*route53.ListHostedZonesByVPCOutput, error) {
 	return &route53.ListHostedZonesByVPCOutput{
 		HostedZoneSummaries: []route53Types.HostedZoneSummary{
@@ -457,5 +841,44 @@ func (m *MockedRoute53) ListHostedZonesByVPC(ctx context.Context, params *route5
 }
 
 func (m *MockedRoute53) ChangeTagsForResource(ctx context.Context, params *route53.ChangeTagsForResourceInput, optFns ...func(*route53.Options)) (*route53.ChangeTagsForResourceOutput, error) {
+	m.ChangeTagsInputs = append(m.ChangeTagsInputs, params)
 	return &route53.ChangeTagsForResourceOutput{}, nil
 }
+
+// matchesFilter returns true if there is no filter with the provided name or one of its values is value
+func matchesFilter(filters []ec2Types.Filter, name, value string) bool {
+	for _, filter := range filters {
+		if aws.ToString(filter.Name) != name {
+			continue
+		}
+		for _, v := range filter.Values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// matchesTagFilters returns true if the provided tags match all the tag-key and tag:<key> filters
+func matchesTagFilters(filters []ec2Types.Filter, tags []ec2Types.Tag) bool {
+	for _, filter := range filters {
+		name := aws.ToString(filter.Name)
+		matches := !(name == "tag-key" || strings.HasPrefix(name, "tag:"))
+		for _, tag := range tags {
+			for _, v := range filter.Values {
+				if (name == "tag-key" && aws.ToString(tag.Key) == v) ||
+					(name == "tag:"+aws.ToString(tag.Key) && aws.ToString(tag.Value) == v) {
+					matches = true
+				}
+			}
+		}
+		if !matches {
+			return false
+		}
+	}
+
+	return true
+}
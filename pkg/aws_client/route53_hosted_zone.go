@@ -18,13 +18,15 @@ package aws_client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 	"github.com/aws/aws-sdk-go-v2/service/route53/types"
-	"github.com/openshift/aws-vpce-operator/pkg/util"
 )
 
 // GetDefaultPrivateHostedZoneId returns the cluster's Route53 private hosted zone
@@ -69,19 +71,82 @@ func (c *AWSClient) ListHostedZonesByName(ctx context.Context, dnsName string) (
 	})
 }
 
-// ListResourceRecordSets returns a list of records for a given hosted zone ID
+// ListPrivateHostedZones returns all the private hosted zones in the account
+func (c *AWSClient) ListPrivateHostedZones(ctx context.Context) ([]types.HostedZone, error) {
+	var hostedZones []types.HostedZone
+	paginator := route53.NewListHostedZonesPaginator(c.route53Client, &route53.ListHostedZonesInput{})
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hz := range resp.HostedZones {
+			if hz.Config != nil && hz.Config.PrivateZone {
+				hostedZones = append(hostedZones, hz)
+			}
+		}
+	}
+
+	return hostedZones, nil
+}
+
+// ListResourceRecordSets returns all the records in a given hosted zone ID, across every page
 func (c *AWSClient) ListResourceRecordSets(ctx context.Context, hostedZoneId string) (*route53.ListResourceRecordSetsOutput, error) {
 	input := &route53.ListResourceRecordSetsInput{
 		HostedZoneId: aws.String(hostedZoneId),
 	}
 
-	// TODO: Handle pagination
-	resp, err := c.route53Client.ListResourceRecordSets(ctx, input)
-	if err != nil {
-		return nil, err
+	out := &route53.ListResourceRecordSetsOutput{}
+	paginator := route53.NewListResourceRecordSetsPaginator(c.route53Client, input)
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		out.ResourceRecordSets = append(out.ResourceRecordSets, resp.ResourceRecordSets...)
 	}
 
-	return resp, err
+	return out, nil
+}
+
+// ListResourceRecordSetsByName returns the records of any type with the provided name in a given hosted zone ID.
+// Records are listed in order starting from the name, so only the pages with that name are requested instead of the
+// whole hosted zone.
+func (c *AWSClient) ListResourceRecordSetsByName(ctx context.Context, hostedZoneId, name string) ([]types.ResourceRecordSet, error) {
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(hostedZoneId),
+		StartRecordName: aws.String(name),
+	}
+
+	var rrsets []types.ResourceRecordSet
+	paginator := route53.NewListResourceRecordSetsPaginator(c.route53Client, input)
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rrs := range resp.ResourceRecordSets {
+			if !Route53RecordNameEqual(aws.ToString(rrs.Name), name) {
+				// Every record with the name has been listed
+				return rrsets, nil
+			}
+			rrsets = append(rrsets, rrs)
+		}
+	}
+
+	return rrsets, nil
+}
+
+// Route53RecordNameEqual returns true if the provided Route53 Record names are equal, ignoring case, a trailing dot,
+// and the octal escaping Route 53 uses for a wildcard when listing records
+func Route53RecordNameEqual(a, b string) bool {
+	normalize := func(name string) string {
+		return strings.TrimSuffix(strings.ReplaceAll(name, `\052`, "*"), ".")
+	}
+
+	return strings.EqualFold(normalize(a), normalize(b))
 }
 
 // UpsertResourceRecordSet updates or creates a resource record set
@@ -103,6 +168,38 @@ func (c *AWSClient) UpsertResourceRecordSet(ctx context.Context, rrs *types.Reso
 	return c.route53Client.ChangeResourceRecordSets(ctx, input)
 }
 
+// ReplaceResourceRecordSets atomically deletes the replaced resource record sets and updates or creates rrsets, e.g.
+// to change the type of a record.
+// NOTE: The replaced resource record sets must match the existing ones exactly, as with DeleteResourceRecordSet.
+func (c *AWSClient) ReplaceResourceRecordSets(ctx context.Context, rrsets []types.ResourceRecordSet, replaced []types.ResourceRecordSet, hostedZoneId string) (*route53.ChangeResourceRecordSetsOutput, error) {
+	if len(rrsets) == 0 && len(replaced) == 0 {
+		return nil, errors.New("must specify resource record sets to replace")
+	}
+
+	changes := make([]types.Change, 0, len(replaced)+len(rrsets))
+	for i := range replaced {
+		changes = append(changes, types.Change{
+			Action:            types.ChangeActionDelete,
+			ResourceRecordSet: &replaced[i],
+		})
+	}
+	for i := range rrsets {
+		changes = append(changes, types.Change{
+			Action:            types.ChangeActionUpsert,
+			ResourceRecordSet: &rrsets[i],
+		})
+	}
+
+	input := &route53.ChangeResourceRecordSetsInput{
+		ChangeBatch: &types.ChangeBatch{
+			Changes: changes,
+		},
+		HostedZoneId: aws.String(hostedZoneId),
+	}
+
+	return c.route53Client.ChangeResourceRecordSets(ctx, input)
+}
+
 // DeleteResourceRecordSet deletes a specific record from a hosted zone
 // NOTE: To delete a resource record set, you must specify all the same values that you specified when you created it.
 func (c *AWSClient) DeleteResourceRecordSet(ctx context.Context, rrs *types.ResourceRecordSet, hostedZoneId string) (*route53.ChangeResourceRecordSetsOutput, error) {
@@ -124,9 +221,15 @@ func (c *AWSClient) DeleteResourceRecordSet(ctx context.Context, rrs *types.Reso
 
 // CreateHostedZone creates a Route 53 Private Hosted Zone with the specified domain, associated to the specified
 // vpcId + region.
-func (c *AWSClient) CreateHostedZone(ctx context.Context, domain, vpcId, region string) (*route53.CreateHostedZoneOutput, error) {
+// When callerReference is specified, retrying with the same callerReference returns the hosted zone that was
+// originally created instead of creating a duplicate. If that hosted zone has since been deleted, a new one is created.
+func (c *AWSClient) CreateHostedZone(ctx context.Context, domain, vpcId, region, callerReference string) (*route53.CreateHostedZoneOutput, error) {
+	if callerReference == "" {
+		callerReference = time.Now().String()
+	}
+
 	zoneInput := &route53.CreateHostedZoneInput{
-		CallerReference: aws.String(time.Now().String()),
+		CallerReference: aws.String(callerReference),
 		Name:            aws.String(domain),
 		HostedZoneConfig: &types.HostedZoneConfig{
 			Comment:     aws.String("Managed by aws-vpce-operator"),
@@ -134,29 +237,37 @@ func (c *AWSClient) CreateHostedZone(ctx context.Context, domain, vpcId, region
 		},
 		VPC: &types.VPC{VPCId: aws.String(vpcId), VPCRegion: types.VPCRegion(region)},
 	}
-	return c.route53Client.CreateHostedZone(ctx, zoneInput)
-}
 
-// DeleteHostedZone deletes a Route 53 Hosted Zone by ID
-func (c *AWSClient) DeleteHostedZone(ctx context.Context, id string) (*route53.DeleteHostedZoneOutput, error) {
-	return c.route53Client.DeleteHostedZone(ctx, &route53.DeleteHostedZoneInput{Id: aws.String(id)})
-}
-
-// GenerateDefaultTagsForHostedZoneInput generates the ChangeTagsForResourceInput using the default tags for the zoneId
-func (c *AWSClient) GenerateDefaultTagsForHostedZoneInput(zoneId, clusterTagKey string) (*route53.ChangeTagsForResourceInput, error) {
-	defaultTags, err := util.GenerateR53Tags(clusterTagKey)
+	resp, err := c.route53Client.CreateHostedZone(ctx, zoneInput)
 	if err != nil {
-		return nil, err
-	}
+		var alreadyExists *types.HostedZoneAlreadyExists
+		if !errors.As(err, &alreadyExists) {
+			return nil, err
+		}
 
-	changeTagsInput := &route53.ChangeTagsForResourceInput{
-		ResourceId:    aws.String(zoneId),
-		ResourceType:  types.TagResourceTypeHostedzone,
-		AddTags:       defaultTags,
-		RemoveTagKeys: nil,
+		// The CallerReference has already been used, find the hosted zone created with it
+		zones, listErr := c.ListHostedZonesByName(ctx, domain)
+		if listErr != nil {
+			return nil, listErr
+		}
+		for i := range zones.HostedZones {
+			if aws.ToString(zones.HostedZones[i].CallerReference) == callerReference {
+				return &route53.CreateHostedZoneOutput{HostedZone: &zones.HostedZones[i]}, nil
+			}
+		}
+
+		// CallerReferences can never be reused, so if the hosted zone created with it no longer exists,
+		// fall back to a unique CallerReference
+		zoneInput.CallerReference = aws.String(fmt.Sprintf("%s-%d", callerReference, time.Now().Unix()))
+		return c.route53Client.CreateHostedZone(ctx, zoneInput)
 	}
 
-	return changeTagsInput, nil
+	return resp, nil
+}
+
+// DeleteHostedZone deletes a Route 53 Hosted Zone by ID
+func (c *AWSClient) DeleteHostedZone(ctx context.Context, id string) (*route53.DeleteHostedZoneOutput, error) {
+	return c.route53Client.DeleteHostedZone(ctx, &route53.DeleteHostedZoneInput{Id: aws.String(id)})
 }
 
 // FetchPrivateZoneTags takes context and a Route53 ZoneID and returns the output provided by ListTagsForResource for a hosted zone
@@ -167,6 +278,30 @@ func (c *AWSClient) FetchPrivateZoneTags(ctx context.Context, zoneId string) (*r
 	})
 }
 
+// listTagsForResourcesMaxIds is the maximum number of resource IDs a ListTagsForResources call accepts
+const listTagsForResourcesMaxIds = 10
+
+// FetchPrivateZonesTags returns the tags of the given hosted zones by their ID, batching the ListTagsForResources
+// calls to stay within Route53's request rate limit
+func (c *AWSClient) FetchPrivateZonesTags(ctx context.Context, zoneIds []string) (map[string][]types.Tag, error) {
+	tags := make(map[string][]types.Tag, len(zoneIds))
+	for batch := range slices.Chunk(zoneIds, listTagsForResourcesMaxIds) {
+		resp, err := c.route53Client.ListTagsForResources(ctx, &route53.ListTagsForResourcesInput{
+			ResourceIds:  batch,
+			ResourceType: types.TagResourceTypeHostedzone,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tagSet := range resp.ResourceTagSets {
+			tags[strings.TrimPrefix(aws.ToString(tagSet.ResourceId), "/hostedzone/")] = tagSet.Tags
+		}
+	}
+
+	return tags, nil
+}
+
 func (c *AWSClient) CreateVPCAssociationAuthorization(ctx context.Context, hostedZoneId, vpcId, region string) (*route53.CreateVPCAssociationAuthorizationOutput, error) {
 	return c.route53Client.CreateVPCAssociationAuthorization(ctx, &route53.CreateVPCAssociationAuthorizationInput{
 		HostedZoneId: aws.String(hostedZoneId),
@@ -18,7 +18,12 @@ package aws_client
 
 import (
 	"context"
+	"fmt"
 	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAWSClient_ListResourceRecordSets(t *testing.T) {
@@ -29,6 +34,52 @@ func TestAWSClient_ListResourceRecordSets(t *testing.T) {
 	}
 }
 
+// mockPagedResourceRecordSets returns a mock that lists the records of MockHostedZoneId two at a time
+func mockPagedResourceRecordSets() *MockedRoute53 {
+	return &MockedRoute53{
+		ResourceRecordSetsPageSize: 2,
+		ResourceRecordSets: map[string][]types.ResourceRecordSet{
+			MockHostedZoneId: {
+				{Name: aws.String("example.com."), Type: types.RRTypeNs},
+				{Name: aws.String("example.com."), Type: types.RRTypeSoa},
+				{Name: aws.String("api.example.com."), Type: types.RRTypeA},
+				{Name: aws.String("api.example.com."), Type: types.RRTypeAaaa},
+				{Name: aws.String("api.example.com."), Type: types.RRTypeCname},
+				{Name: aws.String("apps.example.com."), Type: types.RRTypeCname},
+			},
+		},
+	}
+}
+
+func TestAWSClient_ListResourceRecordSets_paginated(t *testing.T) {
+	mock := mockPagedResourceRecordSets()
+	client := NewAwsClientWithServiceClients(&MockedEC2{}, mock)
+
+	resp, err := client.ListResourceRecordSets(context.TODO(), MockHostedZoneId)
+	assert.NoError(t, err)
+	assert.Len(t, resp.ResourceRecordSets, 6)
+	assert.Len(t, mock.ListResourceRecordSetsInputs, 3)
+}
+
+func TestAWSClient_ListResourceRecordSetsByName(t *testing.T) {
+	mock := mockPagedResourceRecordSets()
+	client := NewAwsClientWithServiceClients(&MockedEC2{}, mock)
+
+	rrsets, err := client.ListResourceRecordSetsByName(context.TODO(), MockHostedZoneId, "API.example.com")
+	assert.NoError(t, err)
+	if assert.Len(t, rrsets, 3) {
+		assert.Equal(t, types.RRTypeA, rrsets[0].Type)
+		assert.Equal(t, types.RRTypeCname, rrsets[2].Type)
+	}
+	// The listing stops at the first record with another name
+	assert.Len(t, mock.ListResourceRecordSetsInputs, 2)
+	assert.Equal(t, aws.String("API.example.com"), mock.ListResourceRecordSetsInputs[0].StartRecordName)
+
+	rrsets, err = client.ListResourceRecordSetsByName(context.TODO(), MockHostedZoneId, "missing.example.com")
+	assert.NoError(t, err)
+	assert.Empty(t, rrsets)
+}
+
 func TestAWSClient_UpsertDeleteResourceRecordSet(t *testing.T) {
 	client := NewMockedAwsClient()
 
@@ -40,3 +91,79 @@ func TestAWSClient_UpsertDeleteResourceRecordSet(t *testing.T) {
 		t.Errorf("expected no err, got %s", err)
 	}
 }
+
+func TestAWSClient_CreateHostedZoneCallerReference(t *testing.T) {
+	client := NewMockedAwsClient()
+
+	first, err := client.CreateHostedZone(context.TODO(), "example.com", MockVpcId, "us-east-1", "reference")
+	if err != nil {
+		t.Fatalf("expected no err, got %s", err)
+	}
+
+	// Retrying with the same caller reference should return the originally created hosted zone
+	retry, err := client.CreateHostedZone(context.TODO(), "example.com", MockVpcId, "us-east-1", "reference")
+	if err != nil {
+		t.Fatalf("expected no err, got %s", err)
+	}
+
+	if *first.HostedZone.Id != *retry.HostedZone.Id {
+		t.Errorf("expected hosted zone %s, got %s", *first.HostedZone.Id, *retry.HostedZone.Id)
+	}
+}
+
+func TestAWSClient_ListPrivateHostedZones(t *testing.T) {
+	client := NewAwsClientWithServiceClients(&MockedEC2{}, &MockedRoute53{
+		HostedZones: []types.HostedZone{
+			{
+				Id:     aws.String("/hostedzone/Z1"),
+				Name:   aws.String("private.example.com."),
+				Config: &types.HostedZoneConfig{PrivateZone: true},
+			},
+			{
+				Id:     aws.String("/hostedzone/Z2"),
+				Name:   aws.String("public.example.com."),
+				Config: &types.HostedZoneConfig{PrivateZone: false},
+			},
+		},
+	})
+
+	resp, err := client.ListPrivateHostedZones(context.TODO())
+	if err != nil {
+		t.Fatalf("expected no err, got %s", err)
+	}
+
+	if len(resp) != 1 || *resp[0].Id != "/hostedzone/Z1" {
+		t.Errorf("expected only hosted zone /hostedzone/Z1, got %v", resp)
+	}
+}
+
+func TestAWSClient_FetchPrivateZonesTags(t *testing.T) {
+	mock := &MockedRoute53{
+		HostedZoneTagsById: map[string][]types.Tag{
+			"Z1": {{Key: aws.String("mock"), Value: aws.String("Z1")}},
+		},
+	}
+	client := NewAwsClientWithServiceClients(&MockedEC2{}, mock)
+
+	var ids []string
+	for i := range 12 {
+		ids = append(ids, fmt.Sprintf("Z%d", i))
+	}
+
+	tags, err := client.FetchPrivateZonesTags(context.TODO(), ids)
+	assert.NoError(t, err)
+	assert.Len(t, tags, 12)
+	assert.Equal(t, "Z1", aws.ToString(tags["Z1"][0].Value))
+	// At most 10 hosted zones are looked up per call
+	if assert.Len(t, mock.ListTagsForResourcesInputs, 2) {
+		assert.Len(t, mock.ListTagsForResourcesInputs[0].ResourceIds, 10)
+		assert.Len(t, mock.ListTagsForResourcesInputs[1].ResourceIds, 2)
+	}
+}
+
+func TestRoute53RecordNameEqual(t *testing.T) {
+	assert.True(t, Route53RecordNameEqual("api.example.com.", "api.example.com"))
+	assert.True(t, Route53RecordNameEqual("API.example.com", "api.example.com"))
+	assert.True(t, Route53RecordNameEqual(`\052.apps.example.com.`, "*.apps.example.com"))
+	assert.False(t, Route53RecordNameEqual("api.example.com", "apps.example.com"))
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/openshift/aws-vpce-operator/api/v1alpha2"
+)
+
+// DescribeRouteTablesById returns the route tables with the provided ids. AWS returns an
+// InvalidRouteTableID.NotFound error if any of the route tables do not exist.
+func (c *AWSClient) DescribeRouteTablesById(ctx context.Context, ids []string) ([]types.RouteTable, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("no route tables provided")
+	}
+
+	resp, err := c.ec2Client.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{
+		RouteTableIds: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.RouteTables, nil
+}
+
+// FilterRouteTablesByTags returns the route tables in the provided VPC with all the provided tags.
+// If there is no value in a provided tag, filtering is done by tag-key only
+func (c *AWSClient) FilterRouteTablesByTags(ctx context.Context, vpcId string, tags ...v1alpha2.Tag) ([]types.RouteTable, error) {
+	if vpcId == "" {
+		return nil, errors.New("must specify vpc id when filtering route tables by tags")
+	}
+
+	filters := []types.Filter{
+		{
+			Name:   aws.String("vpc-id"),
+			Values: []string{vpcId},
+		},
+	}
+	for _, t := range tags {
+		switch {
+		case t.Key == "":
+			// Don't filter by an empty tag-key as it will exclude all route tables
+			continue
+		case t.Value == "":
+			filters = append(filters, types.Filter{
+				Name:   aws.String("tag-key"),
+				Values: []string{t.Key},
+			})
+		default:
+			filters = append(filters, types.Filter{
+				Name:   aws.String(fmt.Sprintf("tag:%s", t.Key)),
+				Values: []string{t.Value},
+			})
+		}
+	}
+
+	var routeTables []types.RouteTable
+	paginator := ec2.NewDescribeRouteTablesPaginator(c.ec2Client, &ec2.DescribeRouteTablesInput{
+		Filters: filters,
+	})
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		routeTables = append(routeTables, resp.RouteTables...)
+	}
+
+	return routeTables, nil
+}
+
+// GetRouteTablesVpcId returns the VPC ID of the route tables with the provided ids. Returns an error if the route
+// tables are not in the same VPC.
+func (c *AWSClient) GetRouteTablesVpcId(ctx context.Context, ids []string) (string, error) {
+	routeTables, err := c.DescribeRouteTablesById(ctx, ids)
+	if err != nil {
+		return "", fmt.Errorf("failed to describe route tables: %w", err)
+	}
+
+	if len(routeTables) == 0 {
+		return "", fmt.Errorf("no route tables found with ids: %v", ids)
+	}
+
+	vpcId := aws.ToString(routeTables[0].VpcId)
+	for _, rt := range routeTables {
+		if aws.ToString(rt.VpcId) != vpcId {
+			return "", fmt.Errorf("route tables %v are a part of multiple VPCs", ids)
+		}
+	}
+
+	return vpcId, nil
+}
+
+// CreateVpcEndpointRoute creates a route in a route table that sends traffic for the route's destination to a VPC
+// endpoint
+func (c *AWSClient) CreateVpcEndpointRoute(ctx context.Context, route v1alpha2.GatewayLoadBalancerRoute, vpceId string) (*ec2.CreateRouteOutput, error) {
+	input := &ec2.CreateRouteInput{
+		RouteTableId:  aws.String(route.RouteTableId),
+		VpcEndpointId: aws.String(vpceId),
+	}
+	if route.DestinationCidrBlock != "" {
+		input.DestinationCidrBlock = aws.String(route.DestinationCidrBlock)
+	}
+	if route.DestinationIpv6CidrBlock != "" {
+		input.DestinationIpv6CidrBlock = aws.String(route.DestinationIpv6CidrBlock)
+	}
+
+	return c.ec2Client.CreateRoute(ctx, input)
+}
+
+// Route target types, named after the ReplaceRoute parameters, as returned by GetRouteTarget
+const (
+	RouteTargetCarrierGateway            = "carrierGatewayId"
+	RouteTargetCoreNetwork               = "coreNetworkArn"
+	RouteTargetEgressOnlyInternetGateway = "egressOnlyInternetGatewayId"
+	RouteTargetGateway                   = "gatewayId"
+	RouteTargetInstance                  = "instanceId"
+	RouteTargetLocalGateway              = "localGatewayId"
+	RouteTargetNatGateway                = "natGatewayId"
+	RouteTargetNetworkInterface          = "networkInterfaceId"
+	RouteTargetTransitGateway            = "transitGatewayId"
+	RouteTargetVpcEndpoint               = "vpcEndpointId"
+	RouteTargetVpcPeeringConnection      = "vpcPeeringConnectionId"
+	vpcEndpointIdPrefix                  = "vpce-"
+)
+
+// GetRouteTarget returns the type and id of a route's target, or empty strings if the route has none, e.g. the
+// local route
+func GetRouteTarget(route types.Route) (string, string) {
+	switch {
+	case aws.ToString(route.GatewayId) == "local":
+		return "", ""
+	case strings.HasPrefix(aws.ToString(route.GatewayId), vpcEndpointIdPrefix):
+		// AWS reports the VPC Endpoint a route sends traffic through as its gateway
+		return RouteTargetVpcEndpoint, aws.ToString(route.GatewayId)
+	case route.GatewayId != nil:
+		return RouteTargetGateway, aws.ToString(route.GatewayId)
+	case route.NatGatewayId != nil:
+		return RouteTargetNatGateway, aws.ToString(route.NatGatewayId)
+	case route.TransitGatewayId != nil:
+		return RouteTargetTransitGateway, aws.ToString(route.TransitGatewayId)
+	case route.VpcPeeringConnectionId != nil:
+		return RouteTargetVpcPeeringConnection, aws.ToString(route.VpcPeeringConnectionId)
+	case route.EgressOnlyInternetGatewayId != nil:
+		return RouteTargetEgressOnlyInternetGateway, aws.ToString(route.EgressOnlyInternetGatewayId)
+	case route.LocalGatewayId != nil:
+		return RouteTargetLocalGateway, aws.ToString(route.LocalGatewayId)
+	case route.CarrierGatewayId != nil:
+		return RouteTargetCarrierGateway, aws.ToString(route.CarrierGatewayId)
+	case route.NetworkInterfaceId != nil:
+		// Routes to an instance also report its network interface, which is the more specific target
+		return RouteTargetNetworkInterface, aws.ToString(route.NetworkInterfaceId)
+	case route.InstanceId != nil:
+		return RouteTargetInstance, aws.ToString(route.InstanceId)
+	case route.CoreNetworkArn != nil:
+		return RouteTargetCoreNetwork, aws.ToString(route.CoreNetworkArn)
+	}
+
+	return "", ""
+}
+
+// ReplaceVpcEndpointRoute replaces the target of an existing route in a route table with a VPC endpoint
+func (c *AWSClient) ReplaceVpcEndpointRoute(ctx context.Context, route v1alpha2.GatewayLoadBalancerRoute, vpceId string) (*ec2.ReplaceRouteOutput, error) {
+	return c.ReplaceRouteTarget(ctx, route, RouteTargetVpcEndpoint, vpceId)
+}
+
+// ReplaceRouteTarget replaces the target of an existing route in a route table with the target of the provided type
+// and id, e.g. to restore a route's original target
+func (c *AWSClient) ReplaceRouteTarget(ctx context.Context, route v1alpha2.GatewayLoadBalancerRoute, targetType, targetId string) (*ec2.ReplaceRouteOutput, error) {
+	input := &ec2.ReplaceRouteInput{
+		RouteTableId: aws.String(route.RouteTableId),
+	}
+	if route.DestinationCidrBlock != "" {
+		input.DestinationCidrBlock = aws.String(route.DestinationCidrBlock)
+	}
+	if route.DestinationIpv6CidrBlock != "" {
+		input.DestinationIpv6CidrBlock = aws.String(route.DestinationIpv6CidrBlock)
+	}
+
+	switch targetType {
+	case RouteTargetCarrierGateway:
+		input.CarrierGatewayId = aws.String(targetId)
+	case RouteTargetCoreNetwork:
+		input.CoreNetworkArn = aws.String(targetId)
+	case RouteTargetEgressOnlyInternetGateway:
+		input.EgressOnlyInternetGatewayId = aws.String(targetId)
+	case RouteTargetGateway:
+		input.GatewayId = aws.String(targetId)
+	case RouteTargetInstance:
+		input.InstanceId = aws.String(targetId)
+	case RouteTargetLocalGateway:
+		input.LocalGatewayId = aws.String(targetId)
+	case RouteTargetNatGateway:
+		input.NatGatewayId = aws.String(targetId)
+	case RouteTargetNetworkInterface:
+		input.NetworkInterfaceId = aws.String(targetId)
+	case RouteTargetTransitGateway:
+		input.TransitGatewayId = aws.String(targetId)
+	case RouteTargetVpcEndpoint:
+		input.VpcEndpointId = aws.String(targetId)
+	case RouteTargetVpcPeeringConnection:
+		input.VpcPeeringConnectionId = aws.String(targetId)
+	default:
+		return nil, fmt.Errorf("unsupported route target type: %s", targetType)
+	}
+
+	return c.ec2Client.ReplaceRoute(ctx, input)
+}
+
+// DeleteRoute deletes the route for the route's destination from its route table
+func (c *AWSClient) DeleteRoute(ctx context.Context, route v1alpha2.GatewayLoadBalancerRoute) (*ec2.DeleteRouteOutput, error) {
+	input := &ec2.DeleteRouteInput{
+		RouteTableId: aws.String(route.RouteTableId),
+	}
+	if route.DestinationCidrBlock != "" {
+		input.DestinationCidrBlock = aws.String(route.DestinationCidrBlock)
+	}
+	if route.DestinationIpv6CidrBlock != "" {
+		input.DestinationIpv6CidrBlock = aws.String(route.DestinationIpv6CidrBlock)
+	}
+
+	return c.ec2Client.DeleteRoute(ctx, input)
+}
+
+// FindRoute returns the route in the route table with the same destination as the provided route, or nil if there
+// is none
+func FindRoute(routeTable types.RouteTable, route v1alpha2.GatewayLoadBalancerRoute) *types.Route {
+	for i, r := range routeTable.Routes {
+		if route.DestinationCidrBlock != "" && aws.ToString(r.DestinationCidrBlock) == route.DestinationCidrBlock {
+			return &routeTable.Routes[i]
+		}
+		if route.DestinationIpv6CidrBlock != "" && aws.ToString(r.DestinationIpv6CidrBlock) == route.DestinationIpv6CidrBlock {
+			return &routeTable.Routes[i]
+		}
+	}
+
+	return nil
+}
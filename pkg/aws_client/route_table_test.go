@@ -0,0 +1,234 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws_client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockedAwsClientWithRouteTables() *AWSClient {
+	return NewAwsClientWithServiceClients(&MockedEC2{
+		RouteTables: []types.RouteTable{
+			{
+				RouteTableId: aws.String(MockRouteTableId),
+				VpcId:        aws.String(MockVpcId),
+				Tags: []types.Tag{
+					{Key: aws.String(MockLegacyClusterTag), Value: aws.String("owned")},
+					{Key: aws.String("Name"), Value: aws.String("private")},
+				},
+			},
+			{
+				RouteTableId: aws.String("rtb-public"),
+				VpcId:        aws.String(MockVpcId),
+				Tags: []types.Tag{
+					{Key: aws.String(MockLegacyClusterTag), Value: aws.String("owned")},
+					{Key: aws.String("Name"), Value: aws.String("public")},
+				},
+			},
+			{
+				RouteTableId: aws.String("rtb-other"),
+				VpcId:        aws.String("vpc-other"),
+				Tags: []types.Tag{
+					{Key: aws.String(MockLegacyClusterTag), Value: aws.String("owned")},
+				},
+			},
+		},
+	}, &MockedRoute53{})
+}
+
+func TestAWSClient_DescribeRouteTablesById(t *testing.T) {
+	client := newMockedAwsClientWithRouteTables()
+
+	routeTables, err := client.DescribeRouteTablesById(context.TODO(), []string{MockRouteTableId})
+	assert.NoError(t, err)
+	assert.Len(t, routeTables, 1)
+
+	_, err = client.DescribeRouteTablesById(context.TODO(), nil)
+	assert.Error(t, err)
+}
+
+func TestAWSClient_FilterRouteTablesByTags(t *testing.T) {
+	tests := []struct {
+		name      string
+		vpcId     string
+		tags      []v1alpha2.Tag
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name:     "cluster tag key",
+			vpcId:    MockVpcId,
+			tags:     []v1alpha2.Tag{{Key: MockLegacyClusterTag}},
+			expected: []string{MockRouteTableId, "rtb-public"},
+		},
+		{
+			name:     "cluster tag key and value",
+			vpcId:    MockVpcId,
+			tags:     []v1alpha2.Tag{{Key: MockLegacyClusterTag}, {Key: "Name", Value: "private"}},
+			expected: []string{MockRouteTableId},
+		},
+		{
+			name:     "empty tag key is ignored",
+			vpcId:    "vpc-other",
+			tags:     []v1alpha2.Tag{{Key: ""}},
+			expected: []string{"rtb-other"},
+		},
+		{
+			name:      "missing vpc id",
+			tags:      []v1alpha2.Tag{{Key: MockLegacyClusterTag}},
+			expectErr: true,
+		},
+	}
+
+	client := newMockedAwsClientWithRouteTables()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			routeTables, err := client.FilterRouteTablesByTags(context.TODO(), test.vpcId, test.tags...)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			var ids []string
+			for _, rt := range routeTables {
+				ids = append(ids, *rt.RouteTableId)
+			}
+			assert.Equal(t, test.expected, ids)
+		})
+	}
+}
+
+func TestAWSClient_GetRouteTablesVpcId(t *testing.T) {
+	client := newMockedAwsClientWithRouteTables()
+
+	vpcId, err := client.GetRouteTablesVpcId(context.TODO(), []string{MockRouteTableId, "rtb-public"})
+	assert.NoError(t, err)
+	assert.Equal(t, MockVpcId, vpcId)
+
+	_, err = client.GetRouteTablesVpcId(context.TODO(), []string{MockRouteTableId, "rtb-other"})
+	assert.Error(t, err)
+
+	_, err = client.GetRouteTablesVpcId(context.TODO(), []string{"rtb-missing"})
+	assert.Error(t, err)
+}
+
+func TestAWSClient_VpcEndpointRoutes(t *testing.T) {
+	mock := &MockedEC2{}
+	client := NewAwsClientWithServiceClients(mock, &MockedRoute53{})
+	ipv4Route := v1alpha2.GatewayLoadBalancerRoute{RouteTableId: MockRouteTableId, DestinationCidrBlock: "0.0.0.0/0"}
+	ipv6Route := v1alpha2.GatewayLoadBalancerRoute{RouteTableId: MockRouteTableId, DestinationIpv6CidrBlock: "::/0"}
+
+	_, err := client.CreateVpcEndpointRoute(context.TODO(), ipv4Route, "vpce-12345")
+	assert.NoError(t, err)
+	assert.Equal(t, aws.String("0.0.0.0/0"), mock.CreateRouteInputs[0].DestinationCidrBlock)
+	assert.Nil(t, mock.CreateRouteInputs[0].DestinationIpv6CidrBlock)
+	assert.Equal(t, aws.String("vpce-12345"), mock.CreateRouteInputs[0].VpcEndpointId)
+
+	_, err = client.ReplaceVpcEndpointRoute(context.TODO(), ipv6Route, "vpce-12345")
+	assert.NoError(t, err)
+	assert.Nil(t, mock.ReplaceRouteInputs[0].DestinationCidrBlock)
+	assert.Equal(t, aws.String("::/0"), mock.ReplaceRouteInputs[0].DestinationIpv6CidrBlock)
+	assert.Equal(t, aws.String("vpce-12345"), mock.ReplaceRouteInputs[0].VpcEndpointId)
+
+	_, err = client.DeleteRoute(context.TODO(), ipv4Route)
+	assert.NoError(t, err)
+	assert.Equal(t, aws.String(MockRouteTableId), mock.DeleteRouteInputs[0].RouteTableId)
+	assert.Equal(t, aws.String("0.0.0.0/0"), mock.DeleteRouteInputs[0].DestinationCidrBlock)
+}
+
+func TestFindRoute(t *testing.T) {
+	routeTable := types.RouteTable{
+		RouteTableId: aws.String(MockRouteTableId),
+		Routes: []types.Route{
+			{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")},
+			{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("vpce-12345")},
+			{DestinationIpv6CidrBlock: aws.String("::/0"), GatewayId: aws.String("igw-12345")},
+		},
+	}
+
+	assert.Equal(t, &routeTable.Routes[1], FindRoute(routeTable, v1alpha2.GatewayLoadBalancerRoute{RouteTableId: MockRouteTableId, DestinationCidrBlock: "0.0.0.0/0"}))
+	assert.Equal(t, &routeTable.Routes[2], FindRoute(routeTable, v1alpha2.GatewayLoadBalancerRoute{RouteTableId: MockRouteTableId, DestinationIpv6CidrBlock: "::/0"}))
+
+	assert.Nil(t, FindRoute(routeTable, v1alpha2.GatewayLoadBalancerRoute{RouteTableId: MockRouteTableId, DestinationCidrBlock: "192.168.0.0/16"}))
+}
+
+func TestGetRouteTarget(t *testing.T) {
+	tests := []struct {
+		name         string
+		route        types.Route
+		expectedType string
+		expectedId   string
+	}{
+		{
+			name:  "local",
+			route: types.Route{GatewayId: aws.String("local")},
+		},
+		{
+			name:         "internet gateway",
+			route:        types.Route{GatewayId: aws.String("igw-12345")},
+			expectedType: RouteTargetGateway,
+			expectedId:   "igw-12345",
+		},
+		{
+			name:         "vpc endpoint",
+			route:        types.Route{GatewayId: aws.String("vpce-12345")},
+			expectedType: RouteTargetVpcEndpoint,
+			expectedId:   "vpce-12345",
+		},
+		{
+			name:         "nat gateway",
+			route:        types.Route{NatGatewayId: aws.String("nat-12345")},
+			expectedType: RouteTargetNatGateway,
+			expectedId:   "nat-12345",
+		},
+		{
+			name:         "instance",
+			route:        types.Route{InstanceId: aws.String("i-12345"), NetworkInterfaceId: aws.String("eni-12345")},
+			expectedType: RouteTargetNetworkInterface,
+			expectedId:   "eni-12345",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			targetType, targetId := GetRouteTarget(test.route)
+			assert.Equal(t, test.expectedType, targetType)
+			assert.Equal(t, test.expectedId, targetId)
+		})
+	}
+}
+
+func TestAWSClient_ReplaceRouteTarget(t *testing.T) {
+	mock := &MockedEC2{}
+	client := NewAwsClientWithServiceClients(mock, &MockedRoute53{})
+	route := v1alpha2.GatewayLoadBalancerRoute{RouteTableId: MockRouteTableId, DestinationCidrBlock: "0.0.0.0/0"}
+
+	_, err := client.ReplaceRouteTarget(context.TODO(), route, RouteTargetNatGateway, "nat-12345")
+	assert.NoError(t, err)
+	assert.Equal(t, aws.String("nat-12345"), mock.ReplaceRouteInputs[0].NatGatewayId)
+	assert.Nil(t, mock.ReplaceRouteInputs[0].VpcEndpointId)
+
+	_, err = client.ReplaceRouteTarget(context.TODO(), route, "unknown", "mock")
+	assert.Error(t, err)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/smithy-go"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
 	"github.com/openshift/aws-vpce-operator/pkg/util"
 )
 
@@ -112,12 +113,119 @@ func (c *AWSClient) FilterSecurityGroupById(ctx context.Context, groupId string)
 	return resp, err
 }
 
-// CreateSecurityGroup creates a security group with the specified name and cluster tag key in a specified VPC
-func (c *AWSClient) CreateSecurityGroup(ctx context.Context, name, vpcId, tagKey string) (*ec2.CreateSecurityGroupOutput, error) {
+// DescribeSecurityGroupsById describes the security groups with the specified ids, returning an error if any of them
+// do not exist
+func (c *AWSClient) DescribeSecurityGroupsById(ctx context.Context, ids []string) ([]types.SecurityGroup, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("must specify security group ids when describing security groups")
+	}
+
+	resp, err := c.ec2Client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+		GroupIds: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.SecurityGroups, nil
+}
+
+// FilterSecurityGroupsByTags describes the security groups in a specified VPC that have all the specified tags
+func (c *AWSClient) FilterSecurityGroupsByTags(ctx context.Context, vpcId string, tags []avov1alpha2.Tag) ([]types.SecurityGroup, error) {
+	if vpcId == "" || len(tags) == 0 {
+		return nil, errors.New("must specify vpc id and tags when filtering security groups by tags")
+	}
+
+	filters := []types.Filter{
+		{
+			Name:   aws.String("vpc-id"),
+			Values: []string{vpcId},
+		},
+	}
+	for _, tag := range tags {
+		filters = append(filters, types.Filter{
+			Name:   aws.String(fmt.Sprintf("tag:%s", tag.Key)),
+			Values: []string{tag.Value},
+		})
+	}
+
+	var securityGroups []types.SecurityGroup
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(c.ec2Client, &ec2.DescribeSecurityGroupsInput{
+		Filters: filters,
+	})
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		securityGroups = append(securityGroups, resp.SecurityGroups...)
+	}
+
+	return securityGroups, nil
+}
+
+// FilterSecurityGroupsByClusterTag returns the security groups managed by AVO for the cluster identified by
+// clusterTag, i.e. with the operator and cluster tags
+func (c *AWSClient) FilterSecurityGroupsByClusterTag(ctx context.Context, clusterTag string) ([]types.SecurityGroup, error) {
+	if clusterTag == "" {
+		return nil, errors.New("must specify cluster tag when filtering security groups by cluster tag")
+	}
+
+	var securityGroups []types.SecurityGroup
+	paginator := ec2.NewDescribeSecurityGroupsPaginator(c.ec2Client, &ec2.DescribeSecurityGroupsInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("tag:" + util.OperatorTagKey),
+				Values: []string{util.OperatorTagValue},
+			},
+			{
+				Name:   aws.String("tag-key"),
+				Values: []string{clusterTag},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		securityGroups = append(securityGroups, resp.SecurityGroups...)
+	}
+
+	return securityGroups, nil
+}
+
+// FilterSecurityGroupByName describes the security group with the specified group name in a specified VPC
+func (c *AWSClient) FilterSecurityGroupByName(ctx context.Context, vpcId, name string) (*ec2.DescribeSecurityGroupsOutput, error) {
+	if vpcId == "" || name == "" {
+		return nil, errors.New("must specify vpc id and name when filtering security groups by name")
+	}
+
+	return c.ec2Client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("group-name"),
+				Values: []string{name},
+			},
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpcId},
+			},
+		},
+	})
+}
+
+// CreateSecurityGroup creates a security group with the specified name and cluster tag key, as well as userTags, in a
+// specified VPC.
+// EC2 does not support client tokens for security groups, but group names are unique per VPC, so if a security group
+// with the same name that is managed by this operator already exists, e.g. from a previous attempt whose response
+// was lost, it is returned instead.
+func (c *AWSClient) CreateSecurityGroup(ctx context.Context, name, vpcId, tagKey string, userTags map[string]string) (*ec2.CreateSecurityGroupOutput, error) {
 	tags, err := util.GenerateAwsTags(name, tagKey)
 	if err != nil {
 		return nil, err
 	}
+	tags = append(tags, util.GenerateUserAwsTags(userTags)...)
 
 	input := &ec2.CreateSecurityGroupInput{
 		GroupName:   aws.String(name),
@@ -133,6 +241,25 @@ func (c *AWSClient) CreateSecurityGroup(ctx context.Context, name, vpcId, tagKey
 
 	sg, err := c.ec2Client.CreateSecurityGroup(ctx, input)
 	if err != nil {
+		var ae smithy.APIError
+		if errors.As(err, &ae) && ae.ErrorCode() == "InvalidGroup.Duplicate" {
+			existing, filterErr := c.FilterSecurityGroupByName(ctx, vpcId, name)
+			if filterErr != nil {
+				return nil, filterErr
+			}
+
+			for _, group := range existing.SecurityGroups {
+				for _, tag := range group.Tags {
+					if aws.ToString(tag.Key) == util.OperatorTagKey && aws.ToString(tag.Value) == util.OperatorTagValue {
+						return &ec2.CreateSecurityGroupOutput{
+							GroupId: group.GroupId,
+							Tags:    group.Tags,
+						}, nil
+					}
+				}
+			}
+		}
+
 		return nil, err
 	}
 
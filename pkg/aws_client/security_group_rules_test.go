@@ -94,3 +94,33 @@ func TestAWSClient_AuthorizeSecurityGroupRules(t *testing.T) {
 		}
 	}
 }
+
+func TestAWSClient_RevokeSecurityGroupRules(t *testing.T) {
+	tests := []struct {
+		name           string
+		ingressRuleIds []string
+		egressRuleIds  []string
+		expectedIds    []string
+	}{
+		{
+			name: "nothing to revoke",
+		},
+		{
+			name:           "ingress and egress",
+			ingressRuleIds: []string{"sgr-ingress"},
+			egressRuleIds:  []string{"sgr-egress"},
+			expectedIds:    []string{"sgr-ingress", "sgr-egress"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := &MockedEC2{}
+			client := NewAwsClientWithServiceClients(mock, &MockedRoute53{})
+
+			err := client.RevokeSecurityGroupRules(context.TODO(), MockSecurityGroupId, test.ingressRuleIds, test.egressRuleIds)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedIds, mock.RevokedSecurityGroupRuleIds)
+		})
+	}
+}
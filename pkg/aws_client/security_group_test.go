@@ -20,6 +20,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/util"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -105,12 +109,92 @@ func TestAWSClient_FilterSecurityGroupById(t *testing.T) {
 	}
 }
 
+func TestAWSClient_DescribeSecurityGroupsById(t *testing.T) {
+	client := NewMockedAwsClient()
+
+	_, err := client.DescribeSecurityGroupsById(context.TODO(), nil)
+	assert.Error(t, err)
+
+	resp, err := client.DescribeSecurityGroupsById(context.TODO(), []string{"sg-1", "sg-2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(resp))
+	assert.Equal(t, MockVpcId, *resp[0].VpcId)
+}
+
+func TestAWSClient_FilterSecurityGroupsByTags(t *testing.T) {
+	client := NewAwsClientWithServiceClients(&MockedEC2{
+		SecurityGroups: []types.SecurityGroup{
+			{
+				GroupId: aws.String("sg-1"),
+				VpcId:   aws.String(MockVpcId),
+				Tags:    []types.Tag{{Key: aws.String("team"), Value: aws.String("network")}},
+			},
+			{
+				GroupId: aws.String("sg-2"),
+				VpcId:   aws.String(MockVpcId),
+				Tags:    []types.Tag{{Key: aws.String("team"), Value: aws.String("other")}},
+			},
+			{
+				GroupId: aws.String("sg-3"),
+				VpcId:   aws.String("vpc-other"),
+				Tags:    []types.Tag{{Key: aws.String("team"), Value: aws.String("network")}},
+			},
+		},
+	}, &MockedRoute53{})
+
+	_, err := client.FilterSecurityGroupsByTags(context.TODO(), MockVpcId, nil)
+	assert.Error(t, err)
+
+	resp, err := client.FilterSecurityGroupsByTags(context.TODO(), MockVpcId, []avov1alpha2.Tag{{Key: "team", Value: "network"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp))
+	assert.Equal(t, "sg-1", *resp[0].GroupId)
+}
+
 func TestAWSClient_CreateDeleteSecurityGroup(t *testing.T) {
 	client := NewMockedAwsClient()
 
-	resp, err := client.CreateSecurityGroup(context.TODO(), "name", MockVpcId, MockLegacyClusterTag)
+	resp, err := client.CreateSecurityGroup(context.TODO(), "name", MockVpcId, MockLegacyClusterTag, nil)
 	assert.NoError(t, err)
 
 	_, err = client.DeleteSecurityGroup(context.TODO(), *resp.GroupId)
 	assert.NoError(t, err)
 }
+
+func TestAWSClient_CreateSecurityGroupDuplicate(t *testing.T) {
+	client := NewAwsClientWithServiceClients(&MockedEC2{SecurityGroupExists: true}, &MockedRoute53{})
+
+	resp, err := client.CreateSecurityGroup(context.TODO(), "name", MockVpcId, MockLegacyClusterTag, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, MockSecurityGroupId, *resp.GroupId)
+}
+
+func TestAWSClient_FilterSecurityGroupsByClusterTag(t *testing.T) {
+	client := NewAwsClientWithServiceClients(&MockedEC2{
+		SecurityGroups: []types.SecurityGroup{
+			{
+				GroupId: aws.String("sg-managed"),
+				VpcId:   aws.String(MockVpcId),
+				Tags: []types.Tag{
+					{Key: aws.String(util.OperatorTagKey), Value: aws.String(util.OperatorTagValue)},
+					{Key: aws.String(MockLegacyClusterTag), Value: aws.String("owned")},
+				},
+			},
+			{
+				GroupId: aws.String("sg-unmanaged"),
+				VpcId:   aws.String(MockVpcId),
+				Tags: []types.Tag{
+					{Key: aws.String(MockLegacyClusterTag), Value: aws.String("owned")},
+				},
+			},
+		},
+	}, &MockedRoute53{})
+
+	_, err := client.FilterSecurityGroupsByClusterTag(context.TODO(), "")
+	assert.Error(t, err)
+
+	resp, err := client.FilterSecurityGroupsByClusterTag(context.TODO(), MockLegacyClusterTag)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp))
+	assert.Equal(t, "sg-managed", *resp[0].GroupId)
+}
@@ -27,6 +27,9 @@ import (
 	"github.com/openshift/aws-vpce-operator/api/v1alpha2"
 )
 
+// ErrSubnetsNotFound is returned when no subnets could be autodiscovered
+var ErrSubnetsNotFound = errors.New("failed to find subnets")
+
 // privateSubnetTagKey is labelled by Hive on a non-BYOVPC cluster's subnets at install time
 const privateSubnetTagKey = "kubernetes.io/role/internal-elb"
 
@@ -59,6 +62,23 @@ func (c *AWSClient) GetVPCId(ctx context.Context, subnetIds []string) (string, e
 	return vpcId, nil
 }
 
+// DescribeSubnetsById returns the subnets with the provided subnetIds. AWS returns an InvalidSubnetID.NotFound error
+// if any of the subnets do not exist.
+func (c *AWSClient) DescribeSubnetsById(ctx context.Context, subnetIds []string) ([]types.Subnet, error) {
+	if len(subnetIds) == 0 {
+		return nil, errors.New("no subnets provided")
+	}
+
+	resp, err := c.ec2Client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: subnetIds,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Subnets, nil
+}
+
 // AutodiscoverPrivateSubnets attempts to automatically return a slice of ROSA cluster private subnet ids.
 // A ROSA cluster's subnets are tagged with a tag key in AWS: "kubernetes.io/cluster/<cluster-name>".
 // Private subnets for non-BYOVPC clusters also have the `kubernetes.io/role/internal-elb` tag key.
@@ -84,7 +104,7 @@ func (c *AWSClient) AutodiscoverPrivateSubnets(ctx context.Context, clusterTag s
 		return byovpc.Subnets, nil
 	}
 
-	return nil, fmt.Errorf("failed to find subnets with tag key: %s", clusterTag)
+	return nil, fmt.Errorf("%w with tag key: %s", ErrSubnetsNotFound, clusterTag)
 }
 
 // DescribeSubnetsByTags returns a list of subnets filtered by the provided tags
@@ -19,15 +19,26 @@ package aws_client
 import (
 	"context"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 )
 
+// maxRoute53TagChanges is the maximum number of tags that can be added, and the maximum number of tag keys that can
+// be removed, in a single Route 53 ChangeTagsForResource request
+const maxRoute53TagChanges = 10
+
 // CreateTags creates tags in an idempotent fashion
 func (c *AWSClient) CreateTags(ctx context.Context, input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
 	return c.ec2Client.CreateTags(ctx, input)
 }
 
+// DeleteTags deletes tags from EC2 resources. Tags specified without a value are deleted regardless of their value.
+func (c *AWSClient) DeleteTags(ctx context.Context, input *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+	return c.ec2Client.DeleteTags(ctx, input)
+}
+
 // ListTagsForResource will fetch tags of a hosted zone or healthcheck
 func (c *AWSClient) ListTagsForResource(ctx context.Context, params *route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error) {
 	return c.route53Client.ListTagsForResource(ctx, params)
@@ -37,3 +48,26 @@ func (c *AWSClient) ListTagsForResource(ctx context.Context, params *route53.Lis
 func (c *AWSClient) ChangeTagsForResource(ctx context.Context, params *route53.ChangeTagsForResourceInput) (*route53.ChangeTagsForResourceOutput, error) {
 	return c.route53Client.ChangeTagsForResource(ctx, params)
 }
+
+// ChangeHostedZoneTags adds or overwrites addTags and removes removeTagKeys on a hosted zone, splitting the changes
+// into as many ChangeTagsForResource requests as needed
+func (c *AWSClient) ChangeHostedZoneTags(ctx context.Context, zoneId string, addTags []route53Types.Tag, removeTagKeys []string) error {
+	for i := 0; i < len(addTags) || i < len(removeTagKeys); i += maxRoute53TagChanges {
+		input := &route53.ChangeTagsForResourceInput{
+			ResourceId:   aws.String(zoneId),
+			ResourceType: route53Types.TagResourceTypeHostedzone,
+		}
+		if i < len(addTags) {
+			input.AddTags = addTags[i:min(i+maxRoute53TagChanges, len(addTags))]
+		}
+		if i < len(removeTagKeys) {
+			input.RemoveTagKeys = removeTagKeys[i:min(i+maxRoute53TagChanges, len(removeTagKeys))]
+		}
+
+		if _, err := c.route53Client.ChangeTagsForResource(ctx, input); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -18,9 +18,12 @@ package aws_client
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	route53Types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -46,3 +49,53 @@ func TestAWSClient_CreateTags(t *testing.T) {
 		}
 	}
 }
+
+func TestAWSClient_ChangeHostedZoneTags(t *testing.T) {
+	var addTags []route53Types.Tag
+	for i := 0; i < 12; i++ {
+		addTags = append(addTags, route53Types.Tag{Key: aws.String(fmt.Sprintf("key-%d", i)), Value: aws.String("value")})
+	}
+
+	tests := []struct {
+		name             string
+		addTags          []route53Types.Tag
+		removeTagKeys    []string
+		expectedRequests int
+	}{
+		{
+			name:             "no changes",
+			expectedRequests: 0,
+		},
+		{
+			name:             "single request",
+			addTags:          addTags[:2],
+			removeTagKeys:    []string{"removed"},
+			expectedRequests: 1,
+		},
+		{
+			name:             "batched",
+			addTags:          addTags,
+			removeTagKeys:    []string{"removed"},
+			expectedRequests: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			route53Client := &MockedRoute53{}
+			client := NewAwsClientWithServiceClients(&MockedEC2{}, route53Client)
+
+			assert.NoError(t, client.ChangeHostedZoneTags(context.TODO(), MockHostedZoneId, test.addTags, test.removeTagKeys))
+			assert.Len(t, route53Client.ChangeTagsInputs, test.expectedRequests)
+
+			var added, removed int
+			for _, input := range route53Client.ChangeTagsInputs {
+				assert.LessOrEqual(t, len(input.AddTags), maxRoute53TagChanges)
+				added += len(input.AddTags)
+				removed += len(input.RemoveTagKeys)
+			}
+			assert.Equal(t, len(test.addTags), added)
+			assert.Equal(t, len(test.removeTagKeys), removed)
+		})
+	}
+}
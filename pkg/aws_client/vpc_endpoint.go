@@ -113,6 +113,86 @@ func (c *AWSClient) FilterVpcIdsByTags(ctx context.Context, tags []avov1alpha2.T
 	return ids, nil
 }
 
+// DescribeVpcsById returns the VPCs with the provided ids. AWS returns an InvalidVpcID.NotFound error if any of the
+// VPCs do not exist.
+func (c *AWSClient) DescribeVpcsById(ctx context.Context, ids []string) ([]types.Vpc, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("must specify vpc ids when describing VPCs")
+	}
+
+	resp, err := c.ec2Client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{
+		VpcIds: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Vpcs, nil
+}
+
+// GetNetworkInterfacePrivateIps returns the primary private IPv4 addresses of the network interfaces with the
+// provided ids, keyed by the name of their Availability Zone
+func (c *AWSClient) GetNetworkInterfacePrivateIps(ctx context.Context, ids []string) (map[string][]string, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("must specify network interface ids when describing network interfaces")
+	}
+
+	resp, err := c.ec2Client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ips := map[string][]string{}
+	for _, eni := range resp.NetworkInterfaces {
+		if eni.PrivateIpAddress != nil {
+			az := aws.ToString(eni.AvailabilityZone)
+			ips[az] = append(ips[az], *eni.PrivateIpAddress)
+		}
+	}
+
+	return ips, nil
+}
+
+// GetNetworkInterfaceIpv6Ips returns the IPv6 addresses of the network interfaces with the provided ids, keyed by
+// the name of their Availability Zone
+func (c *AWSClient) GetNetworkInterfaceIpv6Ips(ctx context.Context, ids []string) (map[string][]string, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("must specify network interface ids when describing network interfaces")
+	}
+
+	resp, err := c.ec2Client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ips := map[string][]string{}
+	for _, eni := range resp.NetworkInterfaces {
+		az := aws.ToString(eni.AvailabilityZone)
+		for _, ip := range eni.Ipv6Addresses {
+			if ip.Ipv6Address != nil {
+				ips[az] = append(ips[az], *ip.Ipv6Address)
+			}
+		}
+	}
+
+	return ips, nil
+}
+
+// DescribeNetworkInterfacesById returns information about the network interfaces with the provided ids
+func (c *AWSClient) DescribeNetworkInterfacesById(ctx context.Context, ids []string) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("must specify network interface ids when describing network interfaces")
+	}
+
+	return c.ec2Client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: ids,
+	})
+}
+
 // GetVpcCidrBlock returns the primary CIDR block for the given VPC ID
 func (c *AWSClient) GetVpcCidrBlock(ctx context.Context, vpcId string) (string, error) {
 	if vpcId == "" {
@@ -137,6 +217,34 @@ func (c *AWSClient) GetVpcCidrBlock(ctx context.Context, vpcId string) (string,
 	return *resp.Vpcs[0].CidrBlock, nil
 }
 
+// GetVpcIpv6CidrBlocks returns the IPv6 CIDR blocks associated with the given VPC ID
+func (c *AWSClient) GetVpcIpv6CidrBlocks(ctx context.Context, vpcId string) ([]string, error) {
+	if vpcId == "" {
+		return nil, errors.New("must specify vpc id when getting VPC IPv6 CIDR blocks")
+	}
+
+	resp, err := c.ec2Client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{
+		VpcIds: []string{vpcId},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe VPC %s: %w", vpcId, err)
+	}
+
+	if len(resp.Vpcs) == 0 {
+		return nil, fmt.Errorf("no VPC found with id: %s", vpcId)
+	}
+
+	var cidrs []string
+	for _, association := range resp.Vpcs[0].Ipv6CidrBlockAssociationSet {
+		if association.Ipv6CidrBlock != nil && association.Ipv6CidrBlockState != nil &&
+			association.Ipv6CidrBlockState.State == types.VpcCidrBlockStateCodeAssociated {
+			cidrs = append(cidrs, *association.Ipv6CidrBlock)
+		}
+	}
+
+	return cidrs, nil
+}
+
 // DescribeSingleVPCEndpointById returns information about a VPC endpoint with a given id.
 func (c *AWSClient) DescribeSingleVPCEndpointById(ctx context.Context, id string) (*ec2.DescribeVpcEndpointsOutput, error) {
 	if id == "" {
@@ -167,7 +275,8 @@ func (c *AWSClient) DescribeSingleVPCEndpointById(ctx context.Context, id string
 	return resp, err
 }
 
-// FilterVPCEndpointByDefaultTags returns information about a VPC endpoint with the default expected tags.
+// FilterVPCEndpointByDefaultTags returns information about a VPC endpoint with the default expected tags
+// that is not being deleted.
 func (c *AWSClient) FilterVPCEndpointByDefaultTags(ctx context.Context, clusterTag, vpceNameTag string) (*ec2.DescribeVpcEndpointsOutput, error) {
 	if clusterTag == "" {
 		return &ec2.DescribeVpcEndpointsOutput{}, nil
@@ -187,22 +296,65 @@ func (c *AWSClient) FilterVPCEndpointByDefaultTags(ctx context.Context, clusterT
 				Name:   aws.String("tag:" + util.OperatorTagKey),
 				Values: []string{util.OperatorTagValue},
 			},
+			{
+				// Deleted VPC Endpoints remain visible for a while and should not be reused
+				Name:   aws.String("vpc-endpoint-state"),
+				Values: []string{"pendingAcceptance", "pending", "available", "rejected", "failed"},
+			},
 		},
 	})
 }
 
-// CreateDefaultInterfaceVPCEndpoint creates an interface VPC endpoint with
-// the default (open to all) VPC Endpoint policy. It attaches no security groups
-// nor associates the VPC Endpoint with any subnets.
-func (c *AWSClient) CreateDefaultInterfaceVPCEndpoint(ctx context.Context, name, vpcId, serviceName, tagKey string) (*ec2.CreateVpcEndpointOutput, error) {
+// FilterVPCEndpointsByClusterTag returns the VPC endpoints managed by AVO for the cluster identified by clusterTag,
+// i.e. with the operator and cluster tags, that are not being deleted
+func (c *AWSClient) FilterVPCEndpointsByClusterTag(ctx context.Context, clusterTag string) ([]types.VpcEndpoint, error) {
+	if clusterTag == "" {
+		return nil, errors.New("must specify cluster tag when filtering VPC Endpoints by cluster tag")
+	}
+
+	var vpcEndpoints []types.VpcEndpoint
+	paginator := ec2.NewDescribeVpcEndpointsPaginator(c.ec2Client, &ec2.DescribeVpcEndpointsInput{
+		Filters: []types.Filter{
+			{
+				Name:   aws.String("tag:" + util.OperatorTagKey),
+				Values: []string{util.OperatorTagValue},
+			},
+			{
+				Name:   aws.String("tag-key"),
+				Values: []string{clusterTag},
+			},
+			{
+				Name:   aws.String("vpc-endpoint-state"),
+				Values: []string{"pendingAcceptance", "pending", "available", "rejected", "failed"},
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		vpcEndpoints = append(vpcEndpoints, resp.VpcEndpoints...)
+	}
+
+	return vpcEndpoints, nil
+}
+
+// CreateDefaultInterfaceVPCEndpoint creates an interface VPC endpoint with the provided policyDocument, or
+// the default (open to all) VPC Endpoint policy when it's empty. It attaches no security groups
+// nor associates the VPC Endpoint with any subnets. userTags are applied in addition to the default tags.
+// When clientToken is specified, retrying with the same clientToken returns the
+// VPC Endpoint that was originally created instead of creating a duplicate.
+// When ipAddressType or dnsRecordIpType are empty, AWS defaults to ipv4.
+func (c *AWSClient) CreateDefaultInterfaceVPCEndpoint(ctx context.Context, name, vpcId, serviceName, tagKey string, userTags map[string]string, clientToken, policyDocument string,
+	ipAddressType types.IpAddressType, dnsRecordIpType types.DnsRecordIpType) (*ec2.CreateVpcEndpointOutput, error) {
 	tags, err := util.GenerateAwsTags(name, tagKey)
 	if err != nil {
 		return nil, err
 	}
+	tags = append(tags, util.GenerateUserAwsTags(userTags)...)
 
 	input := &ec2.CreateVpcEndpointInput{
-		// TODO: Implement ClientToken for idempotency guarantees
-		// ClientToken:     "token",
 		VpcId:           &vpcId,
 		ServiceName:     &serviceName,
 		VpcEndpointType: types.VpcEndpointTypeInterface,
@@ -213,6 +365,84 @@ func (c *AWSClient) CreateDefaultInterfaceVPCEndpoint(ctx context.Context, name,
 			},
 		},
 	}
+	if clientToken != "" {
+		input.ClientToken = aws.String(clientToken)
+	}
+	if policyDocument != "" {
+		input.PolicyDocument = aws.String(policyDocument)
+	}
+	if ipAddressType != "" {
+		input.IpAddressType = ipAddressType
+	}
+	if dnsRecordIpType != "" {
+		input.DnsOptions = &types.DnsOptionsSpecification{
+			DnsRecordIpType: dnsRecordIpType,
+		}
+	}
+
+	return c.ec2Client.CreateVpcEndpoint(ctx, input)
+}
+
+// CreateDefaultGatewayVPCEndpoint creates a gateway VPC endpoint with the provided policyDocument, or
+// the default (open to all) VPC Endpoint policy when it's empty. It associates no route tables with the VPC Endpoint.
+// userTags are applied in addition to the default tags.
+// When clientToken is specified, retrying with the same clientToken returns the
+// VPC Endpoint that was originally created instead of creating a duplicate.
+func (c *AWSClient) CreateDefaultGatewayVPCEndpoint(ctx context.Context, name, vpcId, serviceName, tagKey string, userTags map[string]string, clientToken, policyDocument string) (*ec2.CreateVpcEndpointOutput, error) {
+	tags, err := util.GenerateAwsTags(name, tagKey)
+	if err != nil {
+		return nil, err
+	}
+	tags = append(tags, util.GenerateUserAwsTags(userTags)...)
+
+	input := &ec2.CreateVpcEndpointInput{
+		VpcId:           &vpcId,
+		ServiceName:     &serviceName,
+		VpcEndpointType: types.VpcEndpointTypeGateway,
+		TagSpecifications: []types.TagSpecification{
+			{
+				ResourceType: types.ResourceTypeVpcEndpoint,
+				Tags:         tags,
+			},
+		},
+	}
+	if clientToken != "" {
+		input.ClientToken = aws.String(clientToken)
+	}
+	if policyDocument != "" {
+		input.PolicyDocument = aws.String(policyDocument)
+	}
+
+	return c.ec2Client.CreateVpcEndpoint(ctx, input)
+}
+
+// CreateDefaultGatewayLoadBalancerVPCEndpoint creates a GatewayLoadBalancer VPC endpoint with a network interface in
+// the provided subnet, which can't be changed afterward.
+// userTags are applied in addition to the default tags.
+// When clientToken is specified, retrying with the same clientToken returns the
+// VPC Endpoint that was originally created instead of creating a duplicate.
+func (c *AWSClient) CreateDefaultGatewayLoadBalancerVPCEndpoint(ctx context.Context, name, vpcId, serviceName, subnetId, tagKey string, userTags map[string]string, clientToken string) (*ec2.CreateVpcEndpointOutput, error) {
+	tags, err := util.GenerateAwsTags(name, tagKey)
+	if err != nil {
+		return nil, err
+	}
+	tags = append(tags, util.GenerateUserAwsTags(userTags)...)
+
+	input := &ec2.CreateVpcEndpointInput{
+		VpcId:           &vpcId,
+		ServiceName:     &serviceName,
+		SubnetIds:       []string{subnetId},
+		VpcEndpointType: types.VpcEndpointTypeGatewayLoadBalancer,
+		TagSpecifications: []types.TagSpecification{
+			{
+				ResourceType: types.ResourceTypeVpcEndpoint,
+				Tags:         tags,
+			},
+		},
+	}
+	if clientToken != "" {
+		input.ClientToken = aws.String(clientToken)
+	}
 
 	return c.ec2Client.CreateVpcEndpoint(ctx, input)
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+	"github.com/openshift/aws-vpce-operator/pkg/util"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -91,16 +92,156 @@ func TestAWSClient_FilterVPCEndpointByDefaultTags(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAWSClient_FilterVPCEndpointsByClusterTag(t *testing.T) {
+	client := NewAwsClientWithServiceClients(&MockedEC2{
+		VpcEndpoints: []types.VpcEndpoint{
+			{
+				VpcEndpointId: aws.String("vpce-managed"),
+				Tags: []types.Tag{
+					{Key: aws.String(util.OperatorTagKey), Value: aws.String(util.OperatorTagValue)},
+					{Key: aws.String(MockLegacyClusterTag), Value: aws.String("owned")},
+				},
+			},
+			{
+				VpcEndpointId: aws.String("vpce-other-cluster"),
+				Tags: []types.Tag{
+					{Key: aws.String(util.OperatorTagKey), Value: aws.String(util.OperatorTagValue)},
+					{Key: aws.String("kubernetes.io/cluster/other"), Value: aws.String("owned")},
+				},
+			},
+			{
+				VpcEndpointId: aws.String("vpce-unmanaged"),
+				Tags: []types.Tag{
+					{Key: aws.String(MockLegacyClusterTag), Value: aws.String("owned")},
+				},
+			},
+		},
+	}, &MockedRoute53{})
+
+	_, err := client.FilterVPCEndpointsByClusterTag(context.TODO(), "")
+	assert.Error(t, err)
+
+	resp, err := client.FilterVPCEndpointsByClusterTag(context.TODO(), MockLegacyClusterTag)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resp))
+	assert.Equal(t, "vpce-managed", *resp[0].VpcEndpointId)
+}
+
 func TestCreateDeleteVPCEndpoint(t *testing.T) {
 	client := NewMockedAwsClient()
 
-	resp, err := client.CreateDefaultInterfaceVPCEndpoint(context.TODO(), "name", MockVpcId, MockVpcEndpointServiceName, MockLegacyClusterTag)
+	resp, err := client.CreateDefaultInterfaceVPCEndpoint(context.TODO(), "name", MockVpcId, MockVpcEndpointServiceName, MockLegacyClusterTag, nil, "", "", "", "")
 	assert.NoError(t, err)
 
 	_, err = client.DeleteVPCEndpoint(context.TODO(), *resp.VpcEndpoint.VpcEndpointId)
 	assert.NoError(t, err)
 }
 
+func TestAWSClient_CreateDefaultInterfaceVPCEndpointClientToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		clientToken string
+		expected    *string
+	}{
+		{
+			name: "no client token",
+		},
+		{
+			name:        "client token",
+			clientToken: "token",
+			expected:    aws.String("token"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := &MockedEC2{}
+			client := NewAwsClientWithServiceClients(mock, &MockedRoute53{})
+
+			_, err := client.CreateDefaultInterfaceVPCEndpoint(context.TODO(), "name", MockVpcId, MockVpcEndpointServiceName, MockLegacyClusterTag, nil, test.clientToken, "", "", "")
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, mock.LastCreateVpcEndpointInput.ClientToken)
+		})
+	}
+}
+
+func TestAWSClient_CreateDefaultInterfaceVPCEndpointUserTags(t *testing.T) {
+	mock := &MockedEC2{}
+	client := NewAwsClientWithServiceClients(mock, &MockedRoute53{})
+
+	_, err := client.CreateDefaultInterfaceVPCEndpoint(context.TODO(), "name", MockVpcId, MockVpcEndpointServiceName, MockLegacyClusterTag,
+		map[string]string{"cost-center": "1234", "Name": "override"}, "", "", "", "")
+	assert.NoError(t, err)
+
+	tags := map[string]string{}
+	for _, tag := range mock.LastCreateVpcEndpointInput.TagSpecifications[0].Tags {
+		tags[*tag.Key] = *tag.Value
+	}
+	assert.Equal(t, "1234", tags["cost-center"])
+	assert.Equal(t, "name", tags["Name"])
+}
+
+func TestAWSClient_CreateDefaultInterfaceVPCEndpointIpAddressType(t *testing.T) {
+	tests := []struct {
+		name               string
+		ipAddressType      types.IpAddressType
+		dnsRecordIpType    types.DnsRecordIpType
+		expectedDnsOptions *types.DnsOptionsSpecification
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name:               "dualstack",
+			ipAddressType:      types.IpAddressTypeDualstack,
+			dnsRecordIpType:    types.DnsRecordIpTypeDualstack,
+			expectedDnsOptions: &types.DnsOptionsSpecification{DnsRecordIpType: types.DnsRecordIpTypeDualstack},
+		},
+		{
+			name:               "ipv6",
+			ipAddressType:      types.IpAddressTypeIpv6,
+			dnsRecordIpType:    types.DnsRecordIpTypeIpv6,
+			expectedDnsOptions: &types.DnsOptionsSpecification{DnsRecordIpType: types.DnsRecordIpTypeIpv6},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := &MockedEC2{}
+			client := NewAwsClientWithServiceClients(mock, &MockedRoute53{})
+
+			_, err := client.CreateDefaultInterfaceVPCEndpoint(context.TODO(), "name", MockVpcId, MockVpcEndpointServiceName, MockLegacyClusterTag, nil, "", "", test.ipAddressType, test.dnsRecordIpType)
+			assert.NoError(t, err)
+			assert.Equal(t, test.ipAddressType, mock.LastCreateVpcEndpointInput.IpAddressType)
+			assert.Equal(t, test.expectedDnsOptions, mock.LastCreateVpcEndpointInput.DnsOptions)
+		})
+	}
+}
+
+func TestAWSClient_CreateDefaultGatewayVPCEndpoint(t *testing.T) {
+	mock := &MockedEC2{}
+	client := NewAwsClientWithServiceClients(mock, &MockedRoute53{})
+
+	_, err := client.CreateDefaultGatewayVPCEndpoint(context.TODO(), "name", MockVpcId, "com.amazonaws.us-east-1.s3", MockLegacyClusterTag, nil, "token", `{"Statement":[]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, types.VpcEndpointTypeGateway, mock.LastCreateVpcEndpointInput.VpcEndpointType)
+	assert.Equal(t, aws.String("token"), mock.LastCreateVpcEndpointInput.ClientToken)
+	assert.Equal(t, aws.String(`{"Statement":[]}`), mock.LastCreateVpcEndpointInput.PolicyDocument)
+	assert.Empty(t, mock.LastCreateVpcEndpointInput.SubnetIds)
+}
+
+func TestAWSClient_CreateDefaultGatewayLoadBalancerVPCEndpoint(t *testing.T) {
+	mock := &MockedEC2{}
+	client := NewAwsClientWithServiceClients(mock, &MockedRoute53{})
+
+	_, err := client.CreateDefaultGatewayLoadBalancerVPCEndpoint(context.TODO(), "name", MockVpcId, "com.amazonaws.vpce.us-east-1.vpce-svc-12345", MockPrivateSubnetId, MockLegacyClusterTag, nil, "token")
+	assert.NoError(t, err)
+	assert.Equal(t, types.VpcEndpointTypeGatewayLoadBalancer, mock.LastCreateVpcEndpointInput.VpcEndpointType)
+	assert.Equal(t, aws.String("token"), mock.LastCreateVpcEndpointInput.ClientToken)
+	assert.Equal(t, []string{MockPrivateSubnetId}, mock.LastCreateVpcEndpointInput.SubnetIds)
+	assert.Empty(t, mock.LastCreateVpcEndpointInput.SecurityGroupIds)
+}
+
 func TestAWSClient_GetVpcCidrBlock(t *testing.T) {
 	client := NewMockedAwsClient()
 
@@ -112,6 +253,39 @@ func TestAWSClient_GetVpcCidrBlock(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAWSClient_GetNetworkInterfacePrivateIps(t *testing.T) {
+	client := NewMockedAwsClient()
+
+	ips, err := client.GetNetworkInterfacePrivateIps(context.TODO(), []string{MockNetworkInterfaceId})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{MockAvailabilityZone: {MockNetworkInterfaceIp}}, ips)
+
+	_, err = client.GetNetworkInterfacePrivateIps(context.TODO(), nil)
+	assert.Error(t, err)
+}
+
+func TestAWSClient_GetVpcIpv6CidrBlocks(t *testing.T) {
+	client := NewMockedAwsClient()
+
+	cidrs, err := client.GetVpcIpv6CidrBlocks(context.TODO(), MockVpcId)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{MockVpcIpv6Cidr}, cidrs)
+
+	_, err = client.GetVpcIpv6CidrBlocks(context.TODO(), "")
+	assert.Error(t, err)
+}
+
+func TestAWSClient_GetNetworkInterfaceIpv6Ips(t *testing.T) {
+	client := NewMockedAwsClient()
+
+	ips, err := client.GetNetworkInterfaceIpv6Ips(context.TODO(), []string{MockNetworkInterfaceId})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{MockAvailabilityZone: {MockNetworkInterfaceIpv6}}, ips)
+
+	_, err = client.GetNetworkInterfaceIpv6Ips(context.TODO(), nil)
+	assert.Error(t, err)
+}
+
 func TestAWSClient_ModifyVPCEndpoint(t *testing.T) {
 	tests := []struct {
 		input     *ec2.ModifyVpcEndpointInput
@@ -26,55 +26,86 @@ import (
 
 // GetInfraId returns the infra id of a hostedcontrolplane
 func GetInfraId(ctx context.Context, c client.Client, namespace string) (string, error) {
-	hcpList := new(hyperv1beta1.HostedControlPlaneList)
+	hcp, err := getHostedControlPlane(ctx, c, namespace)
+	if err != nil {
+		return "", err
+	}
 
-	if err := c.List(ctx, hcpList, &client.ListOptions{
-		Namespace: namespace,
-	}); err != nil {
+	if hcp.Spec.InfraID == "" {
+		return "", fmt.Errorf("blank .spec.infraId for %s", hcp.Name)
+	}
+
+	return hcp.Spec.InfraID, nil
+}
+
+// GetPrivateHostedZoneDomainName returns the domain name for a hosted cluster's private hosted zone
+func GetPrivateHostedZoneDomainName(ctx context.Context, c client.Client, namespace string) (string, error) {
+	hcp, err := getHostedControlPlane(ctx, c, namespace)
+	if err != nil {
 		return "", err
 	}
 
-	if len(hcpList.Items) == 1 {
-		if hcpList.Items[0].Spec.InfraID == "" {
-			return "", fmt.Errorf("blank .spec.infraId for %s", hcpList.Items[0].Name)
+	for _, svc := range hcp.Spec.Services {
+		if svc.Service == hyperv1beta1.APIServer {
+			if svc.Type == hyperv1beta1.Route && svc.Route.Hostname != "" {
+				// The hostname contains the full api.${basedomain}, so take out the leading "api"
+				var domainName string
+				if _, err := fmt.Sscanf(svc.Route.Hostname, "api.%s", &domainName); err != nil {
+					return "", err
+				}
+				return domainName, nil
+			} else {
+				return "", fmt.Errorf("unable to find APIServer route hostname in hostedcontrolplane .spec.services")
+			}
 		}
+	}
+
+	return "", fmt.Errorf("unable to find APIServer url in hostedcontrolplane .spec.services")
+}
+
+// GetClusterNetworkCidrs returns the CIDRs of the hosted cluster's pod network
+func GetClusterNetworkCidrs(ctx context.Context, c client.Client, namespace string) ([]string, error) {
+	hcp, err := getHostedControlPlane(ctx, c, namespace)
+	if err != nil {
+		return nil, err
+	}
 
-		return hcpList.Items[0].Spec.InfraID, nil
+	cidrs := make([]string, len(hcp.Spec.Networking.ClusterNetwork))
+	for i := range hcp.Spec.Networking.ClusterNetwork {
+		cidrs[i] = hcp.Spec.Networking.ClusterNetwork[i].CIDR.String()
 	}
 
-	return "", fmt.Errorf("found %d hostedcontrolplanes in namespace: %s, expected 1", len(hcpList.Items), namespace)
+	return cidrs, nil
 }
 
-// GetPrivateHostedZoneDomainName returns the domain name for a hosted cluster's private hosted zone
-func GetPrivateHostedZoneDomainName(ctx context.Context, c client.Client, namespace string) (string, error) {
+// GetMachineNetworkCidrs returns the CIDRs of the hosted cluster's machine network
+func GetMachineNetworkCidrs(ctx context.Context, c client.Client, namespace string) ([]string, error) {
+	hcp, err := getHostedControlPlane(ctx, c, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	cidrs := make([]string, len(hcp.Spec.Networking.MachineNetwork))
+	for i := range hcp.Spec.Networking.MachineNetwork {
+		cidrs[i] = hcp.Spec.Networking.MachineNetwork[i].CIDR.String()
+	}
+
+	return cidrs, nil
+}
+
+// getHostedControlPlane returns the only hostedcontrolplane in the namespace
+func getHostedControlPlane(ctx context.Context, c client.Client, namespace string) (*hyperv1beta1.HostedControlPlane, error) {
 	hcpList := new(hyperv1beta1.HostedControlPlaneList)
 
 	if err := c.List(ctx, hcpList, &client.ListOptions{
 		Namespace: namespace,
 	}); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if len(hcpList.Items) == 1 {
-		if hcpList.Items[0].Spec.Services != nil {
-			for _, svc := range hcpList.Items[0].Spec.Services {
-				if svc.Service == hyperv1beta1.APIServer {
-					if svc.Type == hyperv1beta1.Route && svc.Route.Hostname != "" {
-						// The hostname contains the full api.${basedomain}, so take out the leading "api"
-						var domainName string
-						if _, err := fmt.Sscanf(svc.Route.Hostname, "api.%s", &domainName); err != nil {
-							return "", err
-						}
-						return domainName, nil
-					} else {
-						return "", fmt.Errorf("unable to find APIServer route hostname in hostedcontrolplane .spec.services")
-					}
-				}
-			}
-		}
-
-		return "", fmt.Errorf("unable to find APIServer url in hostedcontrolplane .spec.services")
+	if len(hcpList.Items) != 1 {
+		return nil, fmt.Errorf("found %d hostedcontrolplanes in namespace: %s, expected 1", len(hcpList.Items), namespace)
 	}
 
-	return "", fmt.Errorf("found %d hostedcontrolplanes in namespace: %s, expected 1", len(hcpList.Items), namespace)
+	return &hcpList.Items[0], nil
 }
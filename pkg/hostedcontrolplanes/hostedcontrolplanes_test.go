@@ -22,6 +22,7 @@ import (
 
 	"github.com/openshift/aws-vpce-operator/pkg/testutil"
 	hyperv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/api/util/ipnet"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -168,3 +169,30 @@ func TestGetPrivateHostedZoneDomainName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNetworkCidrs(t *testing.T) {
+	hcp := &hyperv1beta1.HostedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "name",
+			Namespace: "example",
+		},
+		Spec: hyperv1beta1.HostedControlPlaneSpec{
+			Networking: hyperv1beta1.ClusterNetworking{
+				ClusterNetwork: []hyperv1beta1.ClusterNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.132.0.0/14")}},
+				MachineNetwork: []hyperv1beta1.MachineNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.0.0.0/16")}},
+			},
+		},
+	}
+	mock := testutil.NewTestMock(t, hcp)
+
+	clusterNetwork, err := GetClusterNetworkCidrs(context.TODO(), mock.Client, "example")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"10.132.0.0/14"}, clusterNetwork)
+
+	machineNetwork, err := GetMachineNetworkCidrs(context.TODO(), mock.Client, "example")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"10.0.0.0/16"}, machineNetwork)
+
+	_, err = GetMachineNetworkCidrs(context.TODO(), mock.Client, "example2")
+	assert.NotNil(t, err)
+}
@@ -27,12 +27,22 @@ import (
 
 const defaultInfrastructuresName = "cluster"
 
-// GetAWSRegion returns the AWS region for the given cluster
-func GetAWSRegion(ctx context.Context, c client.Client) (string, error) {
+// GetInfrastructure returns the cluster's infrastructure
+func GetInfrastructure(ctx context.Context, c client.Client) (*configv1.Infrastructure, error) {
 	infrastructures := new(configv1.Infrastructure)
 
 	if err := c.Get(ctx, client.ObjectKey{Name: defaultInfrastructuresName}, infrastructures); err != nil {
-		return "", fmt.Errorf("failed to get infrastructure %s: %w", defaultInfrastructuresName, err)
+		return nil, fmt.Errorf("failed to get infrastructure %s: %w", defaultInfrastructuresName, err)
+	}
+
+	return infrastructures, nil
+}
+
+// GetAWSRegion returns the AWS region for the given cluster
+func GetAWSRegion(ctx context.Context, c client.Client) (string, error) {
+	infrastructures, err := GetInfrastructure(ctx, c)
+	if err != nil {
+		return "", err
 	}
 
 	if infrastructures.Status.PlatformStatus.Type != "AWS" || infrastructures.Status.PlatformStatus.AWS == nil {
@@ -44,10 +54,9 @@ func GetAWSRegion(ctx context.Context, c client.Client) (string, error) {
 
 // GetInfrastructureName returns the .status.infrastructureName for the given cluster
 func GetInfrastructureName(ctx context.Context, c client.Client) (string, error) {
-	infrastructures := new(configv1.Infrastructure)
-
-	if err := c.Get(ctx, client.ObjectKey{Name: defaultInfrastructuresName}, infrastructures); err != nil {
-		return "", fmt.Errorf("failed to get infrastructure %s: %w", defaultInfrastructuresName, err)
+	infrastructures, err := GetInfrastructure(ctx, c)
+	if err != nil {
+		return "", err
 	}
 
 	return infrastructures.Status.InfrastructureName, nil
@@ -46,6 +46,17 @@ var mockInfrastructure = &configv1.Infrastructure{
 	},
 }
 
+func TestGetInfrastructure(t *testing.T) {
+	mock := testutil.NewTestMock(t, mockInfrastructure)
+	actual, err := GetInfrastructure(context.TODO(), mock.Client)
+	assert.Nil(t, err)
+	assert.Equal(t, mockInfrastructureName, actual.Status.InfrastructureName)
+
+	mock = testutil.NewTestMock(t)
+	_, err = GetInfrastructure(context.TODO(), mock.Client)
+	assert.NotNil(t, err)
+}
+
 func TestGetInfrastructureName(t *testing.T) {
 	tests := []struct {
 		infra     *configv1.Infrastructure
@@ -0,0 +1,105 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultNetworksName = "cluster"
+
+	// installConfigNamespace, installConfigName, and installConfigKey locate the install-config the cluster was
+	// installed with, which is the only place the machine network is recorded for non-HyperShift clusters
+	installConfigNamespace = "kube-system"
+	installConfigName      = "cluster-config-v1"
+	installConfigKey       = "install-config"
+)
+
+// installConfig is the subset of the cluster's install-config needed to find its networks
+type installConfig struct {
+	Networking struct {
+		MachineNetwork []struct {
+			CIDR string `json:"cidr"`
+		} `json:"machineNetwork"`
+
+		// MachineCIDR is the deprecated single machine network of install-configs predating machineNetwork
+		MachineCIDR string `json:"machineCIDR"`
+	} `json:"networking"`
+}
+
+// GetClusterNetworkCidrs returns the CIDRs of the cluster's pod network, preferring the network configuration's
+// .status over its .spec
+func GetClusterNetworkCidrs(ctx context.Context, c client.Client) ([]string, error) {
+	network := new(configv1.Network)
+
+	if err := c.Get(ctx, client.ObjectKey{Name: defaultNetworksName}, network); err != nil {
+		return nil, fmt.Errorf("failed to get network %s: %w", defaultNetworksName, err)
+	}
+
+	entries := network.Status.ClusterNetwork
+	if len(entries) == 0 {
+		entries = network.Spec.ClusterNetwork
+	}
+
+	cidrs := make([]string, len(entries))
+	for i := range entries {
+		cidrs[i] = entries[i].CIDR
+	}
+
+	return cidrs, nil
+}
+
+// GetMachineNetworkCidrs returns the CIDRs of the cluster's machine network from its install-config, falling back to
+// the legacy machineCIDR for clusters installed before machineNetwork was introduced
+func GetMachineNetworkCidrs(ctx context.Context, c client.Reader) ([]string, error) {
+	cm := new(corev1.ConfigMap)
+
+	// We use an APIReader instead of reading from the cache here so that the controller doesn't need to watch every
+	// ConfigMap in the cluster
+	if err := c.Get(ctx, client.ObjectKey{Namespace: installConfigNamespace, Name: installConfigName}, cm); err != nil {
+		return nil, fmt.Errorf("failed to get configmap %s/%s: %w", installConfigNamespace, installConfigName, err)
+	}
+
+	data, ok := cm.Data[installConfigKey]
+	if !ok {
+		return nil, errors.New("install-config not found")
+	}
+
+	config := new(installConfig)
+	if err := yaml.Unmarshal([]byte(data), config); err != nil {
+		return nil, fmt.Errorf("failed to parse install-config: %w", err)
+	}
+
+	if len(config.Networking.MachineNetwork) == 0 && config.Networking.MachineCIDR != "" {
+		return []string{config.Networking.MachineCIDR}, nil
+	}
+
+	cidrs := make([]string, len(config.Networking.MachineNetwork))
+	for i := range config.Networking.MachineNetwork {
+		cidrs[i] = config.Networking.MachineNetwork[i].CIDR
+	}
+
+	return cidrs, nil
+}
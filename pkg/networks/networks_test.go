@@ -0,0 +1,157 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networks
+
+import (
+	"context"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGetClusterNetworkCidrs(t *testing.T) {
+	tests := []struct {
+		name      string
+		network   *configv1.Network
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name: "status",
+			network: &configv1.Network{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: defaultNetworksName,
+				},
+				Spec: configv1.NetworkSpec{
+					ClusterNetwork: []configv1.ClusterNetworkEntry{{CIDR: "10.132.0.0/14"}},
+				},
+				Status: configv1.NetworkStatus{
+					ClusterNetwork: []configv1.ClusterNetworkEntry{{CIDR: "10.128.0.0/14"}, {CIDR: "fd01::/48"}},
+				},
+			},
+			expected: []string{"10.128.0.0/14", "fd01::/48"},
+		},
+		{
+			name: "spec",
+			network: &configv1.Network{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: defaultNetworksName,
+				},
+				Spec: configv1.NetworkSpec{
+					ClusterNetwork: []configv1.ClusterNetworkEntry{{CIDR: "10.132.0.0/14"}},
+				},
+			},
+			expected: []string{"10.132.0.0/14"},
+		},
+		{
+			name: "non-default network name",
+			network: &configv1.Network{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "other",
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := testutil.NewTestMock(t, test.network)
+			actual, err := GetClusterNetworkCidrs(context.TODO(), mock.Client)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetMachineNetworkCidrs(t *testing.T) {
+	tests := []struct {
+		name      string
+		objs      []client.Object
+		expected  []string
+		expectErr bool
+	}{
+		{
+			name: "install-config",
+			objs: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      installConfigName,
+						Namespace: installConfigNamespace,
+					},
+					Data: map[string]string{
+						installConfigKey: "apiVersion: v1\nnetworking:\n  machineNetwork:\n  - cidr: 10.0.0.0/16\n  - cidr: 10.1.0.0/16\n",
+					},
+				},
+			},
+			expected: []string{"10.0.0.0/16", "10.1.0.0/16"},
+		},
+		{
+			name: "legacy install-config",
+			objs: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      installConfigName,
+						Namespace: installConfigNamespace,
+					},
+					Data: map[string]string{
+						installConfigKey: "apiVersion: v1\nnetworking:\n  machineCIDR: 10.0.0.0/16\n",
+					},
+				},
+			},
+			expected: []string{"10.0.0.0/16"},
+		},
+		{
+			name: "missing install-config",
+			objs: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      installConfigName,
+						Namespace: installConfigNamespace,
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "missing configmap",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := testutil.NewTestMock(t, test.objs...)
+			actual, err := GetMachineNetworkCidrs(context.TODO(), mock.Client)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
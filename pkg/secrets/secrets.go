@@ -20,17 +20,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	stsTypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
 
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// AssumeRoleOptions configures the sts:AssumeRole call made by NewAssumeRoleCredentials
+type AssumeRoleOptions struct {
+	// ExternalId is passed to sts:AssumeRole when not empty
+	ExternalId string
+	// SessionName is the role session name, defaults to the AWS SDK's generated name when empty
+	SessionName string
+	// SessionTags are passed to sts:AssumeRole as session tags and require sts:TagSession in the role's trust policy
+	SessionTags map[string]string
+}
+
 const (
 	defaultRoleArn            = "role_arn"
 	defaultAWSAccessKeyId     = "aws_access_key_id"     //#nosec G101
@@ -40,17 +53,32 @@ const (
 // ParseAWSCredentialOverride takes in an AWS region and a secret reference and attempts to assemble an aws.Config
 // Currently only supports parsing AWS IAM User credentials
 func ParseAWSCredentialOverride(ctx context.Context, c client.Reader, region string, ref *corev1.SecretReference) (aws.Config, error) {
+	secret, err := GetAWSCredentialOverride(ctx, c, ref)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	return AWSConfigFromSecret(ctx, region, secret)
+}
+
+// GetAWSCredentialOverride returns the AWS credential override secret for the provided secret reference
+func GetAWSCredentialOverride(ctx context.Context, c client.Reader, ref *corev1.SecretReference) (*corev1.Secret, error) {
 	if ref == nil {
-		return aws.Config{}, errors.New("AWS Credential Override secret reference must not be nil")
+		return nil, errors.New("AWS Credential Override secret reference must not be nil")
 	}
 
 	secret := new(corev1.Secret)
 	// We use an APIReader instead of reading from the cache here so that the controller can minimize
 	// the K8s RBAC needed to only get secrets where desired
 	if err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
-		return aws.Config{}, err
+		return nil, err
 	}
 
+	return secret, nil
+}
+
+// AWSConfigFromSecret assembles an aws.Config for the AWS region from an AWS credential override secret
+func AWSConfigFromSecret(ctx context.Context, region string, secret *corev1.Secret) (aws.Config, error) {
 	if roleArn, ok := secret.Data[defaultRoleArn]; ok {
 		// Build a client that assumes the provided role is the secret contains one
 		// https://pkg.go.dev/github.com/aws/aws-sdk-go-v2/credentials/stscreds#hdr-Assume_Role
@@ -58,9 +86,7 @@ func ParseAWSCredentialOverride(ctx context.Context, c client.Reader, region str
 		if err != nil {
 			return aws.Config{}, fmt.Errorf("failed to build AWS client. Error: %w", err)
 		}
-		stsSvc := sts.NewFromConfig(cfg)
-		creds := stscreds.NewAssumeRoleProvider(stsSvc, string(roleArn))
-		cfg.Credentials = aws.NewCredentialsCache(creds)
+		cfg.Credentials = NewAssumeRoleCredentials(sts.NewFromConfig(cfg), string(roleArn), AssumeRoleOptions{})
 
 		return cfg, nil
 	}
@@ -76,3 +102,50 @@ func ParseAWSCredentialOverride(ctx context.Context, c client.Reader, region str
 
 	return aws.Config{}, fmt.Errorf("could not parse credential override secret, requires data keys %s and %s", defaultAWSAccessKeyId, defaultAWSSecretAccessKey)
 }
+
+// CredentialSource returns the aws_client.ClientPoolKey credential source of an AWS credential override secret
+func CredentialSource(secret *corev1.Secret) string {
+	return fmt.Sprintf("secret/%s/%s", secret.Namespace, secret.Name)
+}
+
+// ClientPoolKeyOptions returns the aws_client.ClientPoolKey assume role options for the provided options
+func (o AssumeRoleOptions) ClientPoolKeyOptions() string {
+	keys := make([]string, 0, len(o.SessionTags))
+	for k := range o.SessionTags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	tags := make([]string, len(keys))
+	for i, k := range keys {
+		tags[i] = fmt.Sprintf("%s=%s", k, o.SessionTags[k])
+	}
+
+	return fmt.Sprintf("externalId=%s,sessionName=%s,tags=%s", o.ExternalId, o.SessionName, strings.Join(tags, ","))
+}
+
+// NewAssumeRoleCredentials returns a cached credentials provider that assumes the provided role with the provided
+// STS client. The STS client's own credentials are used as the source credentials, allowing role chaining.
+func NewAssumeRoleCredentials(stsSvc stscreds.AssumeRoleAPIClient, roleArn string, opts AssumeRoleOptions) *aws.CredentialsCache {
+	return aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(stsSvc, roleArn, func(o *stscreds.AssumeRoleOptions) {
+		if opts.ExternalId != "" {
+			o.ExternalID = aws.String(opts.ExternalId)
+		}
+
+		if opts.SessionName != "" {
+			o.RoleSessionName = opts.SessionName
+		}
+
+		keys := make([]string, 0, len(opts.SessionTags))
+		for k := range opts.SessionTags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			o.Tags = append(o.Tags, stsTypes.Tag{
+				Key:   aws.String(k),
+				Value: aws.String(opts.SessionTags[k]),
+			})
+		}
+	}))
+}
@@ -19,6 +19,12 @@ package secrets
 import (
 	"context"
 	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	stsTypes "github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/openshift/aws-vpce-operator/pkg/testutil"
 	corev1 "k8s.io/api/core/v1"
@@ -83,3 +89,80 @@ func TestParseAWSCredentialOverride(t *testing.T) {
 		})
 	}
 }
+
+type mockAssumeRoleAPIClient struct {
+	input *sts.AssumeRoleInput
+}
+
+func (m *mockAssumeRoleAPIClient) AssumeRole(ctx context.Context, params *sts.AssumeRoleInput, optFns ...func(*sts.Options)) (*sts.AssumeRoleOutput, error) {
+	m.input = params
+	return &sts.AssumeRoleOutput{
+		Credentials: &stsTypes.Credentials{
+			AccessKeyId:     aws.String(mockAWSAccessKeyId),
+			SecretAccessKey: aws.String(mockAWSSecretAccessKey),
+			SessionToken:    aws.String("mock_session_token"),
+			Expiration:      aws.Time(time.Now().Add(time.Hour)),
+		},
+	}, nil
+}
+
+func TestNewAssumeRoleCredentials(t *testing.T) {
+	tests := []struct {
+		name                string
+		opts                AssumeRoleOptions
+		expectedExternalId  *string
+		expectedSessionName string
+		expectedTags        []stsTypes.Tag
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name: "external id, session name, and session tags",
+			opts: AssumeRoleOptions{
+				ExternalId:  "mock-external-id",
+				SessionName: "mock-session",
+				SessionTags: map[string]string{
+					"namespace": "mock-ns",
+					"name":      "mock-name",
+				},
+			},
+			expectedExternalId:  aws.String("mock-external-id"),
+			expectedSessionName: "mock-session",
+			expectedTags: []stsTypes.Tag{
+				{Key: aws.String("name"), Value: aws.String("mock-name")},
+				{Key: aws.String("namespace"), Value: aws.String("mock-ns")},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mock := &mockAssumeRoleAPIClient{}
+			creds := NewAssumeRoleCredentials(mock, "arn:aws:iam::123456789012:role/mock", test.opts)
+			if _, err := creds.Retrieve(context.TODO()); err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+
+			assert.Equal(t, "arn:aws:iam::123456789012:role/mock", aws.ToString(mock.input.RoleArn))
+			assert.Equal(t, test.expectedExternalId, mock.input.ExternalId)
+			assert.Equal(t, test.expectedTags, mock.input.Tags)
+			if test.expectedSessionName != "" {
+				assert.Equal(t, test.expectedSessionName, aws.ToString(mock.input.RoleSessionName))
+			} else {
+				assert.NotEmpty(t, aws.ToString(mock.input.RoleSessionName))
+			}
+		})
+	}
+}
+
+func TestAssumeRoleOptions_ClientPoolKeyOptions(t *testing.T) {
+	opts := AssumeRoleOptions{
+		ExternalId:  "mock-external-id",
+		SessionName: "mock-session",
+		SessionTags: map[string]string{"b": "2", "a": "1"},
+	}
+
+	assert.Equal(t, "externalId=mock-external-id,sessionName=mock-session,tags=a=1,b=2", opts.ClientPoolKeyOptions())
+	assert.NotEqual(t, opts.ClientPoolKeyOptions(), AssumeRoleOptions{SessionName: "mock-session"}.ClientPoolKeyOptions())
+}
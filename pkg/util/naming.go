@@ -17,8 +17,12 @@ limitations under the License.
 package util
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
@@ -28,6 +32,8 @@ import (
 const (
 	OperatorTagKey           = "kubernetes.io/aws-vpce-operator"
 	OperatorTagValue         = "managed"
+	OperatorTagRetainedValue = "retained"
+	OrphanedTagKey           = "kubernetes.io/aws-vpce-operator-orphaned"
 	RedHatManagedTagKey      = "red-hat-managed"
 	RedHatManagedTagValue    = "true"
 	SecurityGroupDescription = "Managed by AWS VPCE Operator"
@@ -82,6 +88,70 @@ func GenerateAwsTagsAsMap(name, clusterTagKey string) (map[string]string, error)
 	return tagsMap, nil
 }
 
+// IsReservedTagKey returns true if the tag key is used by this operator to identify the AWS resources it manages,
+// or is reserved by AWS, and so can't be supplied by users
+func IsReservedTagKey(key string) bool {
+	return key == OperatorTagKey ||
+		key == OrphanedTagKey ||
+		key == RedHatManagedTagKey ||
+		key == "Name" ||
+		strings.HasPrefix(key, LegacyClusterTagPrefix+"/") ||
+		strings.HasPrefix(key, CapiClusterTagPrefix+"/") ||
+		strings.HasPrefix(strings.ToLower(key), "aws:")
+}
+
+// MergeUserTags merges user-supplied tags, with tags later in the list taking precedence, and drops reserved keys
+func MergeUserTags(tagSets ...map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, tags := range tagSets {
+		for k, v := range tags {
+			if !IsReservedTagKey(k) {
+				merged[k] = v
+			}
+		}
+	}
+
+	return merged
+}
+
+// GenerateUserAwsTags converts user-supplied tags into EC2 tags sorted by key, dropping reserved keys
+func GenerateUserAwsTags(userTags map[string]string) []types.Tag {
+	var tags []types.Tag
+	for _, k := range sortedUserTagKeys(userTags) {
+		tags = append(tags, types.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(userTags[k]),
+		})
+	}
+
+	return tags
+}
+
+// GenerateUserR53Tags converts user-supplied tags into Route 53 tags sorted by key, dropping reserved keys
+func GenerateUserR53Tags(userTags map[string]string) []route53Types.Tag {
+	var tags []route53Types.Tag
+	for _, k := range sortedUserTagKeys(userTags) {
+		tags = append(tags, route53Types.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(userTags[k]),
+		})
+	}
+
+	return tags
+}
+
+func sortedUserTagKeys(userTags map[string]string) []string {
+	keys := make([]string, 0, len(userTags))
+	for k := range userTags {
+		if !IsReservedTagKey(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
 // GetClusterLegacyTagKey returns the tag assigned to all AWS resources for the given cluster
 func GetClusterLegacyTagKey(infraName string) (string, error) {
 	if infraName == "" {
@@ -158,3 +228,16 @@ func GenerateR53Tags(clusterTagKey string) ([]route53Types.Tag, error) {
 		},
 	}, nil
 }
+
+// GenerateClientToken generates a deterministic idempotency token for AWS create calls, e.g. an EC2 ClientToken or
+// Route 53 CallerReference, from a Kubernetes object's UID and generation. Additional parts distinguish between the
+// different AWS resources created for the same object. The token is a 64 character hex string, which fits within
+// the 64 character limit of EC2 ClientTokens. If uid is empty, no token can be derived and an empty string is returned.
+func GenerateClientToken(uid string, generation int64, parts ...string) string {
+	if uid == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(append([]string{uid, fmt.Sprint(generation)}, parts...), "/")))
+	return hex.EncodeToString(sum[:])
+}
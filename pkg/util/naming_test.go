@@ -91,6 +91,62 @@ func TestGenerateAwsTagsAsMap(t *testing.T) {
 	}
 }
 
+func TestMergeUserTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tagSets  []map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "no tags",
+			expected: map[string]string{},
+		},
+		{
+			name: "later tags take precedence",
+			tagSets: []map[string]string{
+				{"cost-center": "1234", "team": "sre"},
+				{"cost-center": "5678"},
+			},
+			expected: map[string]string{"cost-center": "5678", "team": "sre"},
+		},
+		{
+			name: "reserved keys are dropped",
+			tagSets: []map[string]string{
+				{
+					"Name":                                 "override",
+					OperatorTagKey:                         "unmanaged",
+					RedHatManagedTagKey:                    "false",
+					LegacyClusterTagPrefix + "/mock-12345": "shared",
+					CapiClusterTagPrefix + "/mock-12345":   "shared",
+					"aws:cloudformation:stack-name":        "stack",
+					"owner":                                "sre",
+				},
+			},
+			expected: map[string]string{"owner": "sre"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, MergeUserTags(test.tagSets...))
+		})
+	}
+}
+
+func TestGenerateUserAwsTags(t *testing.T) {
+	tags := GenerateUserAwsTags(map[string]string{"b": "2", "a": "1", "Name": "override"})
+	assert.Len(t, tags, 2)
+	assert.Equal(t, "a", *tags[0].Key)
+	assert.Equal(t, "1", *tags[0].Value)
+	assert.Equal(t, "b", *tags[1].Key)
+	assert.Equal(t, "2", *tags[1].Value)
+
+	r53Tags := GenerateUserR53Tags(map[string]string{"b": "2", "a": "1", OperatorTagKey: "unmanaged"})
+	assert.Len(t, r53Tags, 2)
+	assert.Equal(t, "a", *r53Tags[0].Key)
+	assert.Equal(t, "b", *r53Tags[1].Key)
+}
+
 func TestGetClusterLegacyTagKey(t *testing.T) {
 	tests := []struct {
 		infraName string
@@ -220,3 +276,14 @@ func TestGenerateVPCEndpointName(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateClientToken(t *testing.T) {
+	assert.Empty(t, GenerateClientToken("", 1, "vpce"))
+
+	token := GenerateClientToken("uid", 1, "vpce")
+	assert.Len(t, token, 64)
+	assert.Equal(t, token, GenerateClientToken("uid", 1, "vpce"))
+	assert.NotEqual(t, token, GenerateClientToken("uid", 2, "vpce"))
+	assert.NotEqual(t, token, GenerateClientToken("uid", 1, "sg"))
+	assert.NotEqual(t, token, GenerateClientToken("other-uid", 1, "vpce"))
+}
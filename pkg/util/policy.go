@@ -0,0 +1,50 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+// NormalizePolicyDocument returns the compact JSON encoding of an IAM policy document with its object keys sorted, so
+// that policy documents can be compared regardless of their formatting. Returns an error if the policy document is
+// not a JSON object.
+func NormalizePolicyDocument(document string) (string, error) {
+	var policy map[string]interface{}
+	if err := json.Unmarshal([]byte(document), &policy); err != nil {
+		return "", err
+	}
+	if policy == nil {
+		return "", errors.New("policy document must be a JSON object")
+	}
+
+	normalized, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+
+	return string(normalized), nil
+}
+
+// PolicyDocumentHash returns the hex-encoded SHA-256 hash of a normalized policy document
+func PolicyDocumentHash(normalized string) string {
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
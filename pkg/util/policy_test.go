@@ -0,0 +1,79 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePolicyDocument(t *testing.T) {
+	tests := []struct {
+		name      string
+		document  string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name: "formatting and key order are normalized",
+			document: `{
+  "Version": "2012-10-17",
+  "Statement": [{"Principal": "*", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "*"}]
+}`,
+			expected: `{"Statement":[{"Action":"s3:GetObject","Effect":"Allow","Principal":"*","Resource":"*"}],"Version":"2012-10-17"}`,
+		},
+		{
+			name:      "invalid JSON",
+			document:  `{"Version":`,
+			expectErr: true,
+		},
+		{
+			name:      "not an object",
+			document:  `["Version"]`,
+			expectErr: true,
+		},
+		{
+			name:      "null",
+			document:  "null",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := NormalizePolicyDocument(test.document)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPolicyDocumentHash(t *testing.T) {
+	a, err := NormalizePolicyDocument(`{"Version": "2012-10-17", "Statement": []}`)
+	assert.NoError(t, err)
+	b, err := NormalizePolicyDocument(`{"Statement":[],"Version":"2012-10-17"}`)
+	assert.NoError(t, err)
+
+	assert.Len(t, PolicyDocumentHash(a), 64)
+	assert.Equal(t, PolicyDocumentHash(a), PolicyDocumentHash(b))
+	assert.NotEqual(t, PolicyDocumentHash(a), PolicyDocumentHash(`{}`))
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import "regexp"
+
+// awsRegionNameRegex matches the syntax of an AWS region name in any partition, e.g. us-east-1, us-gov-west-1,
+// cn-north-1, or us-iso-east-1
+var awsRegionNameRegex = regexp.MustCompile(`^[a-z]{2,4}(-[a-z]+)+-[0-9]+$`)
+
+// awsRegions are the AWS regions in the aws, aws-us-gov, and aws-cn partitions
+var awsRegions = map[string]bool{
+	"af-south-1":     true,
+	"ap-east-1":      true,
+	"ap-east-2":      true,
+	"ap-northeast-1": true,
+	"ap-northeast-2": true,
+	"ap-northeast-3": true,
+	"ap-south-1":     true,
+	"ap-south-2":     true,
+	"ap-southeast-1": true,
+	"ap-southeast-2": true,
+	"ap-southeast-3": true,
+	"ap-southeast-4": true,
+	"ap-southeast-5": true,
+	"ap-southeast-6": true,
+	"ap-southeast-7": true,
+	"ca-central-1":   true,
+	"ca-west-1":      true,
+	"cn-north-1":     true,
+	"cn-northwest-1": true,
+	"eu-central-1":   true,
+	"eu-central-2":   true,
+	"eu-north-1":     true,
+	"eu-south-1":     true,
+	"eu-south-2":     true,
+	"eu-west-1":      true,
+	"eu-west-2":      true,
+	"eu-west-3":      true,
+	"il-central-1":   true,
+	"me-central-1":   true,
+	"me-south-1":     true,
+	"mx-central-1":   true,
+	"sa-east-1":      true,
+	"us-east-1":      true,
+	"us-east-2":      true,
+	"us-gov-east-1":  true,
+	"us-gov-west-1":  true,
+	"us-west-1":      true,
+	"us-west-2":      true,
+}
+
+// IsAWSRegionName returns true if region has the syntax of an AWS region name. Unlike IsAWSRegion, it accepts regions
+// AWS launched after this operator was built.
+func IsAWSRegionName(region string) bool {
+	return awsRegionNameRegex.MatchString(region)
+}
+
+// IsAWSRegion returns true if region is a known AWS region
+func IsAWSRegion(region string) bool {
+	return awsRegions[region]
+}
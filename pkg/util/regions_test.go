@@ -0,0 +1,48 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAWSRegionName(t *testing.T) {
+	tests := []struct {
+		region   string
+		expected bool
+	}{
+		{region: "us-east-1", expected: true},
+		{region: "us-gov-west-1", expected: true},
+		{region: "cn-north-1", expected: true},
+		{region: "us-iso-east-1", expected: true},
+		{region: "eu-isoe-west-1", expected: true},
+		{region: "xx-future-9", expected: true},
+		{region: "", expected: false},
+		{region: "us-east", expected: false},
+		{region: "useast1", expected: false},
+		{region: "US-EAST-1", expected: false},
+		{region: "us-east-1a", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.region, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsAWSRegionName(test.region))
+		})
+	}
+}
@@ -441,7 +441,7 @@ var _ = Describe("aws-vpce-operator AWS integration", func() {
 		It("should handle an invalid VPC Endpoint Service name gracefully", func(ctx context.Context) {
 			const vpceName = "e2e-invalid-svc"
 			cleanupLeftover(ctx, c, vpceName, ns)
-			vpce := buildVpcEndpoint(vpceName, ns, "com.amazonaws.vpce-svc.doesnotexist")
+			vpce := buildVpcEndpoint(vpceName, ns, fmt.Sprintf("com.amazonaws.vpce.%s.vpce-svc-00000000000000000", helper.region))
 			Expect(c.Create(ctx, vpce)).To(Succeed())
 			DeferCleanup(func(ctx context.Context) {
 				deleteVpceAndWait(ctx, c, vpceName, ns)
@@ -465,6 +465,12 @@ var _ = Describe("aws-vpce-operator AWS integration", func() {
 			}, negativeTestTimeout, pollingInterval).Should(Succeed())
 		})
 
+		It("should reject a malformed VPC Endpoint Service name on admission", func(ctx context.Context) {
+			vpce := buildVpcEndpoint("e2e-malformed-svc", ns, "com.amazonaws.vpce-svc.doesnotexist")
+			err := c.Create(ctx, vpce, &client.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+			Expect(kerr.IsInvalid(err)).To(BeTrue(), "expected the validating webhook to reject the VpcEndpoint, got: %v", err)
+		})
+
 		It("should report VPC Endpoint status transitions", func(ctx context.Context) {
 			const vpceName = "e2e-status-reporting"
 			cleanupLeftover(ctx, c, vpceName, ns)
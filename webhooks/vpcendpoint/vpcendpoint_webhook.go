@@ -0,0 +1,473 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/smithy-go"
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/openshift/aws-vpce-operator/pkg/infrastructures"
+	"github.com/openshift/aws-vpce-operator/pkg/util"
+)
+
+const (
+	WebhookName = "VpcEndpoint"
+
+	// awsValidationTimeout bounds the AWS API calls made while validating a VpcEndpoint so that admission stays fast
+	awsValidationTimeout = 5 * time.Second
+)
+
+var (
+	// endpointServiceNameRegex matches the name of a VPC Endpoint Service, capturing its region
+	endpointServiceNameRegex = regexp.MustCompile(`^com\.amazonaws\.vpce\.([a-z0-9-]+)\.vpce-svc-[0-9a-f]+$`)
+
+	// awsServiceNameRegex matches the name of an AWS service, e.g. com.amazonaws.us-east-1.s3, capturing its region
+	awsServiceNameRegex = regexp.MustCompile(`^(?:cn\.)?com\.amazonaws\.([a-z0-9-]+)\.[a-z0-9][a-z0-9.-]*$`)
+)
+
+//+kubebuilder:webhook:path=/mutate-avo-openshift-io-v1alpha2-vpcendpoint,mutating=true,failurePolicy=fail,sideEffects=None,groups=avo.openshift.io,resources=vpcendpoints,verbs=create;update,versions=v1alpha2,name=mvpcendpoint.avo.openshift.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-avo-openshift-io-v1alpha2-vpcendpoint,mutating=false,failurePolicy=fail,sideEffects=None,groups=avo.openshift.io,resources=vpcendpoints,verbs=create;update,versions=v1alpha2,name=vvpcendpoint.avo.openshift.io,admissionReviewVersions=v1
+
+// VpcEndpointWebhook defaults and validates VpcEndpoints on admission, catching mistakes that can't be expressed
+// in CEL before they fail during reconciliation
+type VpcEndpointWebhook struct {
+	Client client.Client
+
+	// EnableAWSValidation is a feature flag that allows the webhook to call AWS to validate the subnets and VPCs
+	// referenced by a VpcEndpoint
+	EnableAWSValidation bool
+
+	log logr.Logger
+
+	// newAWSClient returns an AWS client using the operator's default credentials in the provided region
+	newAWSClient func(ctx context.Context, region string) (*aws_client.AWSClient, error)
+}
+
+var (
+	_ admission.CustomDefaulter = &VpcEndpointWebhook{}
+	_ admission.CustomValidator = &VpcEndpointWebhook{}
+)
+
+// SetupWebhookWithManager registers the VpcEndpoint defaulting, validating, and conversion webhooks with the
+// manager's webhook server
+func (w *VpcEndpointWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	w.log = mgr.GetLogger().WithName("webhook").WithName(WebhookName)
+	if w.newAWSClient == nil && w.EnableAWSValidation {
+		// The AWS config is only loaded once, admission requests only set its region
+		cfg, err := config.LoadDefaultConfig(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to load the AWS config: %w", err)
+		}
+		w.newAWSClient = newDefaultAWSClient(cfg)
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&avov1alpha2.VpcEndpoint{}).
+		WithDefaulter(w).
+		WithValidator(w).
+		Complete()
+}
+
+// Default sets .spec.region from the Infrastructure CR when creating a VpcEndpoint without one
+func (w *VpcEndpointWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	vpce, ok := obj.(*avov1alpha2.VpcEndpoint)
+	if !ok {
+		return fmt.Errorf("expected a VpcEndpoint but got %T", obj)
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Existing VpcEndpoints without a region keep following the cluster's region
+	if req.Operation != admissionv1.Create {
+		return nil
+	}
+
+	// .spec.region is not supported with subnet or Private Hosted Zone autodiscovery
+	if vpce.Spec.Region != "" || vpce.Spec.Vpc.AutoDiscoverSubnets || vpce.Spec.CustomDns.Route53PrivateHostedZone.AutoDiscover {
+		return nil
+	}
+
+	region, err := infrastructures.GetAWSRegion(ctx, w.Client)
+	if err != nil {
+		// The controller falls back to the Infrastructure CR's region as well, so don't block admission
+		w.log.V(0).Info("Unable to default region", "vpcEndpoint", vpce.Name, "namespace", vpce.Namespace, "error", err.Error())
+		return nil
+	}
+
+	w.log.V(1).Info("Defaulting region", "vpcEndpoint", vpce.Name, "namespace", vpce.Namespace, "region", region)
+	vpce.Spec.Region = region
+
+	return nil
+}
+
+// ValidateCreate validates a new VpcEndpoint
+func (w *VpcEndpointWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	vpce, ok := obj.(*avov1alpha2.VpcEndpoint)
+	if !ok {
+		return nil, fmt.Errorf("expected a VpcEndpoint but got %T", obj)
+	}
+
+	return w.validate(ctx, vpce)
+}
+
+// ValidateUpdate validates an updated VpcEndpoint when its spec has changed
+func (w *VpcEndpointWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldVpce, ok := oldObj.(*avov1alpha2.VpcEndpoint)
+	if !ok {
+		return nil, fmt.Errorf("expected a VpcEndpoint but got %T", oldObj)
+	}
+
+	vpce, ok := newObj.(*avov1alpha2.VpcEndpoint)
+	if !ok {
+		return nil, fmt.Errorf("expected a VpcEndpoint but got %T", newObj)
+	}
+
+	// Metadata-only updates, e.g. removing the finalizer, must not be blocked for VpcEndpoints that were created
+	// before these validations existed
+	if equality.Semantic.DeepEqual(oldVpce.Spec, vpce.Spec) {
+		return nil, nil
+	}
+
+	return w.validate(ctx, vpce)
+}
+
+// ValidateDelete allows all VpcEndpoints to be deleted
+func (w *VpcEndpointWebhook) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (w *VpcEndpointWebhook) validate(ctx context.Context, vpce *avov1alpha2.VpcEndpoint) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+	var warnings admission.Warnings
+	allErrs := field.ErrorList{}
+
+	if vpce.Spec.Region != "" {
+		regionWarnings, errs := validateRegion(specPath.Child("region"), vpce.Spec.Region)
+		warnings = append(warnings, regionWarnings...)
+		allErrs = append(allErrs, errs...)
+	}
+
+	if vpce.Spec.ServiceName != "" {
+		serviceWarnings, errs := validateServiceName(specPath.Child("serviceName"), vpce.Spec.ServiceName, vpce.Spec.Region)
+		warnings = append(warnings, serviceWarnings...)
+		allErrs = append(allErrs, errs...)
+	}
+
+	if vpce.Spec.ServiceNameRef != nil && vpce.Spec.ServiceNameRef.Name != "" {
+		serviceWarnings, errs := validateServiceName(specPath.Child("serviceNameRef", "name"), vpce.Spec.ServiceNameRef.Name, vpce.Spec.Region)
+		warnings = append(warnings, serviceWarnings...)
+		allErrs = append(allErrs, errs...)
+	}
+
+	subnetIdsPath := specPath.Child("vpc", "subnetIds")
+	subnetIds := map[string]bool{}
+	for i, id := range vpce.Spec.Vpc.SubnetIds {
+		if subnetIds[id] {
+			allErrs = append(allErrs, field.Duplicate(subnetIdsPath.Index(i), id))
+		}
+		subnetIds[id] = true
+	}
+
+	securityGroupIdsPath := specPath.Child("securityGroup", "ids")
+	securityGroupIds := map[string]bool{}
+	for i, id := range vpce.Spec.SecurityGroup.Ids {
+		if securityGroupIds[id] {
+			allErrs = append(allErrs, field.Duplicate(securityGroupIdsPath.Index(i), id))
+		}
+		securityGroupIds[id] = true
+	}
+
+	routeTableIdsPath := specPath.Child("routeTables", "ids")
+	routeTableIds := map[string]bool{}
+	for i, id := range vpce.Spec.RouteTables.Ids {
+		if routeTableIds[id] {
+			allErrs = append(allErrs, field.Duplicate(routeTableIdsPath.Index(i), id))
+		}
+		routeTableIds[id] = true
+	}
+
+	routesPath := specPath.Child("routes")
+	routes := map[avov1alpha2.GatewayLoadBalancerRoute]bool{}
+	for i, route := range vpce.Spec.Routes {
+		if routes[route] {
+			allErrs = append(allErrs, field.Duplicate(routesPath.Index(i), route))
+		}
+		routes[route] = true
+	}
+
+	if vpce.Spec.Policy != nil && vpce.Spec.Policy.Document != "" {
+		if _, err := util.NormalizePolicyDocument(vpce.Spec.Policy.Document); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("policy", "document"), vpce.Spec.Policy.Document, fmt.Sprintf("must be a JSON policy document: %v", err)))
+		}
+	}
+
+	associatedVpcsPath := specPath.Child("customDns", "route53PrivateHostedZone", "associatedVpcs")
+	associatedVpcIds := map[string]bool{}
+	for i, associatedVpc := range vpce.Spec.CustomDns.Route53PrivateHostedZone.AssociatedVpcs {
+		if associatedVpcIds[associatedVpc.VpcId] {
+			allErrs = append(allErrs, field.Duplicate(associatedVpcsPath.Index(i).Child("vpcId"), associatedVpc.VpcId))
+		}
+		associatedVpcIds[associatedVpc.VpcId] = true
+
+		regionWarnings, errs := validateRegion(associatedVpcsPath.Index(i).Child("region"), associatedVpc.Region)
+		warnings = append(warnings, regionWarnings...)
+		allErrs = append(allErrs, errs...)
+	}
+
+	allErrs = append(allErrs, validateRecords(specPath.Child("customDns", "route53PrivateHostedZone"), vpce.Spec.CustomDns.Route53PrivateHostedZone)...)
+
+	tagKeys := make([]string, 0, len(vpce.Spec.Tags))
+	for k := range vpce.Spec.Tags {
+		tagKeys = append(tagKeys, k)
+	}
+	sort.Strings(tagKeys)
+	for _, k := range tagKeys {
+		if util.IsReservedTagKey(k) {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("tags").Key(k), "tag key is reserved by AWS or used by AVO to identify the AWS resources it manages"))
+		}
+	}
+
+	// Only call AWS once the VpcEndpoint is otherwise valid
+	if len(allErrs) == 0 && w.EnableAWSValidation {
+		awsWarnings, errs := w.validateAWSResources(ctx, vpce)
+		warnings = append(warnings, awsWarnings...)
+		allErrs = append(allErrs, errs...)
+	}
+
+	if len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(avov1alpha2.GroupVersion.WithKind("VpcEndpoint").GroupKind(), vpce.Name, allErrs)
+	}
+
+	return warnings, nil
+}
+
+// validateRegion validates that region is an AWS region name, warning if it isn't a region known to this operator
+// rather than rejecting it so that regions AWS launches later can be used without rebuilding the operator
+func validateRegion(fldPath *field.Path, region string) (admission.Warnings, field.ErrorList) {
+	if !util.IsAWSRegionName(region) {
+		return nil, field.ErrorList{field.Invalid(fldPath, region, "must be an AWS region")}
+	}
+
+	if !util.IsAWSRegion(region) {
+		return admission.Warnings{fmt.Sprintf("%s %s is not a known AWS region", fldPath, region)}, nil
+	}
+
+	return nil, nil
+}
+
+// validateServiceName validates that serviceName is the name of a VPC Endpoint Service or AWS service in an AWS
+// region, warning if that region is unknown or different from the VpcEndpoint's region
+func validateServiceName(fldPath *field.Path, serviceName, region string) (admission.Warnings, field.ErrorList) {
+	var matches []string
+	switch {
+	case endpointServiceNameRegex.MatchString(serviceName):
+		matches = endpointServiceNameRegex.FindStringSubmatch(serviceName)
+	case awsServiceNameRegex.MatchString(serviceName):
+		matches = awsServiceNameRegex.FindStringSubmatch(serviceName)
+	default:
+		return nil, field.ErrorList{field.Invalid(fldPath, serviceName,
+			"must be a VPC Endpoint Service name, com.amazonaws.vpce.<region>.vpce-svc-<id>, or an AWS service name, com.amazonaws.<region>.<service>")}
+	}
+
+	serviceRegion := matches[1]
+	if !util.IsAWSRegionName(serviceRegion) {
+		return nil, field.ErrorList{field.Invalid(fldPath, serviceName, fmt.Sprintf("%s is not an AWS region", serviceRegion))}
+	}
+
+	var warnings admission.Warnings
+	if !util.IsAWSRegion(serviceRegion) {
+		warnings = append(warnings, fmt.Sprintf("%s is in %s, which is not a known AWS region", fldPath, serviceRegion))
+	}
+
+	if region != "" && region != serviceRegion {
+		warnings = append(warnings, fmt.Sprintf("%s is in %s, which is different from spec.region %s", fldPath, serviceRegion, region))
+	}
+
+	return warnings, nil
+}
+
+// validateRecords validates that the hostnames of a Route53PrivateHostedZone's records are unique, that "*" is only
+// used as the leftmost label of a wildcard hostname, and that ExternalName services are unique
+func validateRecords(fldPath *field.Path, zone avov1alpha2.Route53PrivateHostedZone) field.ErrorList {
+	type indexedRecord struct {
+		path   *field.Path
+		record avov1alpha2.Route53HostedZoneRecord
+	}
+
+	var records []indexedRecord
+	if zone.Record.Hostname != "" {
+		records = append(records, indexedRecord{path: fldPath.Child("record"), record: zone.Record})
+	}
+	for i, record := range zone.Records {
+		records = append(records, indexedRecord{path: fldPath.Child("records").Index(i), record: record})
+	}
+
+	allErrs := field.ErrorList{}
+	hostnames := map[string]bool{}
+	services := map[string]bool{}
+	for _, r := range records {
+		hostnamePath := r.path.Child("hostname")
+		hostname := strings.ToLower(r.record.Hostname)
+		if hostnames[hostname] {
+			allErrs = append(allErrs, field.Duplicate(hostnamePath, r.record.Hostname))
+		}
+		hostnames[hostname] = true
+
+		for i, label := range strings.Split(hostname, ".") {
+			if strings.Contains(label, "*") && (i > 0 || label != "*") {
+				allErrs = append(allErrs, field.Invalid(hostnamePath, r.record.Hostname, `"*" may only be used as the leftmost label of a wildcard hostname`))
+				break
+			}
+		}
+
+		if name := r.record.ExternalNameService.Name; name != "" {
+			if services[name] {
+				allErrs = append(allErrs, field.Duplicate(r.path.Child("externalNameService", "name"), name))
+			}
+			services[name] = true
+		}
+	}
+
+	return allErrs
+}
+
+// validateAWSResources validates that the subnets and VPCs referenced by a VpcEndpoint exist and that its subnets are
+// in distinct Availability Zones of the same VPC. Failures to reach AWS are returned as warnings rather than errors.
+func (w *VpcEndpointWebhook) validateAWSResources(ctx context.Context, vpce *avov1alpha2.VpcEndpoint) (admission.Warnings, field.ErrorList) {
+	if len(vpce.Spec.Vpc.SubnetIds) == 0 && len(vpce.Spec.Vpc.Ids) == 0 {
+		return nil, nil
+	}
+
+	if vpce.Spec.AWSCredentialOverrideRef != nil || vpce.Spec.AssumeRoleArn != "" {
+		return admission.Warnings{"skipped validating subnets and VPCs with AWS because the VpcEndpoint uses its own AWS credentials"}, nil
+	}
+
+	region := vpce.Spec.Region
+	if region == "" {
+		r, err := infrastructures.GetAWSRegion(ctx, w.Client)
+		if err != nil {
+			return admission.Warnings{fmt.Sprintf("skipped validating subnets and VPCs with AWS: %v", err)}, nil
+		}
+		region = r
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, awsValidationTimeout)
+	defer cancel()
+
+	awsClient, err := w.newAWSClient(ctx, region)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("skipped validating subnets and VPCs with AWS: %v", err)}, nil
+	}
+
+	var warnings admission.Warnings
+	allErrs := field.ErrorList{}
+
+	if len(vpce.Spec.Vpc.SubnetIds) > 0 {
+		subnetIdsPath := field.NewPath("spec", "vpc", "subnetIds")
+		subnets, err := awsClient.DescribeSubnetsById(ctx, vpce.Spec.Vpc.SubnetIds)
+		switch {
+		case isAWSNotFoundError(err, "InvalidSubnetID.NotFound"):
+			allErrs = append(allErrs, field.Invalid(subnetIdsPath, vpce.Spec.Vpc.SubnetIds, awsErrorMessage(err)))
+		case err != nil:
+			warnings = append(warnings, fmt.Sprintf("unable to validate %s with AWS: %v", subnetIdsPath, err))
+		default:
+			vpcIds := map[string]bool{}
+			subnetIdsByAz := map[string]string{}
+			for _, subnet := range subnets {
+				if subnet.VpcId != nil {
+					vpcIds[*subnet.VpcId] = true
+				}
+
+				if subnet.AvailabilityZone == nil || subnet.SubnetId == nil {
+					continue
+				}
+				if id, ok := subnetIdsByAz[*subnet.AvailabilityZone]; ok {
+					allErrs = append(allErrs, field.Invalid(subnetIdsPath, vpce.Spec.Vpc.SubnetIds,
+						fmt.Sprintf("subnets %s and %s are both in %s, each subnet must be in a different Availability Zone", id, *subnet.SubnetId, *subnet.AvailabilityZone)))
+				}
+				subnetIdsByAz[*subnet.AvailabilityZone] = *subnet.SubnetId
+			}
+
+			if len(vpcIds) > 1 {
+				allErrs = append(allErrs, field.Invalid(subnetIdsPath, vpce.Spec.Vpc.SubnetIds, "subnets must all be in the same VPC"))
+			}
+		}
+	}
+
+	if len(vpce.Spec.Vpc.Ids) > 0 {
+		idsPath := field.NewPath("spec", "vpc", "ids")
+		_, err := awsClient.DescribeVpcsById(ctx, vpce.Spec.Vpc.Ids)
+		switch {
+		case isAWSNotFoundError(err, "InvalidVpcID.NotFound"):
+			allErrs = append(allErrs, field.Invalid(idsPath, vpce.Spec.Vpc.Ids, awsErrorMessage(err)))
+		case err != nil:
+			warnings = append(warnings, fmt.Sprintf("unable to validate %s with AWS: %v", idsPath, err))
+		}
+	}
+
+	return warnings, allErrs
+}
+
+// newDefaultAWSClient returns a function returning an AWS client in the provided region using cfg, the operator's
+// default AWS config, whose credentials are shared by every region
+func newDefaultAWSClient(cfg aws.Config) func(ctx context.Context, region string) (*aws_client.AWSClient, error) {
+	return func(_ context.Context, region string) (*aws_client.AWSClient, error) {
+		regionCfg := cfg.Copy()
+		regionCfg.Region = region
+
+		return aws_client.NewAwsClient(regionCfg), nil
+	}
+}
+
+// isAWSNotFoundError returns true if err is an AWS API error with the provided error code
+func isAWSNotFoundError(err error, code string) bool {
+	var ae smithy.APIError
+	return errors.As(err, &ae) && ae.ErrorCode() == code
+}
+
+// awsErrorMessage returns the message of an AWS API error
+func awsErrorMessage(err error) string {
+	var ae smithy.APIError
+	if errors.As(err, &ae) {
+		return ae.ErrorMessage()
+	}
+
+	return err.Error()
+}
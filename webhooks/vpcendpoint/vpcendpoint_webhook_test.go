@@ -0,0 +1,449 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpcendpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/go-logr/logr/testr"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	avov1alpha2 "github.com/openshift/aws-vpce-operator/api/v1alpha2"
+	"github.com/openshift/aws-vpce-operator/pkg/aws_client"
+	"github.com/openshift/aws-vpce-operator/pkg/testutil"
+)
+
+// mockSubnetsEC2 returns the subnets and VPCs it contains, or NotFound errors like AWS
+type mockSubnetsEC2 struct {
+	aws_client.AvoEC2API
+
+	subnets []types.Subnet
+	vpcIds  []string
+}
+
+func (m *mockSubnetsEC2) DescribeSubnets(_ context.Context, params *ec2.DescribeSubnetsInput, _ ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	resp := &ec2.DescribeSubnetsOutput{}
+	for _, id := range params.SubnetIds {
+		found := false
+		for _, subnet := range m.subnets {
+			if *subnet.SubnetId == id {
+				resp.Subnets = append(resp.Subnets, subnet)
+				found = true
+			}
+		}
+		if !found {
+			return nil, &smithy.GenericAPIError{Code: "InvalidSubnetID.NotFound", Message: "The subnet ID '" + id + "' does not exist"}
+		}
+	}
+
+	return resp, nil
+}
+
+func (m *mockSubnetsEC2) DescribeVpcs(_ context.Context, params *ec2.DescribeVpcsInput, _ ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error) {
+	resp := &ec2.DescribeVpcsOutput{}
+	for _, id := range params.VpcIds {
+		found := false
+		for _, vpcId := range m.vpcIds {
+			if vpcId == id {
+				resp.Vpcs = append(resp.Vpcs, types.Vpc{VpcId: aws.String(id)})
+				found = true
+			}
+		}
+		if !found {
+			return nil, &smithy.GenericAPIError{Code: "InvalidVpcID.NotFound", Message: "The vpc ID '" + id + "' does not exist"}
+		}
+	}
+
+	return resp, nil
+}
+
+var mockInfrastructure = &configv1.Infrastructure{
+	ObjectMeta: metav1.ObjectMeta{
+		Name: "cluster",
+	},
+	Status: configv1.InfrastructureStatus{
+		PlatformStatus: &configv1.PlatformStatus{
+			Type: "AWS",
+			AWS: &configv1.AWSPlatformStatus{
+				Region: "us-east-1",
+			},
+		},
+	},
+}
+
+func newTestWebhook(t *testing.T, ec2Client aws_client.AvoEC2API) *VpcEndpointWebhook {
+	return &VpcEndpointWebhook{
+		Client:              testutil.NewTestMock(t, mockInfrastructure).Client,
+		EnableAWSValidation: ec2Client != nil,
+		log:                 testr.New(t),
+		newAWSClient: func(_ context.Context, _ string) (*aws_client.AWSClient, error) {
+			return aws_client.NewAwsClientWithServiceClients(ec2Client, nil), nil
+		},
+	}
+}
+
+func TestVpcEndpointWebhook_Default(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation admissionv1.Operation
+		spec      avov1alpha2.VpcEndpointSpec
+		expected  string
+	}{
+		{
+			name:      "defaults region on create",
+			operation: admissionv1.Create,
+			expected:  "us-east-1",
+		},
+		{
+			name:      "keeps region",
+			operation: admissionv1.Create,
+			spec:      avov1alpha2.VpcEndpointSpec{Region: "us-west-2"},
+			expected:  "us-west-2",
+		},
+		{
+			name:      "does not default region on update",
+			operation: admissionv1.Update,
+			expected:  "",
+		},
+		{
+			name:      "does not default region with autodiscovered subnets",
+			operation: admissionv1.Create,
+			spec:      avov1alpha2.VpcEndpointSpec{Vpc: avov1alpha2.Vpc{AutoDiscoverSubnets: true}},
+			expected:  "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := newTestWebhook(t, nil)
+			vpce := &avov1alpha2.VpcEndpoint{Spec: test.spec}
+			ctx := admission.NewContextWithRequest(context.TODO(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{Operation: test.operation},
+			})
+
+			assert.NoError(t, w.Default(ctx, vpce))
+			assert.Equal(t, test.expected, vpce.Spec.Region)
+		})
+	}
+}
+
+func TestVpcEndpointWebhook_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        avov1alpha2.VpcEndpointSpec
+		ec2Client   aws_client.AvoEC2API
+		expectWarn  bool
+		expectError bool
+	}{
+		{
+			name: "valid VPC Endpoint Service",
+			spec: avov1alpha2.VpcEndpointSpec{ServiceName: "com.amazonaws.vpce.us-east-1.vpce-svc-0123456789abcdef0"},
+		},
+		{
+			name: "valid AWS service",
+			spec: avov1alpha2.VpcEndpointSpec{ServiceName: "com.amazonaws.us-east-1.s3"},
+		},
+		{
+			name:        "invalid service name",
+			spec:        avov1alpha2.VpcEndpointSpec{ServiceName: "vpce-svc-0123456789abcdef0"},
+			expectError: true,
+		},
+		{
+			name:        "invalid service name ref",
+			spec:        avov1alpha2.VpcEndpointSpec{ServiceNameRef: &avov1alpha2.ServiceName{Name: "vpce-svc-0123456789abcdef0"}},
+			expectError: true,
+		},
+		{
+			name:       "unknown service name region",
+			spec:       avov1alpha2.VpcEndpointSpec{ServiceName: "com.amazonaws.vpce.us-fake-1.vpce-svc-0123456789abcdef0"},
+			expectWarn: true,
+		},
+		{
+			name:        "invalid service name region",
+			spec:        avov1alpha2.VpcEndpointSpec{ServiceName: "com.amazonaws.vpce.useast1.vpce-svc-0123456789abcdef0"},
+			expectError: true,
+		},
+		{
+			name:       "service name in another region",
+			spec:       avov1alpha2.VpcEndpointSpec{ServiceName: "com.amazonaws.vpce.us-east-1.vpce-svc-0123456789abcdef0", Region: "us-west-2"},
+			expectWarn: true,
+		},
+		{
+			name:       "unknown region",
+			spec:       avov1alpha2.VpcEndpointSpec{ServiceName: "com.amazonaws.us-east-1.s3", Region: "us-fake-1"},
+			expectWarn: true,
+		},
+		{
+			name:        "invalid region",
+			spec:        avov1alpha2.VpcEndpointSpec{ServiceName: "com.amazonaws.us-east-1.s3", Region: "US_EAST_1"},
+			expectWarn:  true,
+			expectError: true,
+		},
+		{
+			name: "duplicate subnets",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				Vpc:         avov1alpha2.Vpc{SubnetIds: []string{"subnet-1", "subnet-1"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "duplicate security groups",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName:   "com.amazonaws.us-east-1.s3",
+				SecurityGroup: avov1alpha2.SecurityGroup{Ids: []string{"sg-1", "sg-1"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "duplicate route tables",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				Type:        avov1alpha2.VpcEndpointTypeGateway,
+				RouteTables: avov1alpha2.RouteTables{Ids: []string{"rtb-1", "rtb-1"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "duplicate routes",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				Type:        avov1alpha2.VpcEndpointTypeGatewayLoadBalancer,
+				Vpc:         avov1alpha2.Vpc{SubnetIds: []string{"subnet-1"}},
+				Routes: []avov1alpha2.GatewayLoadBalancerRoute{
+					{RouteTableId: "rtb-1", DestinationCidrBlock: "0.0.0.0/0"},
+					{RouteTableId: "rtb-1", DestinationCidrBlock: "0.0.0.0/0"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "valid policy",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				Policy:      &avov1alpha2.Policy{Document: `{"Version":"2012-10-17","Statement":[]}`},
+			},
+		},
+		{
+			name: "invalid policy",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				Policy:      &avov1alpha2.Policy{Document: `{"Version":`},
+			},
+			expectError: true,
+		},
+		{
+			name: "duplicate associated VPCs",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				CustomDns: avov1alpha2.CustomDns{
+					Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+						AssociatedVpcs: []avov1alpha2.AssociatedVpc{
+							{VpcId: "vpc-1", Region: "us-east-1", CredentialsSecretRef: &corev1.SecretReference{Name: "creds"}},
+							{VpcId: "vpc-1", Region: "us-east-1", CredentialsSecretRef: &corev1.SecretReference{Name: "creds"}},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "multiple records with a wildcard",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				CustomDns: avov1alpha2.CustomDns{
+					Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+						Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "api", ExternalNameService: avov1alpha2.ExternalNameService{Name: "api"}},
+						Records: []avov1alpha2.Route53HostedZoneRecord{
+							{Hostname: "*.apps"},
+							{Hostname: "oauth", ExternalNameService: avov1alpha2.ExternalNameService{Name: "oauth"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "duplicate record hostnames",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				CustomDns: avov1alpha2.CustomDns{
+					Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+						Record:  avov1alpha2.Route53HostedZoneRecord{Hostname: "api"},
+						Records: []avov1alpha2.Route53HostedZoneRecord{{Hostname: "API"}},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "wildcard that is not the leftmost label",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				CustomDns: avov1alpha2.CustomDns{
+					Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+						Records: []avov1alpha2.Route53HostedZoneRecord{{Hostname: "apps.*"}},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "duplicate ExternalName services",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				CustomDns: avov1alpha2.CustomDns{
+					Route53PrivateHostedZone: avov1alpha2.Route53PrivateHostedZone{
+						Record: avov1alpha2.Route53HostedZoneRecord{Hostname: "api", ExternalNameService: avov1alpha2.ExternalNameService{Name: "svc"}},
+						Records: []avov1alpha2.Route53HostedZoneRecord{
+							{Hostname: "oauth", ExternalNameService: avov1alpha2.ExternalNameService{Name: "svc"}},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "user tags",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				Tags:        map[string]string{"cost-center": "1234"},
+			},
+		},
+		{
+			name: "reserved tag key",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				Tags:        map[string]string{"Name": "override"},
+			},
+			expectError: true,
+		},
+		{
+			name: "subnets in distinct availability zones",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				Vpc:         avov1alpha2.Vpc{SubnetIds: []string{"subnet-1", "subnet-2"}},
+			},
+			ec2Client: &mockSubnetsEC2{
+				subnets: []types.Subnet{
+					{SubnetId: aws.String("subnet-1"), AvailabilityZone: aws.String("us-east-1a"), VpcId: aws.String("vpc-1")},
+					{SubnetId: aws.String("subnet-2"), AvailabilityZone: aws.String("us-east-1b"), VpcId: aws.String("vpc-1")},
+				},
+			},
+		},
+		{
+			name: "subnets in the same availability zone",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				Vpc:         avov1alpha2.Vpc{SubnetIds: []string{"subnet-1", "subnet-2"}},
+			},
+			ec2Client: &mockSubnetsEC2{
+				subnets: []types.Subnet{
+					{SubnetId: aws.String("subnet-1"), AvailabilityZone: aws.String("us-east-1a"), VpcId: aws.String("vpc-1")},
+					{SubnetId: aws.String("subnet-2"), AvailabilityZone: aws.String("us-east-1a"), VpcId: aws.String("vpc-1")},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "subnets in different VPCs",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				Vpc:         avov1alpha2.Vpc{SubnetIds: []string{"subnet-1", "subnet-2"}},
+			},
+			ec2Client: &mockSubnetsEC2{
+				subnets: []types.Subnet{
+					{SubnetId: aws.String("subnet-1"), AvailabilityZone: aws.String("us-east-1a"), VpcId: aws.String("vpc-1")},
+					{SubnetId: aws.String("subnet-2"), AvailabilityZone: aws.String("us-east-1b"), VpcId: aws.String("vpc-2")},
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "subnet not found",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				Vpc:         avov1alpha2.Vpc{SubnetIds: []string{"subnet-1"}},
+			},
+			ec2Client:   &mockSubnetsEC2{},
+			expectError: true,
+		},
+		{
+			name: "vpc not found",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName: "com.amazonaws.us-east-1.s3",
+				Vpc:         avov1alpha2.Vpc{AutoDiscoverSubnets: true, Ids: []string{"vpc-1", "vpc-2"}},
+			},
+			ec2Client:   &mockSubnetsEC2{vpcIds: []string{"vpc-1"}},
+			expectError: true,
+		},
+		{
+			name: "skips AWS validation with credential overrides",
+			spec: avov1alpha2.VpcEndpointSpec{
+				ServiceName:   "com.amazonaws.us-east-1.s3",
+				AssumeRoleArn: "arn:aws:iam::123456789012:role/test",
+				Vpc:           avov1alpha2.Vpc{SubnetIds: []string{"subnet-1"}},
+			},
+			ec2Client:  &mockSubnetsEC2{},
+			expectWarn: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := newTestWebhook(t, test.ec2Client)
+			warnings, err := w.ValidateCreate(context.TODO(), &avov1alpha2.VpcEndpoint{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec:       test.spec,
+			})
+
+			if test.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectWarn, len(warnings) > 0, warnings)
+		})
+	}
+}
+
+func TestVpcEndpointWebhook_ValidateUpdate(t *testing.T) {
+	w := newTestWebhook(t, nil)
+	oldVpce := &avov1alpha2.VpcEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec:       avov1alpha2.VpcEndpointSpec{ServiceName: "invalid"},
+	}
+
+	// Metadata-only updates are allowed even if the existing spec is invalid
+	newVpce := oldVpce.DeepCopy()
+	newVpce.Finalizers = []string{}
+	_, err := w.ValidateUpdate(context.TODO(), oldVpce, newVpce)
+	assert.NoError(t, err)
+
+	newVpce.Spec.Region = "us-east-1"
+	_, err = w.ValidateUpdate(context.TODO(), oldVpce, newVpce)
+	assert.Error(t, err)
+}